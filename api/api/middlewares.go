@@ -4,9 +4,12 @@ import (
 	"log/slog"
 	"net/http"
 	"runtime/debug"
+	"strings"
 	"workflow-code-test/api/pkg/config"
+	"workflow-code-test/api/pkg/logging"
 	"workflow-code-test/api/pkg/render"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
 )
@@ -19,6 +22,44 @@ func JsonMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// requestIDHeader carries the ID correlating the logs of a request, sent by the client or generated.
+const requestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds the IDs accepted from clients, longer ones being replaced.
+const maxRequestIDLength = 128
+
+// RequestIDMiddleware adds the request ID to the context of requests, so that it is logged with every record
+// about them, and echoes it in the response. The X-Request-ID header of the client is kept if it is valid,
+// otherwise a new ID is generated.
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestIDHeader)
+		if !validRequestID(id) {
+			id = uuid.NewString()
+		}
+
+		w.Header().Set(requestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(logging.WithRequestID(r.Context(), id)))
+	})
+}
+
+// validRequestID reports whether the ID is short and only made of characters safe to log.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', strings.ContainsRune("-_.:", c):
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
 // RecoverMiddleware recovers from panics and logs them using the provided logger.
 func RecoverMiddleware(log *slog.Logger) mux.MiddlewareFunc {
 	return func(next http.Handler) http.Handler {
@@ -2,6 +2,9 @@ package api
 
 import (
 	"net/http"
+	"workflow-code-test/api/internal/nodetype"
+	"workflow-code-test/api/internal/schedule"
+	"workflow-code-test/api/internal/webhook"
 	"workflow-code-test/api/internal/workflow"
 	"workflow-code-test/api/pkg/di"
 
@@ -23,10 +26,58 @@ func (s *Service) LoadRoutes(parentRouter *mux.Router, isProduction bool) {
 	router.StrictSlash(false)
 	router.Use(JsonMiddleware)
 
-	repo := workflow.NewRepository(s.di.DbService.Pool())
-	svc := workflow.NewService(repo, s.di.NodeService, s.di.Logger)
-	wh := workflow.NewHandler(svc, s.di.Logger)
+	wh := workflow.NewHandler(s.di.WorkflowService, s.di.Logger)
 
+	router.HandleFunc("/import", wh.Import).Methods(http.MethodPost)
 	router.HandleFunc("/{id}", wh.Workflow).Methods(http.MethodGet)
+	router.HandleFunc("/{id}/export", wh.Export).Methods(http.MethodGet)
 	router.HandleFunc("/{id}/execute", wh.Execute).Methods(http.MethodPost)
+	router.HandleFunc("/{id}/events", wh.WorkflowEvents).Methods(http.MethodGet)
+
+	router.HandleFunc("/{id}/versions", wh.Versions).Methods(http.MethodGet)
+	router.HandleFunc("/{id}/versions/diff", wh.Diff).Methods(http.MethodGet)
+	router.HandleFunc("/{id}/versions/{version:[0-9]+}", wh.Version).Methods(http.MethodGet)
+	router.HandleFunc("/{id}/versions/{version:[0-9]+}/rollback", wh.Rollback).Methods(http.MethodPost)
+	router.HandleFunc("/{id}/draft", wh.Version).Methods(http.MethodGet)
+	router.HandleFunc("/{id}/draft", wh.SaveDraft).Methods(http.MethodPut)
+	router.HandleFunc("/{id}/draft/publish", wh.Publish).Methods(http.MethodPost)
+
+	sh := schedule.NewHandler(s.di.ScheduleService, s.di.Logger)
+
+	router.HandleFunc("/{id}/schedules", sh.Schedules).Methods(http.MethodGet)
+	router.HandleFunc("/{id}/schedules", sh.Create).Methods(http.MethodPost)
+	router.HandleFunc("/{id}/schedules/{scheduleId}", sh.Update).Methods(http.MethodPut)
+	router.HandleFunc("/{id}/schedules/{scheduleId}", sh.Delete).Methods(http.MethodDelete)
+
+	hh := webhook.NewHandler(s.di.WebhookService, s.di.Logger)
+
+	router.HandleFunc("/{id}/webhooks", hh.Webhooks).Methods(http.MethodGet)
+	router.HandleFunc("/{id}/webhooks", hh.Create).Methods(http.MethodPost)
+	router.HandleFunc("/{id}/webhooks/{webhookId}", hh.Delete).Methods(http.MethodDelete)
+
+	// Deliveries are public and authenticated by their signature
+	hooksRouter := parentRouter.PathPrefix("/hooks").Subrouter()
+	hooksRouter.Use(JsonMiddleware)
+
+	hooksRouter.HandleFunc("/{id}", hh.Receive).Methods(http.MethodPost)
+
+	executionsRouter := parentRouter.PathPrefix("/executions").Subrouter()
+	executionsRouter.Use(JsonMiddleware)
+
+	executionsRouter.HandleFunc("/{id}/resume", wh.Resume).Methods(http.MethodPost)
+	executionsRouter.HandleFunc("/{id}/retry", wh.Retry).Methods(http.MethodPost)
+	executionsRouter.HandleFunc("/{id}/cancel", wh.Cancel).Methods(http.MethodPost)
+	executionsRouter.HandleFunc("/{id}/rerun", wh.Rerun).Methods(http.MethodPost)
+	executionsRouter.HandleFunc("/{id}/events", wh.Events).Methods(http.MethodGet)
+	executionsRouter.HandleFunc("/{id}/debug", wh.Inspect).Methods(http.MethodGet)
+	executionsRouter.HandleFunc("/{id}/debug", wh.UpdateDebug).Methods(http.MethodPatch)
+	executionsRouter.HandleFunc("/{id}/debug/step", wh.Step).Methods(http.MethodPost)
+	executionsRouter.HandleFunc("/{id}/debug/continue", wh.Continue).Methods(http.MethodPost)
+
+	nodeTypesRouter := parentRouter.PathPrefix("/node-types").Subrouter()
+	nodeTypesRouter.Use(JsonMiddleware)
+
+	nh := nodetype.NewHandler(s.di.NodeService, s.di.Logger)
+
+	nodeTypesRouter.HandleFunc("", nh.NodeTypes).Methods(http.MethodGet)
 }
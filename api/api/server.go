@@ -5,10 +5,16 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
+	"workflow-code-test/api/internal/workflow"
 	"workflow-code-test/api/pkg/config"
 	"workflow-code-test/api/pkg/di"
+	"workflow-code-test/api/pkg/health"
+	"workflow-code-test/api/pkg/metrics"
+	"workflow-code-test/api/pkg/telemetry"
+	"workflow-code-test/api/pkg/version"
 
 	"github.com/gorilla/mux"
 )
@@ -28,9 +34,17 @@ func NewServer(di *di.Container, cfg *config.Config) *Server {
 func (s *Server) Start() {
 	container := s.di
 	mainRouter := mux.NewRouter()
+	mainRouter.Use(telemetry.Middleware)
+	mainRouter.Use(metrics.Middleware)
+	mainRouter.Use(RequestIDMiddleware)
 	mainRouter.Use(RecoverMiddleware(container.Logger))
 	mainRouter.Use(CorsMiddleware(s.cfg))
 
+	mainRouter.Handle("/metrics", metrics.Handler()).Methods(http.MethodGet)
+	mainRouter.Handle("/healthz", health.LiveHandler()).Methods(http.MethodGet)
+	mainRouter.Handle("/readyz", container.Health.ReadyHandler()).Methods(http.MethodGet)
+	mainRouter.Handle("/version", version.Handler()).Methods(http.MethodGet)
+
 	apiRouter := mainRouter.PathPrefix("/api/v1").Subrouter()
 
 	apiService, err := NewRouter(container)
@@ -46,6 +60,30 @@ func (s *Server) Start() {
 		Handler: mainRouter,
 	}
 
+	// Run background jobs until the server stops
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	var jobs sync.WaitGroup
+	defer func() {
+		stopJobs()
+		jobs.Wait()
+	}()
+
+	// Resume delayed executions
+	jobs.Add(1)
+	go func() {
+		defer jobs.Done()
+		workflow.RunTimers(jobsCtx, container.WorkflowService, s.cfg.Executions.TimerInterval, container.Logger)
+	}()
+
+	// Trigger scheduled executions, unless the scheduler command runs them
+	if s.cfg.Scheduler.Enabled {
+		jobs.Add(1)
+		go func() {
+			defer jobs.Done()
+			container.Scheduler.Run(jobsCtx)
+		}()
+	}
+
 	// Channel to listen for errors coming from the server
 	serverErrors := make(chan error, 1)
 
@@ -67,6 +105,11 @@ func (s *Server) Start() {
 	case sig := <-shutdown:
 		container.Logger.Info("Shutdown signal received", "signal", sig)
 
+		// Fail readiness while still serving, until the orchestrator stops sending requests
+		container.Health.Drain()
+		container.Logger.Info("Draining requests", "delay", s.cfg.Health.DrainDelay)
+		time.Sleep(s.cfg.Health.DrainDelay)
+
 		// Give outstanding requests 5 seconds to complete
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
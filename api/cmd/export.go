@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"workflow-code-test/api/pkg/di"
+	"workflow-code-test/api/pkg/document"
+
+	"github.com/spf13/cobra"
+)
+
+var exportOptions struct {
+	format string
+	output string
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export <workflow-id>",
+	Short: "export writes the published graph of a workflow to a JSON or YAML document",
+	Long: `export writes the published graph of a workflow to a JSON or YAML document, which the import command
+or the POST /workflows/import endpoint loads into another environment.
+The document is written to <workflow-id>.<format> unless --output is set, "-" writing it to stdout.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		format, err := document.ParseFormat(exportOptions.format)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		di := di.NewService()
+
+		container := di.Container(cmd.Context())
+		if container == nil {
+			log.Fatal("Failed to create container")
+		}
+		defer di.Shutdown(cmd.Context())
+
+		doc, err := container.WorkflowService.Export(cmd.Context(), args[0])
+		if err != nil {
+			log.Fatalf("Failed to export workflow %s: %v", args[0], err)
+		}
+
+		var buf bytes.Buffer
+		if err := document.Encode(&buf, doc, format); err != nil {
+			log.Fatal(err)
+		}
+
+		output := exportOptions.output
+		if output == "" {
+			output = fmt.Sprintf("%s.%s", args[0], format)
+		}
+
+		if output == "-" {
+			os.Stdout.Write(buf.Bytes())
+			return
+		}
+
+		if err := os.WriteFile(output, buf.Bytes(), 0o644); err != nil {
+			log.Fatal(err)
+		}
+		container.Logger.Info("Exported workflow", "id", args[0], "version", doc.Workflow.Version, "file", output)
+	},
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportOptions.format, "format", "f", string(document.FormatJSON), "document format, json or yaml")
+	exportCmd.Flags().StringVarP(&exportOptions.output, "output", "o", "", `file written, "-" for stdout`)
+
+	rootCmd.AddCommand(exportCmd)
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"errors"
+	"io"
+	"log"
+	"os"
+	"workflow-code-test/api/internal/workflow"
+	"workflow-code-test/api/pkg/di"
+	"workflow-code-test/api/pkg/document"
+
+	"github.com/spf13/cobra"
+)
+
+var importOptions struct {
+	format string
+	author string
+	remap  map[string]string
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "import creates a workflow from a JSON or YAML document",
+	Long: `import creates a workflow from a JSON or YAML document written by the export command, "-" reading it from stdin.
+The workflow is given a new ID and its graph is published as version 1.
+Workflows run by its nodes are mapped to the workflows of this environment with --remap <source-id>=<target-id>.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		format := document.FormatOf(args[0])
+		if importOptions.format != "" {
+			var err error
+			if format, err = document.ParseFormat(importOptions.format); err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		var r io.Reader = os.Stdin
+		if args[0] != "-" {
+			f, err := os.Open(args[0])
+			if err != nil {
+				log.Fatal(err)
+			}
+			defer f.Close()
+			r = f
+		}
+
+		doc, err := document.Decode(r, format)
+
+		var documentErr *document.ValidationError
+		if errors.As(err, &documentErr) {
+			log.Fatalf("Failed to read %s: %v", args[0], documentErr.Fields)
+		}
+		if err != nil {
+			log.Fatalf("Failed to read %s: %v", args[0], err)
+		}
+
+		di := di.NewService()
+
+		container := di.Container(cmd.Context())
+		if container == nil {
+			log.Fatal("Failed to create container")
+		}
+		defer di.Shutdown(cmd.Context())
+
+		result, err := container.WorkflowService.Import(cmd.Context(), doc, &workflow.ImportInput{
+			Author:      importOptions.author,
+			WorkflowIDs: importOptions.remap,
+		})
+
+		var validationErr *workflow.ValidationError
+		if errors.As(err, &validationErr) {
+			log.Fatalf("Failed to import %s: %v", args[0], validationErr.Fields)
+		}
+		if err != nil {
+			log.Fatalf("Failed to import %s: %v", args[0], err)
+		}
+
+		container.Logger.Info("Imported workflow", "id", result.WorkflowID, "name", result.Name, "workflowIds", result.WorkflowIDs)
+	},
+}
+
+func init() {
+	importCmd.Flags().StringVarP(&importOptions.format, "format", "f", "", "document format, json or yaml (default from the file extension)")
+	importCmd.Flags().StringVar(&importOptions.author, "author", "", "author of the imported version")
+	importCmd.Flags().StringToStringVar(&importOptions.remap, "remap", nil, "workflow IDs referenced by the document mapped to IDs of this environment, e.g. <source-id>=<target-id>")
+
+	rootCmd.AddCommand(importCmd)
+}
@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+	"workflow-code-test/api/internal/workflow"
+	"workflow-code-test/api/pkg/di"
+	"workflow-code-test/api/pkg/document"
+	"workflow-code-test/api/pkg/fixtures"
+	"workflow-code-test/api/pkg/logging"
+	"workflow-code-test/api/pkg/nodes/types"
+
+	"github.com/spf13/cobra"
+)
+
+// runFlags are the flags of the run command.
+type runFlags struct {
+	file     string
+	input    string
+	include  []string
+	fixtures string
+	record   bool
+	dryRun   bool
+	verbose  bool
+}
+
+var runOptions runFlags
+
+var runCmd = &cobra.Command{
+	Use:   "run",
+	Short: "run executes a workflow document locally, without Postgres",
+	Long: `run executes a workflow document, as written by the export command, with the same engine as the API.
+Workflows and executions are kept in memory, and each step is printed as it runs.
+The input file holds the body of the execute endpoint, e.g. {"formData": {"city": "Sydney"}}, "-" reading it from stdin.
+Workflows run by subworkflow and foreach nodes are loaded with --include.
+
+Emails are logged by the noop mailer rather than sent. With --fixtures, the weather-api node replays the responses
+recorded in the file instead of calling OpenStreetMap and OpenWeather; --record calls them for the responses missing
+from the file and saves them to it. Delay nodes are waited for, while approvals end the run.
+
+With --dry-run, emails, HTTP requests and plugins are simulated and delays are not waited for. The input file may
+then mock the output of nodes by ID, e.g. {"mocks": {"weather-api": {"temperature": 30}}}.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		if err := runDocument(ctx, &runOptions, di.DefaultIntegrations(), os.Stdin, os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+// runDocument runs the workflow document of the flags, reading the input from stdin if it is "-" and printing
+// each step to w. Nodes call the services through integrations, unless their responses are replayed from fixtures.
+func runDocument(ctx context.Context, flags *runFlags, integrations *di.Integrations, stdin io.Reader, w io.Writer) error {
+	if flags.record && flags.fixtures == "" {
+		return errors.New("--record requires --fixtures")
+	}
+
+	level := "warn"
+	if flags.verbose {
+		level = "debug"
+	}
+	logger, err := logging.New(&logging.Options{Level: level, Writer: os.Stderr})
+	if err != nil {
+		return err
+	}
+
+	recorded, err := runIntegrations(flags, integrations)
+	if err != nil {
+		return err
+	}
+
+	repo := workflow.NewMemoryRepository()
+	container := di.LocalContainer(repo, integrations, logger)
+
+	// Included workflows are loaded first, so references to them are remapped to their new IDs
+	workflowIDs := map[string]string{}
+	for _, path := range flags.include {
+		if _, err := loadWorkflow(ctx, container.WorkflowService, path, stdin, workflowIDs); err != nil {
+			return err
+		}
+	}
+	result, err := loadWorkflow(ctx, container.WorkflowService, flags.file, stdin, workflowIDs)
+	if err != nil {
+		return err
+	}
+
+	input := &workflow.ExecutionInput{}
+	if flags.input != "" {
+		b, err := readFile(flags.input, stdin)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(b, input); err != nil {
+			return fmt.Errorf("failed to read %s: %w", flags.input, err)
+		}
+	}
+
+	start := time.Now()
+	ctx = workflow.WithStepHook(ctx, func(ctx context.Context, executionID string, step workflow.Step) {
+		printStep(ctx, w, step)
+	})
+	if flags.dryRun {
+		ctx = workflow.WithDryRun(ctx)
+	}
+
+	execution, err := container.WorkflowService.Execute(ctx, result.WorkflowID, input)
+	for err == nil && execution.Status == workflow.ExecutionStatusWaiting && execution.ResumeAt != nil {
+		fmt.Fprintf(w, "waiting until %s\n", execution.ResumeAt.Format(time.RFC3339))
+		if err = sleepUntil(ctx, *execution.ResumeAt); err != nil {
+			break
+		}
+
+		if _, err = container.WorkflowService.ResumeDue(ctx); err != nil {
+			break
+		}
+
+		var resumed *workflow.Execution
+		if resumed, err = repo.Execution(ctx, execution.ExecutionID); err == nil {
+			execution = resumed.Result()
+		}
+	}
+
+	if recorded != nil {
+		if err := recorded.Save(flags.fixtures); err != nil {
+			return fmt.Errorf("failed to save fixtures: %w", err)
+		}
+	}
+
+	var validationErr *workflow.ValidationError
+	if errors.As(err, &validationErr) {
+		return fmt.Errorf("invalid input: %v", validationErr.Fields)
+	}
+	if execution == nil {
+		return fmt.Errorf("failed to run %s: %w", flags.file, err)
+	}
+
+	fmt.Fprintf(w, "execution %s %s in %s\n", execution.ExecutionID, execution.Status, time.Since(start).Round(time.Millisecond))
+	switch {
+	case err != nil:
+		return err
+	case execution.Status == workflow.ExecutionStatusWaiting && execution.WaitingFor != nil:
+		fmt.Fprintf(w, "waiting for input: %s\n", execution.WaitingFor.Message)
+	}
+
+	return nil
+}
+
+// runIntegrations replaces the clients of integrations called by nodes with the fixtures of the flags, if any.
+// It returns the fixtures to save once the run ends if responses are recorded.
+func runIntegrations(flags *runFlags, integrations *di.Integrations) (*fixtures.Fixtures, error) {
+	if flags.fixtures == "" {
+		return nil, nil
+	}
+
+	f, err := fixtures.Load(flags.fixtures)
+	if errors.Is(err, os.ErrNotExist) && flags.record {
+		f, err = fixtures.New(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fixtures: %w", err)
+	}
+
+	if !flags.record {
+		integrations.GeoClient = f.GeoClient(nil)
+		integrations.WeatherClient = f.WeatherClient(nil)
+		return nil, nil
+	}
+
+	integrations.GeoClient = f.GeoClient(integrations.GeoClient)
+	integrations.WeatherClient = f.WeatherClient(integrations.WeatherClient)
+	return f, nil
+}
+
+// loadWorkflow imports the workflow document at path into the engine, remapping the workflows it references.
+// The ID of the imported workflow is added to workflowIDs, so the workflows loaded next can run it.
+func loadWorkflow(ctx context.Context, svc workflow.Service, path string, stdin io.Reader, workflowIDs map[string]string) (*workflow.ImportResult, error) {
+	b, err := readFile(path, stdin)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := document.Decode(bytes.NewReader(b), document.FormatOf(path))
+
+	var documentErr *document.ValidationError
+	if errors.As(err, &documentErr) {
+		return nil, fmt.Errorf("failed to read %s: %v", path, documentErr.Fields)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	result, err := svc.Import(ctx, doc, &workflow.ImportInput{WorkflowIDs: workflowIDs})
+
+	var validationErr *workflow.ValidationError
+	if errors.As(err, &validationErr) {
+		return nil, fmt.Errorf("failed to load %s: %v", path, validationErr.Fields)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", path, err)
+	}
+
+	if doc.Workflow.ID != "" {
+		workflowIDs[doc.Workflow.ID] = result.WorkflowID
+	}
+
+	return result, nil
+}
+
+// printStep prints a step to w as it runs. Steps of the workflows run by subworkflow and foreach nodes are indented.
+func printStep(ctx context.Context, w io.Writer, step workflow.Step) {
+	line := fmt.Sprintf("%s%-12s %-10s %s", strings.Repeat("  ", types.Depth(ctx)), step.NodeID, step.Status, step.Label)
+	if len(step.Output) > 0 {
+		output, _ := json.Marshal(step.Output)
+		line += " " + string(output)
+	}
+	if step.DurationMs > 0 {
+		line += fmt.Sprintf(" in %s", time.Duration(step.DurationMs)*time.Millisecond)
+	}
+	if step.Mocked {
+		line += " (mocked)"
+	}
+	if len(step.Simulation) > 0 {
+		simulation, _ := json.Marshal(step.Simulation)
+		line += " simulated " + string(simulation)
+	}
+
+	fmt.Fprintln(w, line)
+}
+
+// readFile reads a file, "-" reading stdin.
+func readFile(path string, stdin io.Reader) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(stdin)
+	}
+
+	return os.ReadFile(path)
+}
+
+func sleepUntil(ctx context.Context, t time.Time) error {
+	timer := time.NewTimer(time.Until(t))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func init() {
+	runCmd.Flags().StringVarP(&runOptions.file, "file", "f", "", "workflow document to run, JSON or YAML")
+	runCmd.Flags().StringVarP(&runOptions.input, "input", "i", "", `JSON file with the execution input, "-" for stdin`)
+	runCmd.Flags().StringArrayVar(&runOptions.include, "include", nil, "workflow document run by the workflow's subworkflow or foreach nodes, repeatable")
+	runCmd.Flags().StringVar(&runOptions.fixtures, "fixtures", "", "JSON file of recorded responses replayed by the weather-api node")
+	runCmd.Flags().BoolVar(&runOptions.record, "record", false, "call the services for responses missing from --fixtures and save them")
+	runCmd.Flags().BoolVar(&runOptions.dryRun, "dry-run", false, "simulate nodes with side effects and apply the mocks of the input")
+	runCmd.Flags().BoolVarP(&runOptions.verbose, "verbose", "v", false, "log the engine's debug output to stderr")
+	runCmd.MarkFlagRequired("file")
+
+	rootCmd.AddCommand(runCmd)
+}
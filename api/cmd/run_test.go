@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"workflow-code-test/api/pkg/di"
+	"workflow-code-test/api/pkg/fixtures"
+	"workflow-code-test/api/pkg/mailer"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// weatherDocument reads the temperature of the city
+	weatherDocument = `version: 1
+workflow:
+  id: 6f1c2f3e-8a4b-4c1d-9e2f-3a4b5c6d7e8f
+  name: Weather
+nodes:
+  - id: start
+    type: start
+  - id: weather-api
+    type: weather-api
+    data:
+      metadata:
+        inputVariables: [city]
+        outputVariables: [temperature]
+  - id: end
+    type: end
+edges:
+  - {source: start, target: weather-api}
+  - {source: weather-api, target: end}
+`
+
+	// forecastDocument runs the weather document
+	forecastDocument = `version: 1
+workflow:
+  id: 0d9e8f7a-6b5c-4d3e-8f1a-2b3c4d5e6f7a
+  name: Forecast
+nodes:
+  - id: start
+    type: start
+  - id: weather
+    type: subworkflow
+    data:
+      metadata:
+        workflowId: 6f1c2f3e-8a4b-4c1d-9e2f-3a4b5c6d7e8f
+        inputVariables: [city]
+        outputVariables: [temperature]
+  - id: end
+    type: end
+edges:
+  - {source: start, target: weather}
+  - {source: weather, target: end}
+`
+
+	// delayedDocument runs the forecast document after a delay
+	delayedDocument = `version: 1
+workflow:
+  name: Delayed forecast
+nodes:
+  - id: start
+    type: start
+  - id: delay
+    type: delay
+    data:
+      metadata:
+        duration: 1s
+  - id: forecast
+    type: subworkflow
+    data:
+      metadata:
+        workflowId: 0d9e8f7a-6b5c-4d3e-8f1a-2b3c4d5e6f7a
+        inputVariables: [city]
+        outputVariables: [temperature]
+  - id: end
+    type: end
+edges:
+  - {source: start, target: delay}
+  - {source: delay, target: forecast}
+  - {source: forecast, target: end}
+`
+)
+
+type mockGeoClient struct {
+	calls int
+}
+
+func (m *mockGeoClient) LatLngByCity(ctx context.Context, city string) (float64, float64, error) {
+	m.calls++
+	return -33.8688, 151.2093, nil
+}
+
+type mockWeatherClient struct {
+	calls int
+}
+
+func (m *mockWeatherClient) TemperatureInCelsiusByLatLng(ctx context.Context, lat, lng float64) (float64, error) {
+	m.calls++
+	return 28.5, nil
+}
+
+// writeFiles writes the files to a temporary directory, returning their paths by name.
+func writeFiles(t *testing.T, files map[string]string) map[string]string {
+	t.Helper()
+
+	dir := t.TempDir()
+	paths := map[string]string{}
+	for name, content := range files {
+		paths[name] = filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(paths[name], []byte(content), 0o644))
+	}
+
+	return paths
+}
+
+// stepDurations matches the durations of the run and of its steps, which vary between runs.
+var stepDurations = regexp.MustCompile(` in [0-9.]+[nµm]?s`)
+
+func TestRunDocument(t *testing.T) {
+	paths := writeFiles(t, map[string]string{
+		"weather.yaml":  weatherDocument,
+		"forecast.yaml": forecastDocument,
+		"delayed.yaml":  delayedDocument,
+		"input.json":    `{"formData": {"city": "Sydney"}}`,
+	})
+	flags := &runFlags{
+		file:     paths["delayed.yaml"],
+		input:    paths["input.json"],
+		include:  []string{paths["weather.yaml"], paths["forecast.yaml"]},
+		fixtures: filepath.Join(t.TempDir(), "fixtures.json"),
+		record:   true,
+	}
+	geo, weather := &mockGeoClient{}, &mockWeatherClient{}
+	integrations := func() *di.Integrations {
+		return &di.Integrations{GeoClient: geo, WeatherClient: weather, MailClient: mailer.NewNoopClient()}
+	}
+
+	// The responses missing from the fixtures are recorded
+	var out bytes.Buffer
+	require.NoError(t, runDocument(context.Background(), flags, integrations(), nil, &out))
+	require.Equal(t, 1, geo.calls)
+	require.Equal(t, 1, weather.calls)
+
+	recorded, err := fixtures.Load(flags.fixtures)
+	require.NoError(t, err)
+	require.Equal(t, map[string]fixtures.Coordinates{"Sydney": {Lat: -33.8688, Lng: 151.2093}}, recorded.Cities)
+	require.Equal(t, map[string]float64{"-33.8688,151.2093": 28.5}, recorded.Temperatures)
+
+	// The run waits for the delay, then runs the included workflows, whose steps are indented
+	lines := strings.Split(strings.TrimSuffix(stepDurations.ReplaceAllString(out.String(), ""), "\n"), "\n")
+	require.Len(t, lines, 13)
+	require.Regexp(t, `^waiting until \S+$`, lines[2])
+	require.Regexp(t, `^execution [0-9a-f-]{36} completed$`, lines[12])
+	lines[2], lines[12] = "", ""
+	require.Equal(t, []string{
+		"start        completed  start",
+		"delay        waiting    ",
+		"",
+		"delay        completed  ",
+		"  start        completed  start",
+		"    start        completed  start",
+		`    weather-api  completed   {"temperature":28.5}`,
+		"    end          completed  end",
+		`  weather      completed   {"temperature":28.5}`,
+		"  end          completed  end",
+		`forecast     completed   {"temperature":28.5}`,
+		"end          completed  end",
+		"",
+	}, lines)
+
+	// The recorded responses are replayed without calling the services
+	flags.record = false
+	var replayed bytes.Buffer
+	require.NoError(t, runDocument(context.Background(), flags, integrations(), nil, &replayed))
+	require.Equal(t, 1, geo.calls)
+	require.Equal(t, 1, weather.calls)
+	require.Contains(t, replayed.String(), `forecast     completed   {"temperature":28.5}`)
+}
+
+func TestRunDocument_Errors(t *testing.T) {
+	paths := writeFiles(t, map[string]string{
+		"weather.yaml":  weatherDocument,
+		"forecast.yaml": forecastDocument,
+		"delayed.yaml":  delayedDocument,
+	})
+
+	tests := []struct {
+		name          string
+		flags         *runFlags
+		expectedError string
+	}{
+		{
+			name: "workflow included after the workflow running it",
+			flags: &runFlags{
+				file:    paths["delayed.yaml"],
+				include: []string{paths["forecast.yaml"], paths["weather.yaml"]},
+			},
+			expectedError: "failed to load " + paths["forecast.yaml"] + ": map[nodes.weather:workflow 6f1c2f3e-8a4b-4c1d-9e2f-3a4b5c6d7e8f does not exist, map it to a workflow of this environment]",
+		},
+		{
+			name:          "recording without fixtures",
+			flags:         &runFlags{file: paths["delayed.yaml"], record: true},
+			expectedError: "--record requires --fixtures",
+		},
+		{
+			name:          "missing fixtures",
+			flags:         &runFlags{file: paths["delayed.yaml"], fixtures: filepath.Join(t.TempDir(), "fixtures.json")},
+			expectedError: "failed to load fixtures",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := runDocument(context.Background(), tt.flags, di.DefaultIntegrations(), nil, &out)
+			require.ErrorContains(t, err, tt.expectedError)
+			require.Empty(t, out.String())
+		})
+	}
+}
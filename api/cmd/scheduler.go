@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"workflow-code-test/api/pkg/di"
+
+	"github.com/spf13/cobra"
+)
+
+var schedulerCmd = &cobra.Command{
+	Use:   "scheduler",
+	Short: "scheduler triggers the executions of scheduled workflows without serving the API",
+	Long: `scheduler triggers the executions of scheduled workflows without serving the API.
+Run the api command with SCHEDULER_ENABLED=false when the scheduler runs as a separate process.
+Several schedulers may run at once, only the elected leader triggers executions.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		di := di.NewService()
+
+		container := di.Container(cmd.Context())
+		if container == nil {
+			log.Fatal("Failed to create container")
+		}
+		defer di.Shutdown(cmd.Context())
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		container.Logger.Info("Starting scheduler")
+		container.Scheduler.Run(ctx)
+		container.Logger.Info("Scheduler stopped")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(schedulerCmd)
+}
@@ -0,0 +1,25 @@
+package nodetype
+
+import (
+	"log/slog"
+	"net/http"
+	"workflow-code-test/api/pkg/nodes"
+	"workflow-code-test/api/pkg/render"
+)
+
+type HandlerImpl struct {
+	nodeService *nodes.Service
+	log         *slog.Logger
+}
+
+// NodeTypes implements Handler.
+func (h *HandlerImpl) NodeTypes(w http.ResponseWriter, r *http.Request) {
+	render.JSON(w, r, http.StatusOK, h.nodeService.Descriptors())
+}
+
+func NewHandler(nodeService *nodes.Service, log *slog.Logger) Handler {
+	return &HandlerImpl{
+		nodeService: nodeService,
+		log:         log,
+	}
+}
@@ -0,0 +1,11 @@
+package nodetype
+
+import "net/http"
+
+// Handler is an interface that defines HTTP handler functions for listing the node types
+// available to workflows.
+type Handler interface {
+	// NodeTypes handles HTTP requests listing the descriptor of every available node type,
+	// so editors can show which inputs, outputs and metadata each node kind accepts.
+	NodeTypes(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,13 @@
+package schedule
+
+import "time"
+
+// SetClock sets the clock the service computes the next runs of schedules with.
+func SetClock(svc Service, now func() time.Time) {
+	svc.(*ServiceImpl).now = now
+}
+
+// SetClock sets the clock the scheduler checks for due schedules with.
+func (s *Scheduler) SetClock(now func() time.Time) {
+	s.now = now
+}
@@ -0,0 +1,135 @@
+package schedule
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"workflow-code-test/api/internal/workflow"
+	"workflow-code-test/api/pkg/render"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type HandlerImpl struct {
+	svc Service
+	log *slog.Logger
+}
+
+// Schedules implements Handler.
+func (h *HandlerImpl) Schedules(w http.ResponseWriter, r *http.Request) {
+	workflowID, ok := h.workflowID(w, r)
+	if !ok {
+		return
+	}
+
+	schedules, err := h.svc.Schedules(r.Context(), workflowID)
+	if err != nil {
+		h.renderError(w, r, err)
+		return
+	}
+
+	render.JSON(w, r, http.StatusOK, schedules)
+}
+
+// Create implements Handler.
+func (h *HandlerImpl) Create(w http.ResponseWriter, r *http.Request) {
+	workflowID, ok := h.workflowID(w, r)
+	if !ok {
+		return
+	}
+
+	var input ScheduleInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		render.Error(w, r, http.StatusBadRequest, err, h.log)
+		return
+	}
+
+	schedule, err := h.svc.Create(r.Context(), workflowID, &input)
+	if err != nil {
+		h.renderError(w, r, err)
+		return
+	}
+
+	render.JSON(w, r, http.StatusCreated, schedule)
+}
+
+// Update implements Handler.
+func (h *HandlerImpl) Update(w http.ResponseWriter, r *http.Request) {
+	workflowID, ok := h.workflowID(w, r)
+	if !ok {
+		return
+	}
+
+	var input ScheduleInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		render.Error(w, r, http.StatusBadRequest, err, h.log)
+		return
+	}
+
+	schedule, err := h.svc.Update(r.Context(), workflowID, mux.Vars(r)["scheduleId"], &input)
+	if err != nil {
+		h.renderError(w, r, err)
+		return
+	}
+
+	render.JSON(w, r, http.StatusOK, schedule)
+}
+
+// Delete implements Handler.
+func (h *HandlerImpl) Delete(w http.ResponseWriter, r *http.Request) {
+	workflowID, ok := h.workflowID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.Delete(r.Context(), workflowID, mux.Vars(r)["scheduleId"]); err != nil {
+		h.renderError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// workflowID returns the validated workflow ID of the request, rendering an error if it is invalid.
+// The schedule ID, when present, is validated as well.
+func (h *HandlerImpl) workflowID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	vars := mux.Vars(r)
+
+	if err := uuid.Validate(vars["id"]); err != nil {
+		h.log.ErrorContext(r.Context(), "problem validating workflow id", slog.Any("ID", vars["id"]), slog.Any("ERROR", err))
+		render.Error(w, r, http.StatusBadRequest, render.ErrInvalidWorkflowID, h.log)
+		return "", false
+	}
+
+	if scheduleID, ok := vars["scheduleId"]; ok {
+		if err := uuid.Validate(scheduleID); err != nil {
+			h.log.ErrorContext(r.Context(), "problem validating schedule id", slog.Any("ID", scheduleID), slog.Any("ERROR", err))
+			render.Error(w, r, http.StatusBadRequest, render.ErrInvalidScheduleID, h.log)
+			return "", false
+		}
+	}
+
+	return vars["id"], true
+}
+
+func (h *HandlerImpl) renderError(w http.ResponseWriter, r *http.Request, err error) {
+	var validationErr *workflow.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		render.ValidationError(w, r, validationErr.Fields, h.log)
+	case errors.Is(err, ErrScheduleNotFound), errors.Is(err, ErrWorkflowNotFound):
+		render.Error(w, r, http.StatusNotFound, render.ErrNotFound, h.log)
+	default:
+		h.log.ErrorContext(r.Context(), "problem managing schedules", slog.Any("ERROR", err))
+		render.Error(w, r, http.StatusInternalServerError, err, h.log)
+	}
+}
+
+func NewHandler(svc Service, log *slog.Logger) Handler {
+	return &HandlerImpl{
+		svc: svc,
+		log: log,
+	}
+}
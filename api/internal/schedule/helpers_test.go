@@ -0,0 +1,235 @@
+package schedule_test
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+	"workflow-code-test/api/internal/schedule"
+	"workflow-code-test/api/internal/workflow"
+)
+
+// fakeClock is a clock that only moves when the test advances it.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newClock(now string) *fakeClock {
+	t, err := time.Parse(time.RFC3339, now)
+	if err != nil {
+		panic(err)
+	}
+
+	return &fakeClock{now: t}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// Set moves the clock to now.
+func (c *fakeClock) Set(now string) {
+	t, err := time.Parse(time.RFC3339, now)
+	if err != nil {
+		panic(err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
+
+// fakeRepository stores schedules in memory. Its leader lock is held by at most one scheduler at a time.
+type fakeRepository struct {
+	mu        sync.Mutex
+	schedules []*schedule.Schedule
+	leader    *fakeLock
+}
+
+// Schedules implements schedule.Repository.
+func (r *fakeRepository) Schedules(ctx context.Context, workflowID string) ([]schedule.Schedule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	schedules := []schedule.Schedule{}
+	for _, s := range r.schedules {
+		if s.WorkflowID == workflowID {
+			schedules = append(schedules, *s)
+		}
+	}
+
+	return schedules, nil
+}
+
+// Schedule implements schedule.Repository.
+func (r *fakeRepository) Schedule(ctx context.Context, workflowID string, scheduleID string) (*schedule.Schedule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, s := range r.schedules {
+		if s.WorkflowID == workflowID && s.ID == scheduleID {
+			stored := *s
+			return &stored, nil
+		}
+	}
+
+	return nil, schedule.ErrScheduleNotFound
+}
+
+// CreateSchedule implements schedule.Repository.
+func (r *fakeRepository) CreateSchedule(ctx context.Context, s *schedule.Schedule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s.ID = strconv.Itoa(len(r.schedules) + 1)
+	stored := *s
+	stored.InputData = maps.Clone(s.InputData)
+	r.schedules = append(r.schedules, &stored)
+
+	return nil
+}
+
+// UpdateSchedule implements schedule.Repository.
+func (r *fakeRepository) UpdateSchedule(ctx context.Context, s *schedule.Schedule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, stored := range r.schedules {
+		if stored.WorkflowID == s.WorkflowID && stored.ID == s.ID {
+			updated := *s
+			r.schedules[i] = &updated
+			return nil
+		}
+	}
+
+	return schedule.ErrScheduleNotFound
+}
+
+// DeleteSchedule implements schedule.Repository.
+func (r *fakeRepository) DeleteSchedule(ctx context.Context, workflowID string, scheduleID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, s := range r.schedules {
+		if s.WorkflowID == workflowID && s.ID == scheduleID {
+			r.schedules = slices.Delete(r.schedules, i, i+1)
+			return nil
+		}
+	}
+
+	return schedule.ErrScheduleNotFound
+}
+
+// DueSchedules implements schedule.Repository.
+func (r *fakeRepository) DueSchedules(ctx context.Context, now time.Time) ([]schedule.Schedule, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	schedules := []schedule.Schedule{}
+	for _, s := range r.schedules {
+		if s.Enabled && s.NextRunAt != nil && !s.NextRunAt.After(now) {
+			schedules = append(schedules, *s)
+		}
+	}
+
+	return schedules, nil
+}
+
+// AdvanceSchedule implements schedule.Repository.
+func (r *fakeRepository) AdvanceSchedule(ctx context.Context, s *schedule.Schedule, runAt time.Time, nextRunAt time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, stored := range r.schedules {
+		if stored.ID == s.ID && stored.Enabled && stored.NextRunAt != nil && s.NextRunAt != nil && stored.NextRunAt.Equal(*s.NextRunAt) {
+			stored.LastRunAt = &runAt
+			stored.NextRunAt = &nextRunAt
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// TryLeaderLock implements schedule.Repository.
+func (r *fakeRepository) TryLeaderLock(ctx context.Context) (schedule.Lock, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.leader != nil {
+		return nil, nil
+	}
+
+	r.leader = &fakeLock{repo: r}
+	return r.leader, nil
+}
+
+// hasLeader reports whether a scheduler holds the leader lock.
+func (r *fakeRepository) hasLeader() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.leader != nil
+}
+
+// stored returns the stored state of a schedule.
+func (r *fakeRepository) stored(scheduleID string) schedule.Schedule {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, s := range r.schedules {
+		if s.ID == scheduleID {
+			return *s
+		}
+	}
+
+	return schedule.Schedule{}
+}
+
+type fakeLock struct {
+	repo *fakeRepository
+}
+
+func (l *fakeLock) Held(ctx context.Context) bool {
+	l.repo.mu.Lock()
+	defer l.repo.mu.Unlock()
+
+	return l.repo.leader == l
+}
+
+func (l *fakeLock) Release(ctx context.Context) {
+	l.repo.mu.Lock()
+	defer l.repo.mu.Unlock()
+
+	if l.repo.leader == l {
+		l.repo.leader = nil
+	}
+}
+
+// fakeExecutor records the executions it is asked to start.
+type fakeExecutor struct {
+	mu     sync.Mutex
+	inputs []map[string]any
+}
+
+func (e *fakeExecutor) Execute(ctx context.Context, workflowID string, input *workflow.ExecutionInput) (*workflow.ExecutionResult, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.inputs = append(e.inputs, input.FormData)
+	return &workflow.ExecutionResult{ExecutionID: strconv.Itoa(len(e.inputs)), Status: workflow.ExecutionStatusCompleted}, nil
+}
+
+// Inputs returns the form data of every execution started so far.
+func (e *fakeExecutor) Inputs() []map[string]any {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return slices.Clone(e.inputs)
+}
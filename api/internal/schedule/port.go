@@ -0,0 +1,88 @@
+package schedule
+
+import (
+	"context"
+	"net/http"
+	"time"
+	"workflow-code-test/api/internal/workflow"
+)
+
+// Service defines the interface for managing the cron schedules triggering workflow executions.
+type Service interface {
+	// Schedules lists the schedules of a workflow, oldest first.
+	Schedules(ctx context.Context, workflowID string) ([]Schedule, error)
+
+	// Create adds a schedule to a workflow and computes its first run.
+	// Returns a *workflow.ValidationError if the cron expression or timezone is invalid,
+	// or ErrWorkflowNotFound if the workflow does not exist.
+	Create(ctx context.Context, workflowID string, input *ScheduleInput) (*Schedule, error)
+
+	// Update replaces the cron expression, timezone, input data and enabled flag of a schedule,
+	// and recomputes its next run.
+	// Returns a *workflow.ValidationError if the input is invalid, or ErrScheduleNotFound.
+	Update(ctx context.Context, workflowID string, scheduleID string, input *ScheduleInput) (*Schedule, error)
+
+	// Delete removes a schedule. Returns ErrScheduleNotFound if it does not exist.
+	Delete(ctx context.Context, workflowID string, scheduleID string) error
+}
+
+// Repository is an interface that provides methods to store schedules and coordinate schedulers.
+type Repository interface {
+	// Schedules retrieves the schedules of a workflow, oldest first.
+	Schedules(ctx context.Context, workflowID string) ([]Schedule, error)
+
+	// Schedule retrieves a schedule of a workflow. Returns ErrScheduleNotFound if it does not exist.
+	Schedule(ctx context.Context, workflowID string, scheduleID string) (*Schedule, error)
+
+	// CreateSchedule persists a new schedule and sets its ID, CreatedAt and UpdatedAt.
+	// Returns ErrWorkflowNotFound if the workflow does not exist.
+	CreateSchedule(ctx context.Context, schedule *Schedule) error
+
+	// UpdateSchedule saves the cron expression, timezone, input data, enabled flag and next run of a schedule.
+	// Returns ErrScheduleNotFound if it does not exist.
+	UpdateSchedule(ctx context.Context, schedule *Schedule) error
+
+	// DeleteSchedule removes a schedule. Returns ErrScheduleNotFound if it does not exist.
+	DeleteSchedule(ctx context.Context, workflowID string, scheduleID string) error
+
+	// DueSchedules retrieves the enabled schedules whose next run is not after now, earliest first.
+	DueSchedules(ctx context.Context, now time.Time) ([]Schedule, error)
+
+	// AdvanceSchedule records a run of the schedule at runAt and moves its next run to nextRunAt,
+	// provided its next run is still schedule.NextRunAt. Returns false if the schedule changed meanwhile,
+	// in which case the run must not be triggered.
+	AdvanceSchedule(ctx context.Context, schedule *Schedule, runAt time.Time, nextRunAt time.Time) (bool, error)
+
+	// TryLeaderLock attempts to take the lock electing the scheduler leader among all API instances.
+	// Returns a nil Lock, without error, if another instance holds it.
+	TryLeaderLock(ctx context.Context) (Lock, error)
+}
+
+// Lock is a held leader lock. It is bound to a database session, and is lost with it.
+type Lock interface {
+	// Held reports whether the lock is still held.
+	Held(ctx context.Context) bool
+
+	// Release releases the lock.
+	Release(ctx context.Context)
+}
+
+// Executor starts workflow executions. It is implemented by workflow.Service.
+type Executor interface {
+	Execute(ctx context.Context, workflowID string, input *workflow.ExecutionInput) (*workflow.ExecutionResult, error)
+}
+
+// Handler is an interface that defines HTTP handler functions for managing workflow schedules.
+type Handler interface {
+	// Schedules handles HTTP requests listing the schedules of a workflow.
+	Schedules(w http.ResponseWriter, r *http.Request)
+
+	// Create handles HTTP requests adding a schedule to a workflow.
+	Create(w http.ResponseWriter, r *http.Request)
+
+	// Update handles HTTP requests replacing a schedule, e.g. to disable it.
+	Update(w http.ResponseWriter, r *http.Request)
+
+	// Delete handles HTTP requests removing a schedule.
+	Delete(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,260 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// leaderLockKey identifies the advisory lock electing the scheduler leader.
+	leaderLockKey int64 = 0x5343484544 // "SCHED"
+
+	// foreignKeyViolation is the Postgres error code raised when the workflow does not exist.
+	foreignKeyViolation = "23503"
+
+	scheduleColumns = `id, workflow_id, cron, timezone, input_data, enabled, next_run_at, last_run_at, created_at, updated_at`
+)
+
+type RepositoryImpl struct {
+	pool *pgxpool.Pool
+}
+
+// Schedules implements Repository.
+func (r *RepositoryImpl) Schedules(ctx context.Context, workflowID string) ([]Schedule, error) {
+	args := pgx.NamedArgs{
+		"workflowID": workflowID,
+	}
+
+	query := `select ` + scheduleColumns + ` from workflow_schedules
+		where workflow_id = @workflowID
+		order by created_at`
+
+	return r.querySchedules(ctx, query, args)
+}
+
+// Schedule implements Repository.
+func (r *RepositoryImpl) Schedule(ctx context.Context, workflowID string, scheduleID string) (*Schedule, error) {
+	args := pgx.NamedArgs{
+		"workflowID": workflowID,
+		"id":         scheduleID,
+	}
+
+	query := `select ` + scheduleColumns + ` from workflow_schedules
+		where workflow_id = @workflowID and id = @id`
+
+	schedule, err := scanSchedule(r.pool.QueryRow(ctx, query, args))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrScheduleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+// CreateSchedule implements Repository.
+func (r *RepositoryImpl) CreateSchedule(ctx context.Context, schedule *Schedule) error {
+	args := pgx.NamedArgs{
+		"workflowID": schedule.WorkflowID,
+		"cron":       schedule.Cron,
+		"timezone":   schedule.Timezone,
+		"inputData":  schedule.InputData,
+		"enabled":    schedule.Enabled,
+		"nextRunAt":  schedule.NextRunAt,
+	}
+
+	query := `insert into workflow_schedules (workflow_id, cron, timezone, input_data, enabled, next_run_at)
+		values (@workflowID, @cron, @timezone, @inputData, @enabled, @nextRunAt)
+		returning id, created_at, updated_at`
+
+	err := r.pool.QueryRow(ctx, query, args).Scan(&schedule.ID, &schedule.CreatedAt, &schedule.UpdatedAt)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+		return ErrWorkflowNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create schedule: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateSchedule implements Repository.
+func (r *RepositoryImpl) UpdateSchedule(ctx context.Context, schedule *Schedule) error {
+	args := pgx.NamedArgs{
+		"workflowID": schedule.WorkflowID,
+		"id":         schedule.ID,
+		"cron":       schedule.Cron,
+		"timezone":   schedule.Timezone,
+		"inputData":  schedule.InputData,
+		"enabled":    schedule.Enabled,
+		"nextRunAt":  schedule.NextRunAt,
+	}
+
+	query := `update workflow_schedules
+		set cron = @cron, timezone = @timezone, input_data = @inputData, enabled = @enabled,
+			next_run_at = @nextRunAt, updated_at = now()
+		where workflow_id = @workflowID and id = @id
+		returning updated_at`
+
+	err := r.pool.QueryRow(ctx, query, args).Scan(&schedule.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrScheduleNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update schedule %s: %w", schedule.ID, err)
+	}
+
+	return nil
+}
+
+// DeleteSchedule implements Repository.
+func (r *RepositoryImpl) DeleteSchedule(ctx context.Context, workflowID string, scheduleID string) error {
+	args := pgx.NamedArgs{
+		"workflowID": workflowID,
+		"id":         scheduleID,
+	}
+
+	tag, err := r.pool.Exec(ctx, `delete from workflow_schedules where workflow_id = @workflowID and id = @id`, args)
+	if err != nil {
+		return fmt.Errorf("failed to delete schedule %s: %w", scheduleID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrScheduleNotFound
+	}
+
+	return nil
+}
+
+// DueSchedules implements Repository.
+func (r *RepositoryImpl) DueSchedules(ctx context.Context, now time.Time) ([]Schedule, error) {
+	args := pgx.NamedArgs{
+		"now": now,
+	}
+
+	query := `select ` + scheduleColumns + ` from workflow_schedules
+		where enabled and next_run_at <= @now
+		order by next_run_at`
+
+	return r.querySchedules(ctx, query, args)
+}
+
+// AdvanceSchedule implements Repository.
+func (r *RepositoryImpl) AdvanceSchedule(ctx context.Context, schedule *Schedule, runAt time.Time, nextRunAt time.Time) (bool, error) {
+	args := pgx.NamedArgs{
+		"id":            schedule.ID,
+		"lastRunAt":     runAt,
+		"nextRunAt":     nextRunAt,
+		"prevNextRunAt": schedule.NextRunAt,
+	}
+
+	query := `update workflow_schedules
+		set last_run_at = @lastRunAt, next_run_at = @nextRunAt, updated_at = now()
+		where id = @id and enabled and next_run_at = @prevNextRunAt`
+
+	tag, err := r.pool.Exec(ctx, query, args)
+	if err != nil {
+		return false, fmt.Errorf("failed to advance schedule %s: %w", schedule.ID, err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// TryLeaderLock implements Repository.
+// The advisory lock is session level, so the connection holding it is kept out of the pool until released.
+func (r *RepositoryImpl) TryLeaderLock(ctx context.Context) (Lock, error) {
+	conn, err := r.pool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire database connection: %w", err)
+	}
+
+	var acquired bool
+	if err := conn.QueryRow(ctx, `select pg_try_advisory_lock($1)`, leaderLockKey).Scan(&acquired); err != nil {
+		conn.Release()
+		return nil, fmt.Errorf("failed to try leader lock: %w", err)
+	}
+
+	if !acquired {
+		conn.Release()
+		return nil, nil
+	}
+
+	return &advisoryLock{conn: conn}, nil
+}
+
+func (r *RepositoryImpl) querySchedules(ctx context.Context, query string, args pgx.NamedArgs) ([]Schedule, error) {
+	rows, err := r.pool.Query(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query schedules: %w", err)
+	}
+	defer rows.Close()
+
+	schedules := []Schedule{}
+	for rows.Next() {
+		schedule, err := scanSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		schedules = append(schedules, *schedule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("querySchedules: failed to iterate over rows: %w", err)
+	}
+
+	return schedules, nil
+}
+
+func scanSchedule(row pgx.Row) (*Schedule, error) {
+	var schedule Schedule
+
+	err := row.Scan(
+		&schedule.ID,
+		&schedule.WorkflowID,
+		&schedule.Cron,
+		&schedule.Timezone,
+		&schedule.InputData,
+		&schedule.Enabled,
+		&schedule.NextRunAt,
+		&schedule.LastRunAt,
+		&schedule.CreatedAt,
+		&schedule.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan schedule: %w", err)
+	}
+
+	return &schedule, nil
+}
+
+// advisoryLock is a session level Postgres advisory lock held on a dedicated connection.
+type advisoryLock struct {
+	conn *pgxpool.Conn
+}
+
+func (l *advisoryLock) Held(ctx context.Context) bool {
+	return l.conn.Ping(ctx) == nil
+}
+
+func (l *advisoryLock) Release(ctx context.Context) {
+	// A failed unlock leaves the session holding the lock, so the connection is closed instead of reused
+	if _, err := l.conn.Exec(ctx, `select pg_advisory_unlock($1)`, leaderLockKey); err != nil {
+		l.conn.Conn().Close(ctx)
+	}
+	l.conn.Release()
+}
+
+func NewRepository(pool *pgxpool.Pool) Repository {
+	return &RepositoryImpl{
+		pool: pool,
+	}
+}
@@ -0,0 +1,146 @@
+package schedule
+
+import (
+	"context"
+	"log/slog"
+	"maps"
+	"runtime/debug"
+	"sync"
+	"time"
+	"workflow-code-test/api/internal/workflow"
+)
+
+// Scheduler triggers the executions of due schedules.
+// Every API instance may run a scheduler: only the one holding the leader lock fires schedules,
+// and another takes over once the leader stops or loses its database session.
+type Scheduler struct {
+	repo     Repository
+	executor Executor
+	interval time.Duration
+	log      *slog.Logger
+	now      func() time.Time
+
+	executions sync.WaitGroup
+}
+
+func NewScheduler(repo Repository, executor Executor, interval time.Duration, log *slog.Logger) *Scheduler {
+	return &Scheduler{
+		repo:     repo,
+		executor: executor,
+		interval: interval,
+		log:      log,
+		now:      time.Now,
+	}
+}
+
+// Run checks for due schedules every interval until ctx is done,
+// then releases the leader lock and waits for the executions it triggered.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	var lock Lock
+	defer func() {
+		if lock != nil {
+			lock.Release(context.WithoutCancel(ctx))
+		}
+		s.executions.Wait()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			if lock != nil && !lock.Held(ctx) {
+				s.log.Warn("lost scheduler leadership")
+				lock.Release(ctx)
+				lock = nil
+			}
+
+			if lock == nil {
+				var err error
+				lock, err = s.repo.TryLeaderLock(ctx)
+				if err != nil {
+					s.log.Error("problem electing scheduler leader", slog.Any("ERROR", err))
+					continue
+				}
+				if lock == nil {
+					continue
+				}
+				s.log.Info("became scheduler leader")
+			}
+
+			s.fireDue(ctx)
+		}
+	}
+}
+
+// fireDue triggers an execution for every due schedule and moves it to its next run.
+// Runs missed while no scheduler was leading are fired once rather than caught up.
+func (s *Scheduler) fireDue(ctx context.Context) {
+	defer s.recoverPanic("panic firing due schedules")
+
+	now := s.now()
+
+	schedules, err := s.repo.DueSchedules(ctx, now)
+	if err != nil {
+		s.log.Error("problem loading due schedules", slog.Any("ERROR", err))
+		return
+	}
+
+	for _, schedule := range schedules {
+		next, err := nextRun(&schedule, now)
+		if err != nil {
+			s.log.Error("problem computing next schedule run", slog.Any("ID", schedule.ID), slog.Any("ERROR", err))
+			continue
+		}
+
+		// Advancing before triggering fires each run at most once
+		advanced, err := s.repo.AdvanceSchedule(ctx, &schedule, now, next)
+		if err != nil {
+			s.log.Error("problem advancing schedule", slog.Any("ID", schedule.ID), slog.Any("ERROR", err))
+			continue
+		}
+		if !advanced {
+			continue
+		}
+
+		s.executions.Add(1)
+		go func() {
+			defer s.executions.Done()
+			s.trigger(context.WithoutCancel(ctx), schedule)
+		}()
+	}
+}
+
+func (s *Scheduler) trigger(ctx context.Context, schedule Schedule) {
+	defer s.recoverPanic("panic running scheduled execution")
+
+	s.log.Info("triggering scheduled execution", slog.Any("ID", schedule.ID), slog.Any("workflowID", schedule.WorkflowID))
+
+	input := &workflow.ExecutionInput{
+		FormData: maps.Clone(schedule.InputData),
+	}
+
+	result, err := s.executor.Execute(ctx, schedule.WorkflowID, input)
+	if err != nil {
+		s.log.Error("problem running scheduled execution", slog.Any("ID", schedule.ID), slog.Any("ERROR", err))
+		return
+	}
+
+	s.log.Info("scheduled execution finished",
+		slog.Any("ID", schedule.ID),
+		slog.Any("executionID", result.ExecutionID),
+		slog.Any("status", result.Status),
+	)
+}
+
+// recoverPanic logs a panic of the scheduler, deferred by its goroutines so it keeps running rather than crashing
+// the process running it.
+func (s *Scheduler) recoverPanic(msg string) {
+	if rec := recover(); rec != nil {
+		s.log.Error(msg, slog.Any("PANIC", rec), slog.String("stack", string(debug.Stack())))
+	}
+}
@@ -0,0 +1,106 @@
+package schedule_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+	"workflow-code-test/api/internal/schedule"
+
+	"github.com/stretchr/testify/require"
+)
+
+// runScheduler runs a scheduler checking repo every millisecond with the clock, and returns the function stopping it.
+func runScheduler(t *testing.T, repo schedule.Repository, executor schedule.Executor, clock *fakeClock) (stop func()) {
+	t.Helper()
+
+	scheduler := schedule.NewScheduler(repo, executor, time.Millisecond, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	scheduler.SetClock(clock.Now)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scheduler.Run(ctx)
+	}()
+
+	stop = func() {
+		cancel()
+		<-done
+	}
+	t.Cleanup(stop)
+
+	return stop
+}
+
+// requireExecutions waits for the executor to have started n executions, then checks that no more start.
+func requireExecutions(t *testing.T, executor *fakeExecutor, n int) {
+	t.Helper()
+
+	require.Eventually(t, func() bool { return len(executor.Inputs()) >= n }, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	require.Len(t, executor.Inputs(), n)
+}
+
+func TestScheduler_FiresDue(t *testing.T) {
+	repo := &fakeRepository{}
+	svc, clock := newService(repo)
+	ctx := context.Background()
+
+	every5, err := svc.Create(ctx, "wf", &schedule.ScheduleInput{Cron: "*/5 * * * *", InputData: map[string]any{"city": "Sydney"}})
+	require.NoError(t, err)
+	disabled := false
+	_, err = svc.Create(ctx, "wf", &schedule.ScheduleInput{Cron: "* * * * *", Enabled: &disabled})
+	require.NoError(t, err)
+
+	executor := &fakeExecutor{}
+	runScheduler(t, repo, executor, clock)
+	require.Eventually(t, repo.hasLeader, time.Second, time.Millisecond)
+
+	// Nothing is due before 10:10
+	requireExecutions(t, executor, 0)
+
+	clock.Set("2026-10-19T10:11:00Z")
+	requireExecutions(t, executor, 1)
+	require.Equal(t, map[string]any{"city": "Sydney"}, executor.Inputs()[0])
+
+	stored := repo.stored(every5.ID)
+	require.Equal(t, "2026-10-19T10:11:00Z", stored.LastRunAt.Format(time.RFC3339))
+	require.Equal(t, "2026-10-19T10:15:00Z", stored.NextRunAt.Format(time.RFC3339))
+
+	// Runs missed meanwhile are fired once
+	clock.Set("2026-10-19T10:42:00Z")
+	requireExecutions(t, executor, 2)
+
+	stored = repo.stored(every5.ID)
+	require.Equal(t, "2026-10-19T10:42:00Z", stored.LastRunAt.Format(time.RFC3339))
+	require.Equal(t, "2026-10-19T10:45:00Z", stored.NextRunAt.Format(time.RFC3339))
+}
+
+func TestScheduler_OnlyLeaderFires(t *testing.T) {
+	repo := &fakeRepository{}
+	svc, clock := newService(repo)
+
+	_, err := svc.Create(context.Background(), "wf", &schedule.ScheduleInput{Cron: "* * * * *"})
+	require.NoError(t, err)
+
+	leader := &fakeExecutor{}
+	stopLeader := runScheduler(t, repo, leader, clock)
+	require.Eventually(t, repo.hasLeader, time.Second, time.Millisecond)
+
+	follower := &fakeExecutor{}
+	runScheduler(t, repo, follower, clock)
+
+	clock.Set("2026-10-19T10:08:30Z")
+	requireExecutions(t, leader, 1)
+	require.Empty(t, follower.Inputs())
+
+	// Once the leader stops, the other scheduler takes over
+	stopLeader()
+	require.Eventually(t, repo.hasLeader, time.Second, time.Millisecond)
+
+	clock.Set("2026-10-19T10:09:30Z")
+	requireExecutions(t, follower, 1)
+	require.Len(t, leader.Inputs(), 1)
+}
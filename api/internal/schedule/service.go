@@ -0,0 +1,145 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"workflow-code-test/api/internal/workflow"
+
+	"github.com/robfig/cron/v3"
+)
+
+type ServiceImpl struct {
+	repo Repository
+	now  func() time.Time
+}
+
+// Schedules implements Service.
+func (s *ServiceImpl) Schedules(ctx context.Context, workflowID string) ([]Schedule, error) {
+	return s.repo.Schedules(ctx, workflowID)
+}
+
+// Create implements Service.
+func (s *ServiceImpl) Create(ctx context.Context, workflowID string, input *ScheduleInput) (*Schedule, error) {
+	schedule := &Schedule{
+		WorkflowID: workflowID,
+	}
+	if err := apply(schedule, input, s.now()); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateSchedule(ctx, schedule); err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+// Update implements Service.
+func (s *ServiceImpl) Update(ctx context.Context, workflowID string, scheduleID string, input *ScheduleInput) (*Schedule, error) {
+	schedule, err := s.repo.Schedule(ctx, workflowID, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := apply(schedule, input, s.now()); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateSchedule(ctx, schedule); err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+// Delete implements Service.
+func (s *ServiceImpl) Delete(ctx context.Context, workflowID string, scheduleID string) error {
+	return s.repo.DeleteSchedule(ctx, workflowID, scheduleID)
+}
+
+// apply validates input and sets it on the schedule along with its next run after now.
+func apply(schedule *Schedule, input *ScheduleInput, now time.Time) error {
+	fieldErrors := map[string]string{}
+
+	timezone := input.Timezone
+	if timezone == "" {
+		timezone = defaultTimezone
+	}
+
+	spec, err := parseCron(input.Cron)
+	if err != nil {
+		fieldErrors["cron"] = err.Error()
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		fieldErrors["timezone"] = "must be an IANA time zone, e.g. Europe/London"
+	}
+
+	if len(fieldErrors) == 0 && spec.Next(now.In(location)).IsZero() {
+		fieldErrors["cron"] = "never matches"
+	}
+
+	if len(fieldErrors) > 0 {
+		return &workflow.ValidationError{Fields: fieldErrors}
+	}
+
+	schedule.Cron = input.Cron
+	schedule.Timezone = timezone
+	schedule.InputData = input.InputData
+	if schedule.InputData == nil {
+		schedule.InputData = map[string]any{}
+	}
+
+	schedule.Enabled = input.Enabled == nil || *input.Enabled
+	schedule.NextRunAt = nil
+	if schedule.Enabled {
+		next := spec.Next(now.In(location)).UTC()
+		schedule.NextRunAt = &next
+	}
+
+	return nil
+}
+
+// parseCron parses a standard 5 field cron expression or a descriptor such as @daily.
+// Time zones are set through the schedule's timezone rather than a CRON_TZ prefix.
+func parseCron(expression string) (cron.Schedule, error) {
+	if expression == "" {
+		return nil, fmt.Errorf("is required")
+	}
+
+	if strings.HasPrefix(expression, "TZ=") || strings.HasPrefix(expression, "CRON_TZ=") {
+		return nil, fmt.Errorf("must not set a time zone, use timezone instead")
+	}
+
+	spec, err := cron.ParseStandard(expression)
+	if err != nil {
+		return nil, fmt.Errorf("must be a valid cron expression: %w", err)
+	}
+
+	return spec, nil
+}
+
+// nextRun returns the next run of the schedule strictly after now.
+func nextRun(schedule *Schedule, now time.Time) (time.Time, error) {
+	spec, err := parseCron(schedule.Cron)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("schedule %s: cron %w", schedule.ID, err)
+	}
+
+	location, err := time.LoadLocation(schedule.Timezone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("schedule %s: %w", schedule.ID, err)
+	}
+
+	return spec.Next(now.In(location)).UTC(), nil
+}
+
+func NewService(repo Repository) Service {
+	return &ServiceImpl{
+		repo: repo,
+		now:  time.Now,
+	}
+}
@@ -0,0 +1,182 @@
+package schedule_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"workflow-code-test/api/internal/schedule"
+	"workflow-code-test/api/internal/workflow"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newService returns a service storing schedules in repo, whose clock reads 2026-10-19T10:07:00Z, a Monday.
+func newService(repo schedule.Repository) (schedule.Service, *fakeClock) {
+	clock := newClock("2026-10-19T10:07:00Z")
+	svc := schedule.NewService(repo)
+	schedule.SetClock(svc, clock.Now)
+
+	return svc, clock
+}
+
+func TestCreate(t *testing.T) {
+	disabled := false
+
+	tests := []struct {
+		name              string
+		input             schedule.ScheduleInput
+		expectedTimezone  string
+		expectedNextRunAt string
+	}{
+		{
+			name:              "every 15 minutes",
+			input:             schedule.ScheduleInput{Cron: "*/15 * * * *"},
+			expectedTimezone:  "UTC",
+			expectedNextRunAt: "2026-10-19T10:15:00Z",
+		},
+		{
+			name:              "weekdays in summer time",
+			input:             schedule.ScheduleInput{Cron: "0 9 * * 1-5", Timezone: "Europe/London"},
+			expectedTimezone:  "Europe/London",
+			expectedNextRunAt: "2026-10-20T08:00:00Z",
+		},
+		{
+			name:              "descriptor",
+			input:             schedule.ScheduleInput{Cron: "@daily", Timezone: "Australia/Sydney"},
+			expectedTimezone:  "Australia/Sydney",
+			expectedNextRunAt: "2026-10-19T13:00:00Z",
+		},
+		{
+			name:             "disabled",
+			input:            schedule.ScheduleInput{Cron: "@hourly", Enabled: &disabled},
+			expectedTimezone: "UTC",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeRepository{}
+			svc, _ := newService(repo)
+
+			created, err := svc.Create(context.Background(), "wf", &tt.input)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedTimezone, created.Timezone)
+			require.Equal(t, map[string]any{}, created.InputData)
+			require.Nil(t, created.LastRunAt)
+
+			if tt.expectedNextRunAt == "" {
+				require.False(t, created.Enabled)
+				require.Nil(t, created.NextRunAt)
+			} else {
+				require.True(t, created.Enabled)
+				require.NotNil(t, created.NextRunAt)
+				require.Equal(t, tt.expectedNextRunAt, created.NextRunAt.Format(time.RFC3339))
+			}
+
+			require.Equal(t, *created, repo.stored(created.ID))
+		})
+	}
+}
+
+func TestCreate_Invalid(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          schedule.ScheduleInput
+		expectedFields map[string]string
+	}{
+		{
+			name:           "missing cron",
+			input:          schedule.ScheduleInput{},
+			expectedFields: map[string]string{"cron": "is required"},
+		},
+		{
+			name:           "time zone in the cron",
+			input:          schedule.ScheduleInput{Cron: "CRON_TZ=Europe/London 0 9 * * *"},
+			expectedFields: map[string]string{"cron": "must not set a time zone, use timezone instead"},
+		},
+		{
+			name:           "out of range field",
+			input:          schedule.ScheduleInput{Cron: "61 * * * *"},
+			expectedFields: map[string]string{"cron": "must be a valid cron expression"},
+		},
+		{
+			name:           "seconds field",
+			input:          schedule.ScheduleInput{Cron: "0 0 9 * * *"},
+			expectedFields: map[string]string{"cron": "must be a valid cron expression"},
+		},
+		{
+			name:           "never matching",
+			input:          schedule.ScheduleInput{Cron: "0 0 30 2 *"},
+			expectedFields: map[string]string{"cron": "never matches"},
+		},
+		{
+			name:           "unknown time zone",
+			input:          schedule.ScheduleInput{Cron: "@hourly", Timezone: "Mars/Olympus"},
+			expectedFields: map[string]string{"timezone": "must be an IANA time zone"},
+		},
+		{
+			name:  "invalid cron and time zone",
+			input: schedule.ScheduleInput{Cron: "every day", Timezone: "Mars/Olympus"},
+			expectedFields: map[string]string{
+				"cron":     "must be a valid cron expression",
+				"timezone": "must be an IANA time zone",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeRepository{}
+			svc, _ := newService(repo)
+
+			_, err := svc.Create(context.Background(), "wf", &tt.input)
+			var validationErr *workflow.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			require.Len(t, validationErr.Fields, len(tt.expectedFields))
+			for field, message := range tt.expectedFields {
+				require.Contains(t, validationErr.Fields[field], message)
+			}
+
+			schedules, err := svc.Schedules(context.Background(), "wf")
+			require.NoError(t, err)
+			require.Empty(t, schedules)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	repo := &fakeRepository{}
+	svc, clock := newService(repo)
+	ctx := context.Background()
+
+	created, err := svc.Create(ctx, "wf", &schedule.ScheduleInput{Cron: "0 * * * *", InputData: map[string]any{"city": "Sydney"}})
+	require.NoError(t, err)
+	require.Equal(t, "2026-10-19T11:00:00Z", created.NextRunAt.Format(time.RFC3339))
+
+	disabled := false
+	updated, err := svc.Update(ctx, "wf", created.ID, &schedule.ScheduleInput{Cron: "0 * * * *", Enabled: &disabled})
+	require.NoError(t, err)
+	require.False(t, updated.Enabled)
+	require.Nil(t, updated.NextRunAt)
+	require.Equal(t, map[string]any{}, updated.InputData)
+
+	// Enabling it again computes its next run from the current time
+	clock.Set("2026-10-19T15:30:00Z")
+	updated, err = svc.Update(ctx, "wf", created.ID, &schedule.ScheduleInput{Cron: "*/20 * * * *"})
+	require.NoError(t, err)
+	require.True(t, updated.Enabled)
+	require.Equal(t, "2026-10-19T15:40:00Z", updated.NextRunAt.Format(time.RFC3339))
+	require.Equal(t, *updated, repo.stored(created.ID))
+
+	t.Run("invalid input leaves the schedule unchanged", func(t *testing.T) {
+		_, err := svc.Update(ctx, "wf", created.ID, &schedule.ScheduleInput{Cron: "0 0 30 2 *"})
+		var validationErr *workflow.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		require.Equal(t, *updated, repo.stored(created.ID))
+	})
+
+	t.Run("missing schedule", func(t *testing.T) {
+		_, err := svc.Update(ctx, "other", created.ID, &schedule.ScheduleInput{Cron: "@hourly"})
+		require.ErrorIs(t, err, schedule.ErrScheduleNotFound)
+	})
+}
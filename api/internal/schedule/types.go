@@ -0,0 +1,39 @@
+package schedule
+
+import (
+	"errors"
+	"time"
+)
+
+const defaultTimezone = "UTC"
+
+var (
+	ErrScheduleNotFound = errors.New("schedule not found")
+	ErrWorkflowNotFound = errors.New("workflow not found")
+)
+
+// Schedule triggers executions of a workflow with fixed input data according to a cron expression.
+type Schedule struct {
+	ID         string         `json:"id"`
+	WorkflowID string         `json:"workflowId"`
+	Cron       string         `json:"cron"`
+	Timezone   string         `json:"timezone"`
+	InputData  map[string]any `json:"inputData"`
+	Enabled    bool           `json:"enabled"`
+	NextRunAt  *time.Time     `json:"nextRunAt"`
+	LastRunAt  *time.Time     `json:"lastRunAt"`
+	CreatedAt  time.Time      `json:"createdAt"`
+	UpdatedAt  time.Time      `json:"updatedAt"`
+}
+
+// ScheduleInput creates or replaces a schedule.
+type ScheduleInput struct {
+	// Cron is a standard 5 field cron expression, or a descriptor such as @hourly.
+	Cron string `json:"cron"`
+	// Timezone is the IANA time zone the cron expression is evaluated in. Defaults to UTC.
+	Timezone string `json:"timezone"`
+	// InputData is the form data every triggered execution starts with.
+	InputData map[string]any `json:"inputData"`
+	// Enabled defaults to true.
+	Enabled *bool `json:"enabled"`
+}
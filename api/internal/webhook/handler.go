@@ -0,0 +1,169 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"workflow-code-test/api/internal/workflow"
+	"workflow-code-test/api/pkg/render"
+	signature "workflow-code-test/api/pkg/webhook"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// maxPayloadBytes bounds the size of delivery payloads.
+const maxPayloadBytes = 1 << 20
+
+type HandlerImpl struct {
+	svc Service
+	log *slog.Logger
+}
+
+// Webhooks implements Handler.
+func (h *HandlerImpl) Webhooks(w http.ResponseWriter, r *http.Request) {
+	workflowID, ok := h.workflowID(w, r)
+	if !ok {
+		return
+	}
+
+	webhooks, err := h.svc.Webhooks(r.Context(), workflowID)
+	if err != nil {
+		h.renderError(w, r, err)
+		return
+	}
+
+	render.JSON(w, r, http.StatusOK, webhooks)
+}
+
+// Create implements Handler.
+func (h *HandlerImpl) Create(w http.ResponseWriter, r *http.Request) {
+	workflowID, ok := h.workflowID(w, r)
+	if !ok {
+		return
+	}
+
+	var input WebhookInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		render.Error(w, r, http.StatusBadRequest, err, h.log)
+		return
+	}
+
+	webhook, err := h.svc.Create(r.Context(), workflowID, &input)
+	if err != nil {
+		h.renderError(w, r, err)
+		return
+	}
+
+	render.JSON(w, r, http.StatusCreated, webhook)
+}
+
+// Delete implements Handler.
+func (h *HandlerImpl) Delete(w http.ResponseWriter, r *http.Request) {
+	workflowID, ok := h.workflowID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.Delete(r.Context(), workflowID, mux.Vars(r)["webhookId"]); err != nil {
+		h.renderError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Receive implements Handler.
+func (h *HandlerImpl) Receive(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	h.log.DebugContext(r.Context(), "Handling webhook delivery for id", "id", id)
+
+	if err := uuid.Validate(id); err != nil {
+		render.Error(w, r, http.StatusNotFound, render.ErrNotFound, h.log)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPayloadBytes))
+	if err != nil {
+		render.Error(w, r, http.StatusRequestEntityTooLarge, render.ErrPayloadTooLarge, h.log)
+		return
+	}
+
+	executionResult, err := h.svc.Trigger(r.Context(), id, &Delivery{
+		Timestamp: r.Header.Get(signature.HeaderTimestamp),
+		Nonce:     r.Header.Get(signature.HeaderNonce),
+		Signature: r.Header.Get(signature.HeaderSignature),
+		Body:      body,
+	})
+
+	var validationErr *workflow.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		render.ValidationError(w, r, validationErr.Fields, h.log)
+		return
+	case errors.Is(err, ErrWebhookNotFound):
+		render.Error(w, r, http.StatusNotFound, render.ErrNotFound, h.log)
+		return
+	case errors.Is(err, signature.ErrMissingHeaders),
+		errors.Is(err, signature.ErrInvalidSignature),
+		errors.Is(err, signature.ErrInvalidTimestamp),
+		errors.Is(err, signature.ErrTimestampExpired):
+		h.log.WarnContext(r.Context(), "rejected webhook delivery", slog.Any("ID", id), slog.Any("ERROR", err))
+		render.Error(w, r, http.StatusUnauthorized, render.ErrInvalidSignature, h.log)
+		return
+	case errors.Is(err, ErrReplayed):
+		render.Error(w, r, http.StatusConflict, render.ErrReplayedDelivery, h.log)
+		return
+	}
+
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "problem finishing webhook execution", slog.Any("ID", id), slog.Any("ERROR", err))
+	}
+	if executionResult == nil {
+		render.Error(w, r, http.StatusInternalServerError, err, h.log)
+		return
+	}
+
+	render.JSON(w, r, workflow.ExecutionStatusCode(executionResult), executionResult)
+}
+
+// workflowID returns the validated workflow ID of the request, rendering an error if it is invalid.
+// The webhook ID, when present, is validated as well.
+func (h *HandlerImpl) workflowID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	vars := mux.Vars(r)
+
+	if err := uuid.Validate(vars["id"]); err != nil {
+		h.log.ErrorContext(r.Context(), "problem validating workflow id", slog.Any("ID", vars["id"]), slog.Any("ERROR", err))
+		render.Error(w, r, http.StatusBadRequest, render.ErrInvalidWorkflowID, h.log)
+		return "", false
+	}
+
+	if webhookID, ok := vars["webhookId"]; ok {
+		if err := uuid.Validate(webhookID); err != nil {
+			h.log.ErrorContext(r.Context(), "problem validating webhook id", slog.Any("ID", webhookID), slog.Any("ERROR", err))
+			render.Error(w, r, http.StatusBadRequest, render.ErrInvalidWebhookID, h.log)
+			return "", false
+		}
+	}
+
+	return vars["id"], true
+}
+
+func (h *HandlerImpl) renderError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, ErrWebhookNotFound), errors.Is(err, ErrWorkflowNotFound):
+		render.Error(w, r, http.StatusNotFound, render.ErrNotFound, h.log)
+	default:
+		h.log.ErrorContext(r.Context(), "problem managing webhooks", slog.Any("ERROR", err))
+		render.Error(w, r, http.StatusInternalServerError, err, h.log)
+	}
+}
+
+func NewHandler(svc Service, log *slog.Logger) Handler {
+	return &HandlerImpl{
+		svc: svc,
+		log: log,
+	}
+}
@@ -0,0 +1,134 @@
+package webhook_test
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+	"workflow-code-test/api/internal/webhook"
+	"workflow-code-test/api/internal/workflow"
+)
+
+// fakeRepository stores webhooks and the nonces of their deliveries in memory.
+type fakeRepository struct {
+	mu       sync.Mutex
+	webhooks []webhook.Webhook
+	nonces   map[string]time.Time
+	// expireBefore is the expiry passed to the last RecordNonce call.
+	expireBefore time.Time
+}
+
+// Webhooks implements webhook.Repository.
+func (r *fakeRepository) Webhooks(ctx context.Context, workflowID string) ([]webhook.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	webhooks := []webhook.Webhook{}
+	for _, w := range r.webhooks {
+		if w.WorkflowID == workflowID {
+			webhooks = append(webhooks, w)
+		}
+	}
+
+	return webhooks, nil
+}
+
+// Webhook implements webhook.Repository.
+func (r *fakeRepository) Webhook(ctx context.Context, webhookID string) (*webhook.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, w := range r.webhooks {
+		if w.ID == webhookID {
+			return &w, nil
+		}
+	}
+
+	return nil, webhook.ErrWebhookNotFound
+}
+
+// CreateWebhook implements webhook.Repository.
+func (r *fakeRepository) CreateWebhook(ctx context.Context, w *webhook.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	w.ID = strconv.Itoa(len(r.webhooks) + 1)
+	r.webhooks = append(r.webhooks, *w)
+
+	return nil
+}
+
+// DeleteWebhook implements webhook.Repository.
+func (r *fakeRepository) DeleteWebhook(ctx context.Context, workflowID string, webhookID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := slices.IndexFunc(r.webhooks, func(w webhook.Webhook) bool { return w.WorkflowID == workflowID && w.ID == webhookID })
+	if i < 0 {
+		return webhook.ErrWebhookNotFound
+	}
+	r.webhooks = slices.Delete(r.webhooks, i, i+1)
+
+	return nil
+}
+
+// RecordNonce implements webhook.Repository.
+func (r *fakeRepository) RecordNonce(ctx context.Context, webhookID string, nonce string, receivedAt time.Time, expireBefore time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.nonces == nil {
+		r.nonces = map[string]time.Time{}
+	}
+	for key, at := range r.nonces {
+		if at.Before(expireBefore) {
+			delete(r.nonces, key)
+		}
+	}
+	r.expireBefore = expireBefore
+
+	key := webhookID + "/" + nonce
+	if _, ok := r.nonces[key]; ok {
+		return false, nil
+	}
+	r.nonces[key] = receivedAt
+
+	return true, nil
+}
+
+// recordedNonces returns the number of nonces remembered.
+func (r *fakeRepository) recordedNonces() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.nonces)
+}
+
+// execution is an execution started by fakeExecutor.
+type execution struct {
+	workflowID string
+	formData   map[string]any
+}
+
+// fakeExecutor records the executions it is asked to start.
+type fakeExecutor struct {
+	mu         sync.Mutex
+	executions []execution
+}
+
+func (e *fakeExecutor) Execute(ctx context.Context, workflowID string, input *workflow.ExecutionInput) (*workflow.ExecutionResult, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.executions = append(e.executions, execution{workflowID: workflowID, formData: input.FormData})
+	return &workflow.ExecutionResult{ExecutionID: strconv.Itoa(len(e.executions)), Status: workflow.ExecutionStatusCompleted}, nil
+}
+
+// Executions returns the executions started so far.
+func (e *fakeExecutor) Executions() []execution {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return slices.Clone(e.executions)
+}
@@ -0,0 +1,68 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"time"
+	"workflow-code-test/api/internal/workflow"
+)
+
+// Service defines the interface for managing webhooks and starting executions from their deliveries.
+type Service interface {
+	// Webhooks lists the webhooks of a workflow, oldest first, without their secrets.
+	Webhooks(ctx context.Context, workflowID string) ([]Webhook, error)
+
+	// Create adds a webhook with a generated secret to a workflow. The secret is only returned here.
+	// Returns ErrWorkflowNotFound if the workflow does not exist.
+	Create(ctx context.Context, workflowID string, input *WebhookInput) (*Webhook, error)
+
+	// Delete removes a webhook. Returns ErrWebhookNotFound if it does not exist.
+	Delete(ctx context.Context, workflowID string, webhookID string) error
+
+	// Trigger verifies a delivery and executes the webhook's workflow with the form data mapped from its payload,
+	// exactly as if the form data was submitted to the execute endpoint.
+	// Returns ErrWebhookNotFound, a pkg/webhook error if the signature or timestamp is invalid,
+	// ErrReplayed if the delivery's nonce was already received, or a *workflow.ValidationError.
+	Trigger(ctx context.Context, webhookID string, delivery *Delivery) (*workflow.ExecutionResult, error)
+}
+
+// Repository is an interface that provides methods to store webhooks and the nonces of their deliveries.
+type Repository interface {
+	// Webhooks retrieves the webhooks of a workflow, oldest first.
+	Webhooks(ctx context.Context, workflowID string) ([]Webhook, error)
+
+	// Webhook retrieves a webhook by its ID. Returns ErrWebhookNotFound if it does not exist.
+	Webhook(ctx context.Context, webhookID string) (*Webhook, error)
+
+	// CreateWebhook persists a new webhook and sets its ID, CreatedAt and UpdatedAt.
+	// Returns ErrWorkflowNotFound if the workflow does not exist.
+	CreateWebhook(ctx context.Context, webhook *Webhook) error
+
+	// DeleteWebhook removes a webhook. Returns ErrWebhookNotFound if it does not exist.
+	DeleteWebhook(ctx context.Context, workflowID string, webhookID string) error
+
+	// RecordNonce remembers the nonce of a delivery received at receivedAt, forgetting the nonces received before
+	// expireBefore. Returns false if the nonce was already recorded.
+	RecordNonce(ctx context.Context, webhookID string, nonce string, receivedAt time.Time, expireBefore time.Time) (bool, error)
+}
+
+// Executor starts workflow executions. It is implemented by workflow.Service.
+type Executor interface {
+	Execute(ctx context.Context, workflowID string, input *workflow.ExecutionInput) (*workflow.ExecutionResult, error)
+}
+
+// Handler is an interface that defines HTTP handler functions for managing webhooks and receiving deliveries.
+type Handler interface {
+	// Webhooks handles HTTP requests listing the webhooks of a workflow.
+	Webhooks(w http.ResponseWriter, r *http.Request)
+
+	// Create handles HTTP requests adding a webhook to a workflow.
+	Create(w http.ResponseWriter, r *http.Request)
+
+	// Delete handles HTTP requests removing a webhook.
+	Delete(w http.ResponseWriter, r *http.Request)
+
+	// Receive handles inbound webhook deliveries, starting an execution of the webhook's workflow.
+	// It is public, deliveries are authenticated by their signature.
+	Receive(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,167 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// foreignKeyViolation is the Postgres error code raised when the workflow does not exist.
+	foreignKeyViolation = "23503"
+
+	webhookColumns = `id, workflow_id, secret, mapping, created_at, updated_at`
+)
+
+type RepositoryImpl struct {
+	pool *pgxpool.Pool
+}
+
+// Webhooks implements Repository.
+func (r *RepositoryImpl) Webhooks(ctx context.Context, workflowID string) ([]Webhook, error) {
+	args := pgx.NamedArgs{
+		"workflowID": workflowID,
+	}
+
+	query := `select ` + webhookColumns + ` from workflow_webhooks
+		where workflow_id = @workflowID
+		order by created_at`
+
+	rows, err := r.pool.Query(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	webhooks := []Webhook{}
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		webhooks = append(webhooks, *webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("queryWebhooks: failed to iterate over rows: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+// Webhook implements Repository.
+func (r *RepositoryImpl) Webhook(ctx context.Context, webhookID string) (*Webhook, error) {
+	args := pgx.NamedArgs{
+		"id": webhookID,
+	}
+
+	query := `select ` + webhookColumns + ` from workflow_webhooks where id = @id`
+
+	webhook, err := scanWebhook(r.pool.QueryRow(ctx, query, args))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrWebhookNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// CreateWebhook implements Repository.
+func (r *RepositoryImpl) CreateWebhook(ctx context.Context, webhook *Webhook) error {
+	args := pgx.NamedArgs{
+		"workflowID": webhook.WorkflowID,
+		"secret":     webhook.Secret,
+		"mapping":    webhook.Mapping,
+	}
+
+	query := `insert into workflow_webhooks (workflow_id, secret, mapping)
+		values (@workflowID, @secret, @mapping)
+		returning id, created_at, updated_at`
+
+	err := r.pool.QueryRow(ctx, query, args).Scan(&webhook.ID, &webhook.CreatedAt, &webhook.UpdatedAt)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+		return ErrWorkflowNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteWebhook implements Repository.
+func (r *RepositoryImpl) DeleteWebhook(ctx context.Context, workflowID string, webhookID string) error {
+	args := pgx.NamedArgs{
+		"workflowID": workflowID,
+		"id":         webhookID,
+	}
+
+	tag, err := r.pool.Exec(ctx, `delete from workflow_webhooks where workflow_id = @workflowID and id = @id`, args)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook %s: %w", webhookID, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// RecordNonce implements Repository.
+func (r *RepositoryImpl) RecordNonce(ctx context.Context, webhookID string, nonce string, receivedAt time.Time, expireBefore time.Time) (bool, error) {
+	args := pgx.NamedArgs{
+		"webhookID":    webhookID,
+		"nonce":        nonce,
+		"receivedAt":   receivedAt,
+		"expireBefore": expireBefore,
+	}
+
+	if _, err := r.pool.Exec(ctx, `delete from webhook_nonces where received_at < @expireBefore`, args); err != nil {
+		return false, fmt.Errorf("failed to expire webhook nonces: %w", err)
+	}
+
+	query := `insert into webhook_nonces (webhook_id, nonce, received_at)
+		values (@webhookID, @nonce, @receivedAt)
+		on conflict do nothing`
+
+	tag, err := r.pool.Exec(ctx, query, args)
+	if err != nil {
+		return false, fmt.Errorf("failed to record webhook nonce: %w", err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+func scanWebhook(row pgx.Row) (*Webhook, error) {
+	var webhook Webhook
+
+	err := row.Scan(
+		&webhook.ID,
+		&webhook.WorkflowID,
+		&webhook.Secret,
+		&webhook.Mapping,
+		&webhook.CreatedAt,
+		&webhook.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan webhook: %w", err)
+	}
+
+	return &webhook, nil
+}
+
+func NewRepository(pool *pgxpool.Pool) Repository {
+	return &RepositoryImpl{
+		pool: pool,
+	}
+}
@@ -0,0 +1,106 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+	"workflow-code-test/api/internal/workflow"
+	signature "workflow-code-test/api/pkg/webhook"
+)
+
+// deliveryPath is the path deliveries of a webhook are received at.
+const deliveryPath = "/api/v1/hooks/%s"
+
+type ServiceImpl struct {
+	repo      Repository
+	executor  Executor
+	tolerance time.Duration
+}
+
+// Webhooks implements Service.
+func (s *ServiceImpl) Webhooks(ctx context.Context, workflowID string) ([]Webhook, error) {
+	webhooks, err := s.repo.Webhooks(ctx, workflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+		webhooks[i].URL = fmt.Sprintf(deliveryPath, webhooks[i].ID)
+	}
+
+	return webhooks, nil
+}
+
+// Create implements Service.
+func (s *ServiceImpl) Create(ctx context.Context, workflowID string, input *WebhookInput) (*Webhook, error) {
+	secret, err := signature.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	webhook := &Webhook{
+		WorkflowID: workflowID,
+		Secret:     secret,
+		Mapping:    input.Mapping,
+	}
+	if webhook.Mapping == nil {
+		webhook.Mapping = map[string]string{}
+	}
+
+	if err := s.repo.CreateWebhook(ctx, webhook); err != nil {
+		return nil, err
+	}
+	webhook.URL = fmt.Sprintf(deliveryPath, webhook.ID)
+
+	return webhook, nil
+}
+
+// Delete implements Service.
+func (s *ServiceImpl) Delete(ctx context.Context, workflowID string, webhookID string) error {
+	return s.repo.DeleteWebhook(ctx, workflowID, webhookID)
+}
+
+// Trigger implements Service.
+func (s *ServiceImpl) Trigger(ctx context.Context, webhookID string, delivery *Delivery) (*workflow.ExecutionResult, error) {
+	webhook, err := s.repo.Webhook(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	err = signature.Verify(webhook.Secret, delivery.Timestamp, delivery.Nonce, delivery.Signature, delivery.Body, now, s.tolerance)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deliveries outside the window are rejected by their timestamp, so only nonces within it are kept
+	recorded, err := s.repo.RecordNonce(ctx, webhook.ID, delivery.Nonce, now, now.Add(-2*s.tolerance))
+	if err != nil {
+		return nil, err
+	}
+	if !recorded {
+		return nil, ErrReplayed
+	}
+
+	var payload any
+	if err := json.Unmarshal(delivery.Body, &payload); err != nil {
+		return nil, &workflow.ValidationError{Fields: map[string]string{"payload": "must be valid JSON"}}
+	}
+
+	formData, err := signature.Map(payload, webhook.Mapping)
+	if err != nil {
+		return nil, &workflow.ValidationError{Fields: map[string]string{"payload": "must be a JSON object"}}
+	}
+
+	return s.executor.Execute(ctx, webhook.WorkflowID, &workflow.ExecutionInput{FormData: formData})
+}
+
+func NewService(repo Repository, executor Executor, tolerance time.Duration) Service {
+	return &ServiceImpl{
+		repo:      repo,
+		executor:  executor,
+		tolerance: tolerance,
+	}
+}
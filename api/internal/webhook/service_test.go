@@ -0,0 +1,232 @@
+package webhook_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+	"workflow-code-test/api/internal/webhook"
+	"workflow-code-test/api/internal/workflow"
+	signature "workflow-code-test/api/pkg/webhook"
+
+	"github.com/stretchr/testify/require"
+)
+
+const tolerance = 5 * time.Minute
+
+// newWebhook returns a service with a webhook of the workflow "wf" mapping payloads with mapping.
+func newWebhook(t *testing.T, mapping map[string]string) (webhook.Service, *webhook.Webhook, *fakeRepository, *fakeExecutor) {
+	t.Helper()
+
+	repo := &fakeRepository{}
+	executor := &fakeExecutor{}
+	svc := webhook.NewService(repo, executor, tolerance)
+
+	created, err := svc.Create(context.Background(), "wf", &webhook.WebhookInput{Mapping: mapping})
+	require.NoError(t, err)
+
+	return svc, created, repo, executor
+}
+
+// signed returns a delivery of body sent at sentAt, signed with secret.
+func signed(secret string, sentAt time.Time, nonce string, body string) *webhook.Delivery {
+	timestamp := strconv.FormatInt(sentAt.Unix(), 10)
+
+	return &webhook.Delivery{
+		Timestamp: timestamp,
+		Nonce:     nonce,
+		Signature: signature.Sign(secret, timestamp, nonce, []byte(body)),
+		Body:      []byte(body),
+	}
+}
+
+func TestTrigger(t *testing.T) {
+	alert := `{"alert":{"location":{"city":"Sydney"},"level":3},"recipients":[{"email":"ops@example.com"}]}`
+
+	tests := []struct {
+		name             string
+		mapping          map[string]string
+		body             string
+		expectedFormData map[string]any
+		expectedFields   map[string]string
+	}{
+		{
+			name:             "payload used as the form data",
+			body:             `{"city":"Sydney","threshold":25}`,
+			expectedFormData: map[string]any{"city": "Sydney", "threshold": 25.0},
+		},
+		{
+			name: "mapped payload",
+			mapping: map[string]string{
+				"city":  "alert.location.city",
+				"level": "alert.level",
+				"email": "recipients.0.email",
+			},
+			body:             alert,
+			expectedFormData: map[string]any{"city": "Sydney", "level": 3.0, "email": "ops@example.com"},
+		},
+		{
+			name: "missing paths left out",
+			mapping: map[string]string{
+				"city":    "alert.location.city",
+				"country": "alert.location.country",
+				"cc":      "recipients.1.email",
+				"nested":  "alert.level.value",
+			},
+			body:             alert,
+			expectedFormData: map[string]any{"city": "Sydney"},
+		},
+		{
+			name:             "whole payload mapped to a variable",
+			mapping:          map[string]string{"alert": ""},
+			body:             `["a","b"]`,
+			expectedFormData: map[string]any{"alert": []any{"a", "b"}},
+		},
+		{
+			name:           "payload that is not an object",
+			body:           `["a","b"]`,
+			expectedFields: map[string]string{"payload": "must be a JSON object"},
+		},
+		{
+			name:           "invalid JSON",
+			body:           `{"city":`,
+			expectedFields: map[string]string{"payload": "must be valid JSON"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, hook, _, executor := newWebhook(t, tt.mapping)
+
+			result, err := svc.Trigger(context.Background(), hook.ID, signed(hook.Secret, time.Now(), "nonce", tt.body))
+			if tt.expectedFields != nil {
+				var validationErr *workflow.ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				require.Equal(t, tt.expectedFields, validationErr.Fields)
+				require.Empty(t, executor.Executions())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "1", result.ExecutionID)
+
+			require.Equal(t, []execution{{workflowID: "wf", formData: tt.expectedFormData}}, executor.Executions())
+		})
+	}
+}
+
+func TestTrigger_Rejected(t *testing.T) {
+	const body = `{"city":"Sydney"}`
+
+	tests := []struct {
+		name          string
+		delivery      func(hook *webhook.Webhook) *webhook.Delivery
+		expectedError error
+	}{
+		{
+			name: "signed with another secret",
+			delivery: func(hook *webhook.Webhook) *webhook.Delivery {
+				return signed("whsec_other", time.Now(), "nonce", body)
+			},
+			expectedError: signature.ErrInvalidSignature,
+		},
+		{
+			name: "tampered body",
+			delivery: func(hook *webhook.Webhook) *webhook.Delivery {
+				delivery := signed(hook.Secret, time.Now(), "nonce", body)
+				delivery.Body = []byte(`{"city":"Perth"}`)
+				return delivery
+			},
+			expectedError: signature.ErrInvalidSignature,
+		},
+		{
+			name: "signature without prefix",
+			delivery: func(hook *webhook.Webhook) *webhook.Delivery {
+				delivery := signed(hook.Secret, time.Now(), "nonce", body)
+				delivery.Signature = delivery.Signature[len("sha256="):]
+				return delivery
+			},
+			expectedError: signature.ErrInvalidSignature,
+		},
+		{
+			name: "missing nonce",
+			delivery: func(hook *webhook.Webhook) *webhook.Delivery {
+				return signed(hook.Secret, time.Now(), "", body)
+			},
+			expectedError: signature.ErrMissingHeaders,
+		},
+		{
+			name: "stale timestamp",
+			delivery: func(hook *webhook.Webhook) *webhook.Delivery {
+				return signed(hook.Secret, time.Now().Add(-tolerance-time.Minute), "nonce", body)
+			},
+			expectedError: signature.ErrTimestampExpired,
+		},
+		{
+			name: "timestamp in the future",
+			delivery: func(hook *webhook.Webhook) *webhook.Delivery {
+				return signed(hook.Secret, time.Now().Add(tolerance+time.Minute), "nonce", body)
+			},
+			expectedError: signature.ErrTimestampExpired,
+		},
+		{
+			name: "timestamp that is not a Unix time",
+			delivery: func(hook *webhook.Webhook) *webhook.Delivery {
+				delivery := signed(hook.Secret, time.Now(), "nonce", body)
+				delivery.Timestamp = time.Now().Format(time.RFC3339)
+				delivery.Signature = signature.Sign(hook.Secret, delivery.Timestamp, delivery.Nonce, delivery.Body)
+				return delivery
+			},
+			expectedError: signature.ErrInvalidTimestamp,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, hook, repo, executor := newWebhook(t, nil)
+
+			_, err := svc.Trigger(context.Background(), hook.ID, tt.delivery(hook))
+			require.ErrorIs(t, err, tt.expectedError)
+			require.Empty(t, executor.Executions())
+
+			// Rejected deliveries do not use up their nonce
+			require.Zero(t, repo.recordedNonces())
+		})
+	}
+
+	t.Run("unknown webhook", func(t *testing.T) {
+		svc, hook, _, executor := newWebhook(t, nil)
+
+		_, err := svc.Trigger(context.Background(), "missing", signed(hook.Secret, time.Now(), "nonce", body))
+		require.ErrorIs(t, err, webhook.ErrWebhookNotFound)
+		require.Empty(t, executor.Executions())
+	})
+}
+
+func TestTrigger_Replayed(t *testing.T) {
+	const body = `{"city":"Sydney"}`
+
+	svc, hook, repo, executor := newWebhook(t, nil)
+	other, err := svc.Create(context.Background(), "wf", &webhook.WebhookInput{})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	delivery := signed(hook.Secret, time.Now(), "nonce", body)
+	before := time.Now()
+	_, err = svc.Trigger(ctx, hook.ID, delivery)
+	require.NoError(t, err)
+
+	// Nonces are kept for twice the tolerance, covering every timestamp still accepted
+	require.WithinRange(t, repo.expireBefore, before.Add(-2*tolerance), time.Now().Add(-2*tolerance))
+
+	_, err = svc.Trigger(ctx, hook.ID, delivery)
+	require.ErrorIs(t, err, webhook.ErrReplayed)
+	require.Len(t, executor.Executions(), 1)
+
+	// Another nonce, or the same nonce sent to another webhook, is a new delivery
+	_, err = svc.Trigger(ctx, hook.ID, signed(hook.Secret, time.Now(), "other-nonce", body))
+	require.NoError(t, err)
+
+	_, err = svc.Trigger(ctx, other.ID, signed(other.Secret, time.Now(), "nonce", body))
+	require.NoError(t, err)
+	require.Len(t, executor.Executions(), 3)
+}
@@ -0,0 +1,39 @@
+package webhook
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrWebhookNotFound  = errors.New("webhook not found")
+	ErrWorkflowNotFound = errors.New("workflow not found")
+	ErrReplayed         = errors.New("webhook delivery replayed")
+)
+
+// Webhook lets external systems start executions of a workflow with signed HTTP requests.
+type Webhook struct {
+	ID         string `json:"id"`
+	WorkflowID string `json:"workflowId"`
+	// Secret keys the HMAC signature of deliveries. It is only returned when the webhook is created.
+	Secret string `json:"secret,omitempty"`
+	// Mapping maps each form variable to the dot separated path of its value in the JSON payload.
+	// An empty mapping uses the payload object as the form data.
+	Mapping   map[string]string `json:"mapping"`
+	URL       string            `json:"url"`
+	CreatedAt time.Time         `json:"createdAt"`
+	UpdatedAt time.Time         `json:"updatedAt"`
+}
+
+// WebhookInput creates a webhook.
+type WebhookInput struct {
+	Mapping map[string]string `json:"mapping"`
+}
+
+// Delivery is an inbound webhook request.
+type Delivery struct {
+	Timestamp string
+	Nonce     string
+	Signature string
+	Body      []byte
+}
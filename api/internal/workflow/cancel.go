@@ -0,0 +1,108 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+// cancelCheckInterval is how often a running execution checks whether it was asked to stop.
+const cancelCheckInterval = time.Second
+
+// Cancel implements Service.
+func (s *ServiceImpl) Cancel(ctx context.Context, executionID string) (*ExecutionResult, error) {
+	if _, err := s.repo.Execution(ctx, executionID); err != nil {
+		return nil, err
+	}
+
+	// The execution may be resumed or suspended in between, in which case the other attempt applies
+	for range 2 {
+		cancelled, err := s.repo.CancelExecution(ctx, executionID)
+		if err != nil {
+			return nil, err
+		}
+		if cancelled {
+			execution, err := s.repo.Execution(ctx, executionID)
+			if err != nil {
+				return nil, err
+			}
+
+			s.publishStatus(execution, nil)
+			observeFinished(execution)
+			return execution.Result(), nil
+		}
+
+		requested, err := s.repo.RequestCancel(ctx, executionID)
+		if err != nil {
+			return nil, err
+		}
+		if requested {
+			execution, err := s.repo.Execution(ctx, executionID)
+			if err != nil {
+				return nil, err
+			}
+
+			return execution.Result(), nil
+		}
+	}
+
+	return nil, ErrExecutionFinished
+}
+
+// watchCancellation returns the context an execution runs with, cancelled with ErrExecutionCancelled once the
+// execution is asked to stop, possibly through another API instance, or once the execution running it is.
+// It is detached from any other cancellation of ctx, so a client going away, a request timing out or the server
+// shutting down does not stop the execution. The returned function stops watching.
+func (s *ServiceImpl) watchCancellation(ctx context.Context, executionID string) (context.Context, context.CancelFunc) {
+	parentCtx := ctx
+	parent := parentCtx.Done()
+	ctx, cancel := context.WithCancelCause(context.WithoutCancel(parentCtx))
+
+	go func() {
+		ticker := time.NewTicker(cancelCheckInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-parent:
+				if cancelRequested(parentCtx) {
+					cancel(ErrExecutionCancelled)
+					return
+				}
+				parent = nil
+			case <-ticker.C:
+				requested, err := s.repo.CancelRequested(ctx, executionID)
+				if err != nil {
+					s.log.WarnContext(ctx, "problem checking execution cancellation", slog.Any("ID", executionID), slog.Any("ERROR", err))
+					continue
+				}
+				if requested {
+					cancel(ErrExecutionCancelled)
+					return
+				}
+			}
+		}
+	}()
+
+	return ctx, func() { cancel(nil) }
+}
+
+// cancelRequested reports whether the execution running with ctx was asked to stop. Contexts of executions are
+// only cancelled for that reason, and other errors of ctx are ignored.
+func cancelRequested(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), ErrExecutionCancelled)
+}
+
+// cancelled ends an execution whose context is done, keeping the steps that ran.
+func (s *ServiceImpl) cancelled(ctx context.Context, execution *Execution) (*ExecutionResult, error) {
+	s.log.InfoContext(ctx, "execution cancelled", slog.Any("ID", execution.ID), slog.Any("CAUSE", context.Cause(ctx)))
+
+	execution.Status = ExecutionStatusCancelled
+	execution.ResumeAt = nil
+	execution.WaitingFor = nil
+
+	return s.save(ctx, execution, nil)
+}
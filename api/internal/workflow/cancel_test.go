@@ -0,0 +1,175 @@
+package workflow_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"workflow-code-test/api/internal/workflow"
+	_ "workflow-code-test/api/pkg/nodes/delay"
+	"workflow-code-test/api/pkg/nodes/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+// blockingKinds returns the kinds of a workflow whose block node runs until the execution is cancelled, then finishes
+// as returned by finish, before an after node. The ID of the execution is sent to started once the block node runs.
+func blockingKinds(finish func(ctx context.Context) (any, error)) (map[string]*testKind, <-chan string) {
+	started := make(chan string, 1)
+
+	return map[string]*testKind{
+		"block": {run: func(ctx context.Context, _ int, _ map[string]any) (any, error) {
+			started <- types.ExecutionID(ctx)
+			<-ctx.Done()
+			return finish(ctx)
+		}},
+		"after": {},
+	}, started
+}
+
+// runningExecution starts an execution of the blocking workflow and returns its ID once the block node runs,
+// along with the channel the result of the execution is sent to.
+func runningExecution(t *testing.T, svc workflow.Service, started <-chan string) (string, <-chan *workflow.ExecutionResult) {
+	t.Helper()
+
+	results := make(chan *workflow.ExecutionResult, 1)
+	go func() {
+		result, _ := svc.Execute(context.Background(), "blocking", &workflow.ExecutionInput{})
+		results <- result
+	}()
+
+	select {
+	case id := <-started:
+		return id, results
+	case <-time.After(5 * time.Second):
+		t.Fatal("block node did not start")
+		return "", nil
+	}
+}
+
+func TestCancel_Running(t *testing.T) {
+	tests := []struct {
+		name          string
+		finish        func(ctx context.Context) (any, error)
+		expectedBlock workflow.StepStatus
+	}{
+		{
+			name:          "node stopped by the cancellation",
+			finish:        func(ctx context.Context) (any, error) { return nil, context.Cause(ctx) },
+			expectedBlock: workflow.StepStatusFailed,
+		},
+		{
+			name:          "node completing despite the cancellation",
+			finish:        func(ctx context.Context) (any, error) { return map[string]any{"done": "yes"}, nil },
+			expectedBlock: workflow.StepStatusCompleted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := workflow.NewMemoryRepository()
+			addChain(t, repo, "blocking", newNode("block", "block", nil), newNode("after", "after", nil))
+			kinds, started := blockingKinds(tt.finish)
+			svc := newService(t, repo, kinds)
+			ctx := context.Background()
+
+			id, results := runningExecution(t, svc, started)
+
+			result, err := svc.Cancel(ctx, id)
+			require.NoError(t, err)
+			require.Equal(t, workflow.ExecutionStatusRunning, result.Status)
+			require.True(t, result.CancelRequested)
+
+			result = <-results
+			require.Equal(t, workflow.ExecutionStatusCancelled, result.Status)
+			require.Equal(t, []string{"start", "block"}, stepIDs(result.Steps))
+			require.Equal(t, tt.expectedBlock, result.Steps[1].Status)
+			require.Empty(t, kinds["after"].Runs())
+
+			execution, err := repo.Execution(ctx, id)
+			require.NoError(t, err)
+			require.Equal(t, workflow.ExecutionStatusCancelled, execution.Status)
+			require.NotNil(t, execution.FinishedAt)
+		})
+	}
+}
+
+// TestCancel_OtherInstance cancels an execution run by another service sharing the repository, as another API
+// instance would, which the running service picks up by polling the repository.
+func TestCancel_OtherInstance(t *testing.T) {
+	repo := workflow.NewMemoryRepository()
+	addChain(t, repo, "blocking", newNode("block", "block", nil), newNode("after", "after", nil))
+	kinds, started := blockingKinds(func(ctx context.Context) (any, error) { return nil, context.Cause(ctx) })
+	running := newService(t, repo, kinds)
+	other := newService(t, repo, nil)
+	ctx := context.Background()
+
+	id, results := runningExecution(t, running, started)
+
+	requestedAt := time.Now()
+	_, err := other.Cancel(ctx, id)
+	require.NoError(t, err)
+
+	select {
+	case result := <-results:
+		require.Equal(t, workflow.ExecutionStatusCancelled, result.Status)
+		require.Equal(t, []string{"start", "block"}, stepIDs(result.Steps))
+		require.Contains(t, result.Steps[1].Error, workflow.ErrExecutionCancelled.Error())
+	case <-time.After(5 * time.Second):
+		t.Fatal("execution was not cancelled")
+	}
+
+	// The cancellation is checked every second
+	require.Less(t, time.Since(requestedAt), 3*time.Second)
+	require.Empty(t, kinds["after"].Runs())
+}
+
+func TestCancel_Waiting(t *testing.T) {
+	repo := workflow.NewMemoryRepository()
+	addChain(t, repo, "delayed", newNode("delay", "delay", map[string]any{"duration": "1ms"}), newNode("after", "after", nil))
+	kinds := map[string]*testKind{"after": {}}
+	svc := newService(t, repo, kinds)
+	ctx := context.Background()
+
+	result, err := svc.Execute(ctx, "delayed", &workflow.ExecutionInput{})
+	require.NoError(t, err)
+	require.Equal(t, workflow.ExecutionStatusWaiting, result.Status)
+
+	result, err = svc.Cancel(ctx, result.ExecutionID)
+	require.NoError(t, err)
+	require.Equal(t, workflow.ExecutionStatusCancelled, result.Status)
+	require.Nil(t, result.ResumeAt)
+	require.NotNil(t, result.FinishedAt)
+
+	// The delay has elapsed, but the cancelled execution is not resumed
+	time.Sleep(10 * time.Millisecond)
+	resumed, err := svc.ResumeDue(ctx)
+	require.NoError(t, err)
+	require.Zero(t, resumed)
+	require.Empty(t, kinds["after"].Runs())
+
+	execution, err := repo.Execution(ctx, result.ExecutionID)
+	require.NoError(t, err)
+	require.Equal(t, workflow.ExecutionStatusCancelled, execution.Status)
+	require.Equal(t, []string{"start", "delay"}, stepIDs(execution.Steps))
+}
+
+func TestCancel_Finished(t *testing.T) {
+	repo := workflow.NewMemoryRepository()
+	addChain(t, repo, "quick", newNode("after", "after", nil))
+	svc := newService(t, repo, map[string]*testKind{"after": {}})
+	ctx := context.Background()
+
+	result, err := svc.Execute(ctx, "quick", &workflow.ExecutionInput{})
+	require.NoError(t, err)
+	require.Equal(t, workflow.ExecutionStatusCompleted, result.Status)
+
+	_, err = svc.Cancel(ctx, result.ExecutionID)
+	require.ErrorIs(t, err, workflow.ErrExecutionFinished)
+
+	execution, err := repo.Execution(ctx, result.ExecutionID)
+	require.NoError(t, err)
+	require.Equal(t, workflow.ExecutionStatusCompleted, execution.Status)
+
+	_, err = svc.Cancel(ctx, "missing")
+	require.ErrorIs(t, err, workflow.ErrExecutionNotFound)
+}
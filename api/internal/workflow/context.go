@@ -0,0 +1,39 @@
+package workflow
+
+import "context"
+
+type contextKey int
+
+const (
+	stepHookKey contextKey = iota
+	dryRunKey
+)
+
+// StepHook is called with each step of an execution as soon as it is recorded, from the start step to the end step.
+// Executions started by the nodes of the execution, such as subworkflow and foreach nodes, share the hook of their
+// parent, so it may be called concurrently.
+type StepHook func(ctx context.Context, executionID string, step Step)
+
+// WithStepHook returns a context calling hook with the steps of the executions run with it.
+func WithStepHook(ctx context.Context, hook StepHook) context.Context {
+	return context.WithValue(ctx, stepHookKey, hook)
+}
+
+// notifyStep calls the step hook of ctx, if any.
+func notifyStep(ctx context.Context, executionID string, step Step) {
+	if hook, ok := ctx.Value(stepHookKey).(StepHook); ok {
+		hook(ctx, executionID, step)
+	}
+}
+
+// WithDryRun returns a context running executions as dry runs: nodes with side effects are simulated rather than
+// executed and executions are not persisted. Executions started by the nodes of a dry run are dry runs too.
+func WithDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunKey, true)
+}
+
+// isDryRun reports whether executions run with ctx are dry runs.
+func isDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunKey).(bool)
+	return dryRun
+}
@@ -0,0 +1,157 @@
+package workflow
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"workflow-code-test/api/internal/node"
+)
+
+// Inspect implements Service.
+func (s *ServiceImpl) Inspect(ctx context.Context, executionID string) (*DebugSnapshot, error) {
+	execution, err := s.repo.Execution(ctx, executionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return debugSnapshot(execution), nil
+}
+
+// UpdateDebug implements Service.
+func (s *ServiceImpl) UpdateDebug(ctx context.Context, executionID string, input *DebugInput) (*DebugSnapshot, error) {
+	execution, err := s.pausedExecution(ctx, executionID)
+	if err != nil {
+		return nil, err
+	}
+
+	wf, err := s.executionWorkflow(ctx, execution)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateBreakpoints(wf, "breakpoints", input.Breakpoints); err != nil {
+		return nil, err
+	}
+
+	if execution.State.Variables == nil {
+		execution.State.Variables = map[string]any{}
+	}
+	for name, value := range input.Variables {
+		if value == nil {
+			delete(execution.State.Variables, name)
+			continue
+		}
+		execution.State.Variables[name] = value
+	}
+
+	if input.Breakpoints != nil {
+		execution.Debug.Breakpoints = input.Breakpoints
+	}
+
+	updated, err := s.repo.UpdatePausedExecution(ctx, execution)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, ErrExecutionNotPaused
+	}
+
+	return debugSnapshot(execution), nil
+}
+
+// Step implements Service.
+func (s *ServiceImpl) Step(ctx context.Context, executionID string) (*ExecutionResult, error) {
+	return s.continueDebug(ctx, executionID, true)
+}
+
+// Continue implements Service.
+func (s *ServiceImpl) Continue(ctx context.Context, executionID string) (*ExecutionResult, error) {
+	return s.continueDebug(ctx, executionID, false)
+}
+
+// continueDebug runs a paused execution from the node it is paused at, until its next breakpoint or, when stepping,
+// until the node after it.
+func (s *ServiceImpl) continueDebug(ctx context.Context, executionID string, stepping bool) (*ExecutionResult, error) {
+	execution, err := s.pausedExecution(ctx, executionID)
+	if err != nil {
+		return nil, err
+	}
+
+	claimed, err := s.repo.ClaimPausedExecution(ctx, executionID)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, ErrExecutionNotPaused
+	}
+
+	execution.Status = ExecutionStatusRunning
+	execution.Debug.Stepping = stepping
+	s.publish(execution, Event{Type: EventExecutionResumed, Status: execution.Status})
+
+	wf, err := s.executionWorkflow(ctx, execution)
+	if err != nil {
+		execution.Status = ExecutionStatusFailed
+		return s.save(ctx, execution, err)
+	}
+
+	return s.run(ctx, wf, execution)
+}
+
+// pausedExecution loads an execution, returning ErrExecutionNotPaused if it is not paused by the debugger.
+func (s *ServiceImpl) pausedExecution(ctx context.Context, executionID string) (*Execution, error) {
+	execution, err := s.repo.Execution(ctx, executionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if execution.Status != ExecutionStatusPaused || execution.Debug == nil {
+		return nil, ErrExecutionNotPaused
+	}
+
+	return execution, nil
+}
+
+// pauseBefore reports whether the execution pauses before running the node, recording the node it paused at.
+// An execution continued from a node does not pause at it again.
+func pauseBefore(execution *Execution, nodeID string) bool {
+	debug := execution.Debug
+	if debug == nil {
+		return false
+	}
+
+	continuedAt := debug.PausedAt
+	debug.PausedAt = ""
+	if nodeID == continuedAt || !debug.Stepping && !slices.Contains(debug.Breakpoints, nodeID) {
+		return false
+	}
+
+	debug.PausedAt = nodeID
+	return true
+}
+
+// validateBreakpoints checks that every breakpoint is a node of the workflow. Errors are keyed by "<field>.<nodeID>".
+func validateBreakpoints(wf *Workflow, field string, breakpoints []string) error {
+	fieldErrors := map[string]string{}
+	for _, nodeID := range breakpoints {
+		if !slices.ContainsFunc(wf.Nodes, func(n node.Node) bool { return n.ID == nodeID }) {
+			fieldErrors[field+"."+nodeID] = "is not a node of the workflow"
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return &ValidationError{Fields: fieldErrors}
+	}
+
+	return nil
+}
+
+func debugSnapshot(execution *Execution) *DebugSnapshot {
+	return &DebugSnapshot{
+		ExecutionID: execution.ID,
+		Status:      execution.Status,
+		Debug:       execution.Debug,
+		Variables:   maps.Clone(execution.State.Variables),
+		Steps:       execution.Steps,
+	}
+}
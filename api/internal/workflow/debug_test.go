@@ -0,0 +1,147 @@
+package workflow_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"workflow-code-test/api/internal/workflow"
+
+	"github.com/stretchr/testify/require"
+)
+
+// debugged adds a workflow running the nodes a, b and c, each setting the variable named after it, and returns a
+// service running it.
+func debugged(t *testing.T) (*workflow.MemoryRepository, workflow.Service, *testKind) {
+	t.Helper()
+
+	repo := workflow.NewMemoryRepository()
+	addChain(t, repo, "debugged", newNode("a", "work", nil), newNode("b", "work", nil), newNode("c", "work", nil))
+	variables := []string{"a", "b", "c"}
+	kind := &testKind{run: func(ctx context.Context, n int, _ map[string]any) (any, error) {
+		return map[string]any{variables[n-1]: fmt.Sprintf("run %d", n)}, nil
+	}}
+
+	return repo, newService(t, repo, map[string]*testKind{"work": kind}), kind
+}
+
+func TestDebug_Breakpoints(t *testing.T) {
+	repo, svc, kind := debugged(t)
+	ctx := context.Background()
+
+	result, err := svc.Execute(ctx, "debugged", &workflow.ExecutionInput{
+		FormData: map[string]any{"city": "Sydney"},
+		Debug:    &workflow.Debug{Breakpoints: []string{"b"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, workflow.ExecutionStatusPaused, result.Status)
+	require.Equal(t, &workflow.Debug{Breakpoints: []string{"b"}, PausedAt: "b"}, result.Debug)
+	require.Equal(t, []string{"start", "a"}, stepIDs(result.Steps))
+	require.Nil(t, result.FinishedAt)
+
+	snapshot, err := svc.Inspect(ctx, result.ExecutionID)
+	require.NoError(t, err)
+	require.Equal(t, workflow.ExecutionStatusPaused, snapshot.Status)
+	require.Equal(t, "b", snapshot.Debug.PausedAt)
+	require.Equal(t, map[string]any{"city": "Sydney", "a": "run 1"}, snapshot.Variables)
+
+	// The paused node runs with the updated variables, and the execution pauses at the new breakpoint
+	snapshot, err = svc.UpdateDebug(ctx, result.ExecutionID, &workflow.DebugInput{
+		Variables:   map[string]any{"city": "Perth", "a": nil},
+		Breakpoints: []string{"c"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"city": "Perth"}, snapshot.Variables)
+	require.Equal(t, &workflow.Debug{Breakpoints: []string{"c"}, PausedAt: "b"}, snapshot.Debug)
+
+	result, err = svc.Continue(ctx, result.ExecutionID)
+	require.NoError(t, err)
+	require.Equal(t, workflow.ExecutionStatusPaused, result.Status)
+	require.Equal(t, &workflow.Debug{Breakpoints: []string{"c"}, PausedAt: "c"}, result.Debug)
+	require.Equal(t, []string{"start", "a", "b"}, stepIDs(result.Steps))
+
+	runs := kind.Runs()
+	require.Len(t, runs, 2)
+	require.Equal(t, "Perth", runs[1]["city"])
+	require.NotContains(t, runs[1], "a")
+
+	result, err = svc.Continue(ctx, result.ExecutionID)
+	require.NoError(t, err)
+	require.Equal(t, workflow.ExecutionStatusCompleted, result.Status)
+	require.Equal(t, &workflow.Debug{Breakpoints: []string{"c"}}, result.Debug)
+	require.Equal(t, []string{"start", "a", "b", "c", "end"}, stepIDs(result.Steps))
+	require.Equal(t, map[string]any{"city": "Perth", "b": "run 2", "c": "run 3"}, result.Variables)
+
+	execution, err := repo.Execution(ctx, result.ExecutionID)
+	require.NoError(t, err)
+	require.Equal(t, workflow.ExecutionStatusCompleted, execution.Status)
+	require.Empty(t, execution.Debug.PausedAt)
+
+	t.Run("finished execution is not paused", func(t *testing.T) {
+		_, err := svc.Continue(ctx, result.ExecutionID)
+		require.ErrorIs(t, err, workflow.ErrExecutionNotPaused)
+
+		_, err = svc.Step(ctx, result.ExecutionID)
+		require.ErrorIs(t, err, workflow.ErrExecutionNotPaused)
+
+		_, err = svc.UpdateDebug(ctx, result.ExecutionID, &workflow.DebugInput{})
+		require.ErrorIs(t, err, workflow.ErrExecutionNotPaused)
+		require.Len(t, kind.Runs(), 3)
+	})
+}
+
+func TestDebug_Stepping(t *testing.T) {
+	_, svc, kind := debugged(t)
+	ctx := context.Background()
+
+	result, err := svc.Execute(ctx, "debugged", &workflow.ExecutionInput{
+		Debug: &workflow.Debug{Stepping: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, workflow.ExecutionStatusPaused, result.Status)
+	require.Equal(t, &workflow.Debug{Breakpoints: []string{}, Stepping: true, PausedAt: "a"}, result.Debug)
+	require.Equal(t, []string{"start"}, stepIDs(result.Steps))
+	require.Empty(t, kind.Runs())
+
+	// Every step runs a single node
+	for i, next := range []string{"b", "c"} {
+		result, err = svc.Step(ctx, result.ExecutionID)
+		require.NoError(t, err)
+		require.Equal(t, workflow.ExecutionStatusPaused, result.Status)
+		require.Equal(t, &workflow.Debug{Breakpoints: []string{}, Stepping: true, PausedAt: next}, result.Debug)
+		require.Len(t, kind.Runs(), i+1)
+	}
+
+	// Continuing stops stepping, running the remaining nodes
+	result, err = svc.Continue(ctx, result.ExecutionID)
+	require.NoError(t, err)
+	require.Equal(t, workflow.ExecutionStatusCompleted, result.Status)
+	require.Equal(t, &workflow.Debug{Breakpoints: []string{}}, result.Debug)
+	require.Equal(t, []string{"start", "a", "b", "c", "end"}, stepIDs(result.Steps))
+	require.Len(t, kind.Runs(), 3)
+}
+
+func TestDebug_UnknownBreakpoint(t *testing.T) {
+	_, svc, kind := debugged(t)
+	ctx := context.Background()
+
+	_, err := svc.Execute(ctx, "debugged", &workflow.ExecutionInput{
+		Debug: &workflow.Debug{Breakpoints: []string{"a", "missing"}},
+	})
+	var validationErr *workflow.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Equal(t, map[string]string{"debug.breakpoints.missing": "is not a node of the workflow"}, validationErr.Fields)
+	require.Empty(t, kind.Runs())
+
+	result, err := svc.Execute(ctx, "debugged", &workflow.ExecutionInput{
+		Debug: &workflow.Debug{Breakpoints: []string{"a"}},
+	})
+	require.NoError(t, err)
+
+	_, err = svc.UpdateDebug(ctx, result.ExecutionID, &workflow.DebugInput{Breakpoints: []string{"missing"}})
+	require.ErrorAs(t, err, &validationErr)
+	require.Equal(t, map[string]string{"breakpoints.missing": "is not a node of the workflow"}, validationErr.Fields)
+
+	snapshot, err := svc.Inspect(ctx, result.ExecutionID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a"}, snapshot.Debug.Breakpoints)
+}
@@ -0,0 +1,180 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+	"workflow-code-test/api/internal/edge"
+	"workflow-code-test/api/internal/node"
+	"workflow-code-test/api/pkg/document"
+	"workflow-code-test/api/pkg/nodes/subworkflow"
+
+	"github.com/google/uuid"
+)
+
+// Export implements Service.
+func (s *ServiceImpl) Export(ctx context.Context, workflowID string) (*document.Document, error) {
+	wf, err := s.repo.WorkflowWithNodesAndEdges(ctx, workflowID)
+	if err != nil {
+		return nil, err
+	}
+
+	exportedAt := time.Now().UTC()
+	doc := &document.Document{
+		Version: document.CurrentVersion,
+		Workflow: document.Workflow{
+			ID:         wf.ID,
+			Name:       wf.Name,
+			Version:    wf.Version,
+			ExportedAt: &exportedAt,
+		},
+		Nodes: make([]document.Node, 0, len(wf.Nodes)),
+		Edges: make([]document.Edge, 0, len(wf.Edges)),
+	}
+
+	for _, n := range wf.Nodes {
+		doc.Nodes = append(doc.Nodes, document.Node{
+			ID:       n.ID,
+			Type:     n.Kind,
+			Position: document.Position(n.Position),
+			Data: document.Data{
+				Label:       n.Data.Label,
+				Description: n.Data.Description,
+				Metadata:    n.Data.Metadata,
+			},
+		})
+	}
+
+	for _, e := range wf.Edges {
+		doc.Edges = append(doc.Edges, document.Edge{
+			Source:       e.Source,
+			Target:       e.Target,
+			Type:         e.Kind,
+			Animated:     e.Animated,
+			SourceHandle: e.SourceHandle,
+			Label:        e.Label,
+			Style:        e.Style,
+			LabelStyle:   e.LabelStyle,
+		})
+	}
+
+	return doc, nil
+}
+
+// Import implements Service.
+func (s *ServiceImpl) Import(ctx context.Context, doc *document.Document, input *ImportInput) (*ImportResult, error) {
+	if err := doc.Validate(); err != nil {
+		var validationErr *document.ValidationError
+		if errors.As(err, &validationErr) {
+			return nil, &ValidationError{Fields: validationErr.Fields}
+		}
+		return nil, err
+	}
+
+	workflowID := uuid.NewString()
+
+	workflowIDs := maps.Clone(input.WorkflowIDs)
+	if workflowIDs == nil {
+		workflowIDs = map[string]string{}
+	}
+	if _, ok := workflowIDs[doc.Workflow.ID]; !ok && doc.Workflow.ID != "" {
+		workflowIDs[doc.Workflow.ID] = workflowID
+	}
+
+	draft := &DraftInput{
+		Author:     input.Author,
+		ChangeNote: "Imported",
+		Nodes:      make([]node.Node, 0, len(doc.Nodes)),
+		Edges:      make([]edge.Edge, 0, len(doc.Edges)),
+	}
+	if doc.Workflow.ID != "" {
+		draft.ChangeNote = fmt.Sprintf("Imported from workflow %s", doc.Workflow.ID)
+	}
+
+	// Nodes running another workflow, such as subworkflow and foreach nodes, reference it by the same key
+	remapped := map[string]string{}
+	for _, n := range doc.Nodes {
+		metadata := maps.Clone(n.Data.Metadata)
+		if referenced, ok := metadata[subworkflow.WorkflowIDKey].(string); ok {
+			if target, ok := workflowIDs[referenced]; ok {
+				metadata[subworkflow.WorkflowIDKey] = target
+				remapped[referenced] = target
+			}
+		}
+
+		draft.Nodes = append(draft.Nodes, node.Node{
+			ID:       n.ID,
+			Kind:     n.Type,
+			Position: node.Position(n.Position),
+			Data: node.Data{
+				Label:       n.Data.Label,
+				Description: n.Data.Description,
+				Metadata:    metadata,
+			},
+		})
+	}
+
+	for _, e := range doc.Edges {
+		draft.Edges = append(draft.Edges, edge.Edge{
+			Source:       e.Source,
+			Target:       e.Target,
+			Kind:         e.Type,
+			Animated:     e.Animated,
+			SourceHandle: e.SourceHandle,
+			Label:        e.Label,
+			Style:        e.Style,
+			LabelStyle:   e.LabelStyle,
+		})
+	}
+
+	if err := s.validateDefinition(draft); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateReferences(ctx, draft.Nodes, workflowID); err != nil {
+		return nil, err
+	}
+
+	version, err := s.repo.CreateWorkflow(ctx, workflowID, doc.Workflow.Name, &Definition{Nodes: draft.Nodes, Edges: draft.Edges},
+		&PublishInput{Author: draft.Author, ChangeNote: draft.ChangeNote})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ImportResult{
+		WorkflowID:  workflowID,
+		Name:        doc.Workflow.Name,
+		Version:     version,
+		WorkflowIDs: remapped,
+	}, nil
+}
+
+// validateReferences checks that the workflows run by the imported nodes exist in this environment.
+// IDs set by a variable, such as "{{workflow}}", are only known when the node runs and are not checked.
+func (s *ServiceImpl) validateReferences(ctx context.Context, nodes []node.Node, workflowID string) error {
+	fieldErrors := map[string]string{}
+
+	for _, n := range nodes {
+		referenced, ok := n.Data.Metadata[subworkflow.WorkflowIDKey].(string)
+		if !ok || referenced == workflowID || uuid.Validate(referenced) != nil {
+			continue
+		}
+
+		_, err := s.repo.WorkflowWithNodesAndEdges(ctx, referenced)
+		if errors.Is(err, ErrWorkflowNotFound) {
+			fieldErrors["nodes."+n.ID] = fmt.Sprintf("workflow %s does not exist, map it to a workflow of this environment", referenced)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return &ValidationError{Fields: fieldErrors}
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package workflow_test
+
+import (
+	"context"
+	"testing"
+	"workflow-code-test/api/internal/workflow"
+	"workflow-code-test/api/pkg/document"
+	"workflow-code-test/api/pkg/nodes/foreach"
+	"workflow-code-test/api/pkg/nodes/subworkflow"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// exportedID is the ID of the workflow the documents were exported from
+	exportedID = "6f1c2f3e-8a4b-4c1d-9e2f-3a4b5c6d7e8f"
+	// childID is the ID of the workflow the documents run, in the environment they were exported from
+	childID = "0d9e8f7a-6b5c-4d3e-8f1a-2b3c4d5e6f7a"
+	// localChildID is the ID of that workflow in this environment
+	localChildID = "9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d"
+)
+
+// runningDocument returns a document whose subworkflow and foreach nodes run the workflow.
+func runningDocument(workflowID string) *document.Document {
+	return &document.Document{
+		Version:  document.CurrentVersion,
+		Workflow: document.Workflow{ID: exportedID, Name: "Exported"},
+		Nodes: []document.Node{
+			{ID: "start", Type: "start"},
+			{ID: "sub", Type: subworkflow.Descriptor.Kind, Data: document.Data{Metadata: map[string]any{subworkflow.WorkflowIDKey: workflowID}}},
+			{ID: "each", Type: foreach.Descriptor.Kind, Data: document.Data{Metadata: map[string]any{foreach.WorkflowIDKey: workflowID}}},
+			{ID: "end", Type: "end"},
+		},
+		Edges: []document.Edge{
+			{Source: "start", Target: "sub"},
+			{Source: "sub", Target: "each"},
+			{Source: "each", Target: "end"},
+		},
+	}
+}
+
+func TestImport(t *testing.T) {
+	tests := []struct {
+		name        string
+		workflowID  string
+		workflowIDs map[string]string
+		// expectedID is the workflow run by the imported nodes, "" for the imported workflow itself
+		expectedID       string
+		expectedRemapped func(importedID string) map[string]string
+	}{
+		{
+			name:        "mapped workflow",
+			workflowID:  childID,
+			workflowIDs: map[string]string{childID: localChildID},
+			expectedID:  localChildID,
+			expectedRemapped: func(string) map[string]string {
+				return map[string]string{childID: localChildID}
+			},
+		},
+		{
+			name:       "workflow existing in this environment",
+			workflowID: localChildID,
+			expectedID: localChildID,
+			expectedRemapped: func(string) map[string]string {
+				return map[string]string{}
+			},
+		},
+		{
+			name:       "workflow running itself",
+			workflowID: exportedID,
+			expectedRemapped: func(importedID string) map[string]string {
+				return map[string]string{exportedID: importedID}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := workflow.NewMemoryRepository()
+			addChain(t, repo, localChildID)
+			svc := newService(t, repo, map[string]*testKind{subworkflow.Descriptor.Kind: {}, foreach.Descriptor.Kind: {}})
+			ctx := context.Background()
+
+			result, err := svc.Import(ctx, runningDocument(tt.workflowID), &workflow.ImportInput{
+				Author:      "Jane",
+				WorkflowIDs: tt.workflowIDs,
+			})
+			require.NoError(t, err)
+			require.NotEqual(t, exportedID, result.WorkflowID)
+			require.Equal(t, tt.expectedRemapped(result.WorkflowID), result.WorkflowIDs)
+
+			expectedID := tt.expectedID
+			if expectedID == "" {
+				expectedID = result.WorkflowID
+			}
+
+			imported, err := svc.Workflow(ctx, result.WorkflowID)
+			require.NoError(t, err)
+			require.Equal(t, 1, imported.Version)
+			for _, n := range imported.Nodes[1:3] {
+				require.Equal(t, expectedID, n.Data.Metadata[subworkflow.WorkflowIDKey], n.ID)
+			}
+		})
+	}
+}
+
+func TestImport_MissingWorkflow(t *testing.T) {
+	repo := workflow.NewMemoryRepository()
+	addChain(t, repo, localChildID)
+	svc := newService(t, repo, map[string]*testKind{subworkflow.Descriptor.Kind: {}, foreach.Descriptor.Kind: {}})
+
+	// Mapping another workflow leaves the referenced one unknown
+	_, err := svc.Import(context.Background(), runningDocument(childID), &workflow.ImportInput{
+		WorkflowIDs: map[string]string{exportedID: localChildID},
+	})
+	var validationErr *workflow.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Equal(t, map[string]string{
+		"nodes.sub":  "workflow " + childID + " does not exist, map it to a workflow of this environment",
+		"nodes.each": "workflow " + childID + " does not exist, map it to a workflow of this environment",
+	}, validationErr.Fields)
+}
@@ -0,0 +1,53 @@
+package workflow
+
+import (
+	"context"
+	"time"
+)
+
+// dryRunRepository reads workflows from the repository of the service while keeping the executions of dry runs
+// in memory, so they are never persisted.
+type dryRunRepository struct {
+	Repository
+	executions *MemoryRepository
+}
+
+// CreateExecution implements Repository.
+func (r *dryRunRepository) CreateExecution(ctx context.Context, execution *Execution) error {
+	return r.executions.CreateExecution(ctx, execution)
+}
+
+// UpdateExecution implements Repository.
+func (r *dryRunRepository) UpdateExecution(ctx context.Context, execution *Execution) error {
+	return r.executions.UpdateExecution(ctx, execution)
+}
+
+// Execution implements Repository.
+func (r *dryRunRepository) Execution(ctx context.Context, executionID string) (*Execution, error) {
+	return r.executions.Execution(ctx, executionID)
+}
+
+// ClaimDueExecutions implements Repository. Dry runs are never resumed.
+func (r *dryRunRepository) ClaimDueExecutions(ctx context.Context, now, claimedUntil time.Time, limit int) ([]Execution, error) {
+	return []Execution{}, nil
+}
+
+// ClaimWaitingExecution implements Repository. Dry runs are never resumed.
+func (r *dryRunRepository) ClaimWaitingExecution(ctx context.Context, executionID string) (bool, error) {
+	return false, nil
+}
+
+// CancelRequested implements Repository.
+func (r *dryRunRepository) CancelRequested(ctx context.Context, executionID string) (bool, error) {
+	return r.executions.CancelRequested(ctx, executionID)
+}
+
+// dryRun returns a copy of the service running executions with the executions kept in memory.
+func (s *ServiceImpl) dryRun() *ServiceImpl {
+	return &ServiceImpl{
+		repo:        &dryRunRepository{Repository: s.repo, executions: NewMemoryRepository()},
+		nodeService: s.nodeService,
+		events:      s.events,
+		log:         s.log,
+	}
+}
@@ -0,0 +1,133 @@
+package workflow_test
+
+import (
+	"context"
+	"testing"
+	"workflow-code-test/api/internal/workflow"
+	"workflow-code-test/api/pkg/nodes/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+// notifyKinds returns the kinds of a workflow looking up a recipient before notifying them, a side effect
+// simulated in dry runs.
+func notifyKinds() map[string]*testKind {
+	return map[string]*testKind{
+		"lookup": {run: func(ctx context.Context, _ int, _ map[string]any) (any, error) {
+			return map[string]any{"recipient": "ops@example.com"}, nil
+		}},
+		"notify": {simulate: func(ctx context.Context, args map[string]any) (*types.Simulation, error) {
+			return &types.Simulation{Effect: map[string]any{"to": args["recipient"]}}, nil
+		}},
+		"after": {},
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	repo := workflow.NewMemoryRepository()
+	addChain(t, repo, "notified", newNode("lookup", "lookup", nil), newNode("notify", "notify", nil), newNode("after", "after", nil))
+	kinds := notifyKinds()
+	svc := newService(t, repo, kinds)
+	ctx := workflow.WithDryRun(context.Background())
+
+	result, err := svc.Execute(ctx, "notified", &workflow.ExecutionInput{})
+	require.NoError(t, err)
+	require.True(t, result.DryRun)
+	require.Equal(t, workflow.ExecutionStatusCompleted, result.Status)
+	require.Equal(t, []string{"start", "lookup", "notify", "after", "end"}, stepIDs(result.Steps))
+
+	// The side effect is simulated rather than run
+	require.Equal(t, map[string]any{"to": "ops@example.com"}, result.Steps[2].Simulation)
+	require.Empty(t, kinds["notify"].Runs())
+	require.Len(t, kinds["after"].Runs(), 1)
+
+	// Nothing is left in the repository of the service
+	_, err = repo.Execution(context.Background(), result.ExecutionID)
+	require.ErrorIs(t, err, workflow.ErrExecutionNotFound)
+}
+
+func TestDryRun_Mocks(t *testing.T) {
+	repo := workflow.NewMemoryRepository()
+	addChain(t, repo, "notified", newNode("lookup", "lookup", nil), newNode("notify", "notify", nil), newNode("after", "after", nil))
+	kinds := notifyKinds()
+	svc := newService(t, repo, kinds)
+	ctx := workflow.WithDryRun(context.Background())
+
+	result, err := svc.Execute(ctx, "notified", &workflow.ExecutionInput{
+		Mocks: map[string]map[string]any{"lookup": {"recipient": "test@example.com"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, workflow.ExecutionStatusCompleted, result.Status)
+
+	// The mocked node is not run, and the nodes after it read its mocked output
+	require.Empty(t, kinds["lookup"].Runs())
+	require.True(t, result.Steps[1].Mocked)
+	require.Equal(t, map[string]any{"recipient": "test@example.com"}, result.Steps[1].Output)
+	require.False(t, result.Steps[2].Mocked)
+	require.Equal(t, map[string]any{"to": "test@example.com"}, result.Steps[2].Simulation)
+}
+
+func TestDryRun_UnknownSimulatedOutputs(t *testing.T) {
+	repo := workflow.NewMemoryRepository()
+	addChain(t, repo, "notified",
+		newNode("notify", "notify", map[string]any{"outputVariables": []any{"messageId"}}),
+		newNode("after", "after", nil),
+	)
+	kinds := notifyKinds()
+	svc := newService(t, repo, kinds)
+	ctx := workflow.WithDryRun(context.Background())
+
+	result, err := svc.Execute(ctx, "notified", &workflow.ExecutionInput{})
+	require.ErrorContains(t, err, "outputs [messageId] are only known once it runs, mock them")
+	require.Equal(t, workflow.ExecutionStatusFailed, result.Status)
+	require.Empty(t, kinds["notify"].Runs())
+	require.Empty(t, kinds["after"].Runs())
+
+	result, err = svc.Execute(ctx, "notified", &workflow.ExecutionInput{
+		Mocks: map[string]map[string]any{"notify": {"messageId": "42"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, workflow.ExecutionStatusCompleted, result.Status)
+	require.Equal(t, "42", kinds["after"].Runs()[0]["messageId"])
+	require.Empty(t, kinds["notify"].Runs())
+}
+
+func TestDryRun_InvalidMocks(t *testing.T) {
+	tests := []struct {
+		name           string
+		dryRun         bool
+		expectedFields map[string]string
+	}{
+		{
+			name:           "mock of an unknown node",
+			dryRun:         true,
+			expectedFields: map[string]string{"mocks.missing": "is not a node of the workflow"},
+		},
+		{
+			name:           "mocks outside of a dry run",
+			expectedFields: map[string]string{"mocks": "only allowed in dry runs"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := workflow.NewMemoryRepository()
+			addChain(t, repo, "notified", newNode("lookup", "lookup", nil), newNode("notify", "notify", nil), newNode("after", "after", nil))
+			kinds := notifyKinds()
+			svc := newService(t, repo, kinds)
+
+			ctx := context.Background()
+			if tt.dryRun {
+				ctx = workflow.WithDryRun(ctx)
+			}
+
+			_, err := svc.Execute(ctx, "notified", &workflow.ExecutionInput{
+				Mocks: map[string]map[string]any{"missing": {"recipient": "test@example.com"}},
+			})
+			var validationErr *workflow.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			require.Equal(t, tt.expectedFields, validationErr.Fields)
+			require.Empty(t, kinds["lookup"].Runs())
+		})
+	}
+}
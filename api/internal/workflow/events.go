@@ -0,0 +1,169 @@
+package workflow
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+const (
+	// eventBuffer is the number of events buffered for a subscriber before events are dropped.
+	eventBuffer = 64
+
+	// eventsPollInterval is how often an execution stream checks whether the execution finished elsewhere,
+	// such as on another instance, whose events are not published to this process.
+	eventsPollInterval = time.Second
+)
+
+// Events implements Service.
+func (s *ServiceImpl) Events(ctx context.Context, executionID string) (<-chan Event, error) {
+	// Subscribe before loading the execution, so no event is missed in between
+	events, unsubscribe := s.events.Subscribe(executionTopic(executionID), eventBuffer)
+
+	execution, err := s.repo.Execution(ctx, executionID)
+	if err != nil {
+		unsubscribe()
+		return nil, err
+	}
+
+	out := make(chan Event, 1)
+
+	if finished(execution.Status) {
+		unsubscribe()
+		out <- finishedEvent(execution)
+		close(out)
+		return out, nil
+	}
+
+	go s.forward(ctx, events, unsubscribe, out, func(event Event) bool {
+		return event.Type == EventExecutionFinished
+	}, func(ctx context.Context) *Event {
+		return s.pollFinished(ctx, executionID)
+	})
+
+	return out, nil
+}
+
+// WorkflowEvents implements Service.
+func (s *ServiceImpl) WorkflowEvents(ctx context.Context, workflowID string) (<-chan Event, error) {
+	events, unsubscribe := s.events.Subscribe(workflowTopic(workflowID), eventBuffer)
+
+	if _, err := s.repo.WorkflowWithNodesAndEdges(ctx, workflowID); err != nil {
+		unsubscribe()
+		return nil, err
+	}
+
+	out := make(chan Event, 1)
+	go s.forward(ctx, events, unsubscribe, out, func(Event) bool { return false }, nil)
+
+	return out, nil
+}
+
+// forward sends the events of a subscription to out until ctx is done or last reports the final event,
+// then unsubscribes and closes out. Unless poll is nil, it is also called every eventsPollInterval for an event
+// missing from the subscription, once the events already received were sent.
+func (s *ServiceImpl) forward(ctx context.Context, events <-chan Event, unsubscribe func(), out chan<- Event, last func(Event) bool, poll func(context.Context) *Event) {
+	defer close(out)
+	defer unsubscribe()
+
+	var ticks <-chan time.Time
+	if poll != nil {
+		ticker := time.NewTicker(s.eventsPollInterval)
+		defer ticker.Stop()
+		ticks = ticker.C
+	}
+
+	for {
+		var event Event
+		select {
+		case <-ctx.Done():
+			return
+		case event = <-events:
+		case <-ticks:
+			if len(events) > 0 {
+				continue
+			}
+
+			polled := poll(ctx)
+			if polled == nil {
+				continue
+			}
+			event = *polled
+		}
+
+		select {
+		case out <- event:
+		case <-ctx.Done():
+			return
+		}
+
+		if last(event) {
+			return
+		}
+	}
+}
+
+// pollFinished returns the execution.finished event of the execution if it finished, nil otherwise.
+func (s *ServiceImpl) pollFinished(ctx context.Context, executionID string) *Event {
+	execution, err := s.repo.Execution(ctx, executionID)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.log.WarnContext(ctx, "problem polling execution", slog.Any("ID", executionID), slog.Any("ERROR", err))
+		}
+		return nil
+	}
+	if !finished(execution.Status) {
+		return nil
+	}
+
+	event := finishedEvent(execution)
+	return &event
+}
+
+// finishedEvent returns the execution.finished event of a finished execution, as of its last update.
+func finishedEvent(execution *Execution) Event {
+	return Event{
+		Type:        EventExecutionFinished,
+		ExecutionID: execution.ID,
+		WorkflowID:  execution.WorkflowID,
+		Status:      execution.Status,
+		Time:        execution.UpdatedAt,
+	}
+}
+
+// publish publishes an event of the execution to the subscribers of the execution and of its workflow,
+// stamped with the current time.
+func (s *ServiceImpl) publish(execution *Execution, event Event) {
+	event.ExecutionID = execution.ID
+	event.WorkflowID = execution.WorkflowID
+	event.Time = time.Now()
+
+	s.events.Publish(executionTopic(execution.ID), event)
+	s.events.Publish(workflowTopic(execution.WorkflowID), event)
+}
+
+// publishStatus publishes the event matching the status the execution ended a run with.
+func (s *ServiceImpl) publishStatus(execution *Execution, execErr error) {
+	event := Event{Type: EventExecutionSuspended, Status: execution.Status}
+	if finished(execution.Status) {
+		event.Type = EventExecutionFinished
+	}
+	if execErr != nil {
+		event.Error = execErr.Error()
+	}
+
+	s.publish(execution, event)
+}
+
+// finished reports whether an execution with the status will not run again.
+func finished(status ExecutionStatus) bool {
+	return status == ExecutionStatusCompleted || status == ExecutionStatusFailed || status == ExecutionStatusCancelled
+}
+
+func executionTopic(executionID string) string {
+	return "execution:" + executionID
+}
+
+func workflowTopic(workflowID string) string {
+	return "workflow:" + workflowID
+}
@@ -0,0 +1,156 @@
+package workflow_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"workflow-code-test/api/internal/workflow"
+
+	"github.com/stretchr/testify/require"
+)
+
+// eventTypes returns the types of the events until the channel is closed or n events were received,
+// failing the test if it takes too long. A negative n reads until the channel is closed.
+func eventTypes(t *testing.T, events <-chan workflow.Event, n int) []workflow.EventType {
+	t.Helper()
+
+	var types []workflow.EventType
+	timeout := time.After(5 * time.Second)
+	for len(types) != n {
+		select {
+		case event, open := <-events:
+			if !open {
+				return types
+			}
+			types = append(types, event.Type)
+		case <-timeout:
+			require.FailNow(t, "events were not received", "received %v", types)
+		}
+	}
+
+	return types
+}
+
+func TestEvents(t *testing.T) {
+	repo := workflow.NewMemoryRepository()
+	addApproval(t, repo)
+	svc := newService(t, repo, approvalKinds())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	workflowEvents, err := svc.WorkflowEvents(ctx, "approved")
+	require.NoError(t, err)
+
+	waiting, err := svc.Execute(ctx, "approved", &workflow.ExecutionInput{})
+	require.NoError(t, err)
+
+	events, err := svc.Events(ctx, waiting.ExecutionID)
+	require.NoError(t, err)
+
+	_, err = svc.Resume(ctx, waiting.ExecutionID, &workflow.ResumeInput{
+		Decision: workflow.DecisionApprove,
+		FormData: map[string]any{"reason": "Checked"},
+	})
+	require.NoError(t, err)
+
+	// The execution stream ends once the execution finished
+	resumed := []workflow.EventType{
+		workflow.EventExecutionResumed,
+		workflow.EventNodeStarted, workflow.EventNodeCompleted,
+		workflow.EventExecutionFinished,
+	}
+	require.Equal(t, resumed, eventTypes(t, events, -1))
+
+	// The workflow stream follows the execution from its start and stays open until ctx is done
+	started := []workflow.EventType{
+		workflow.EventExecutionStarted,
+		workflow.EventNodeStarted, workflow.EventNodeCompleted,
+		workflow.EventExecutionSuspended,
+	}
+	require.Equal(t, append(started, resumed...), eventTypes(t, workflowEvents, len(started)+len(resumed)))
+
+	cancel()
+	require.Empty(t, eventTypes(t, workflowEvents, -1))
+}
+
+func TestEvents_Failed(t *testing.T) {
+	repo := workflow.NewMemoryRepository()
+	addChain(t, repo, "broken", newNode("work", "work", nil), newNode("broken", "broken", nil))
+	svc := newService(t, repo, map[string]*testKind{
+		"work": {},
+		"broken": {run: func(context.Context, int, map[string]any) (any, error) {
+			return nil, errors.New("boom")
+		}},
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := svc.WorkflowEvents(ctx, "broken")
+	require.NoError(t, err)
+
+	result, err := svc.Execute(ctx, "broken", &workflow.ExecutionInput{})
+	require.Error(t, err)
+
+	var received []workflow.Event
+	for event := range events {
+		received = append(received, event)
+		if event.Type == workflow.EventExecutionFinished {
+			break
+		}
+	}
+
+	require.Len(t, received, 6)
+	for _, event := range received {
+		require.Equal(t, result.ExecutionID, event.ExecutionID)
+		require.Equal(t, "broken", event.WorkflowID)
+		require.False(t, event.Time.IsZero())
+	}
+
+	failed := received[4]
+	require.Equal(t, workflow.EventNodeFailed, failed.Type)
+	require.Equal(t, "broken", failed.NodeID)
+	require.Equal(t, workflow.StepStatusFailed, failed.Step.Status)
+	require.Contains(t, failed.Error, "boom")
+
+	finished := received[5]
+	require.Equal(t, workflow.ExecutionStatusFailed, finished.Status)
+	require.Contains(t, finished.Error, "boom")
+
+	// Streams of finished executions yield their final event
+	events, err = svc.Events(ctx, result.ExecutionID)
+	require.NoError(t, err)
+	require.Equal(t, []workflow.EventType{workflow.EventExecutionFinished}, eventTypes(t, events, -1))
+
+	_, err = svc.Events(ctx, "missing")
+	require.ErrorIs(t, err, workflow.ErrExecutionNotFound)
+}
+
+// TestEvents_OtherInstance streams an execution finished by another instance, whose events are not published to
+// this one.
+func TestEvents_OtherInstance(t *testing.T) {
+	repo := workflow.NewMemoryRepository()
+	addApproval(t, repo)
+	kinds := approvalKinds()
+	svc, other := newService(t, repo, kinds), newService(t, repo, kinds)
+	workflow.SetEventsPollInterval(svc, 10*time.Millisecond)
+	ctx := context.Background()
+
+	waiting, err := other.Execute(ctx, "approved", &workflow.ExecutionInput{})
+	require.NoError(t, err)
+
+	events, err := svc.Events(ctx, waiting.ExecutionID)
+	require.NoError(t, err)
+
+	_, err = other.Resume(ctx, waiting.ExecutionID, &workflow.ResumeInput{
+		Decision: workflow.DecisionReject,
+		FormData: map[string]any{"reason": "Checked"},
+	})
+	require.NoError(t, err)
+
+	event := <-events
+	require.Equal(t, workflow.EventExecutionFinished, event.Type)
+	require.Equal(t, workflow.ExecutionStatusCompleted, event.Status)
+	require.Equal(t, waiting.ExecutionID, event.ExecutionID)
+	require.Empty(t, eventTypes(t, events, -1))
+}
@@ -0,0 +1,13 @@
+package workflow
+
+import "time"
+
+// SetEventsPollInterval sets how often the execution event streams of the service poll the execution.
+func SetEventsPollInterval(svc Service, interval time.Duration) {
+	svc.(*ServiceImpl).eventsPollInterval = interval
+}
+
+// SetEventsKeepAlive sets how often the idle event streams of the handler are sent a comment.
+func SetEventsKeepAlive(h Handler, interval time.Duration) {
+	h.(*HandlerImpl).keepAlive = interval
+}
@@ -1,25 +1,43 @@
 package workflow
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"workflow-code-test/api/pkg/document"
 	"workflow-code-test/api/pkg/render"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 )
 
+const (
+	// eventsKeepAlive is how often an idle event stream is sent a comment.
+	eventsKeepAlive = 15 * time.Second
+
+	// maxIdempotencyKeyLength bounds the Idempotency-Key header, which fits a UUID or a hash.
+	maxIdempotencyKeyLength = 255
+)
+
 type HandlerImpl struct {
 	svc Service
 	log *slog.Logger
+
+	// keepAlive is how often idle event streams are sent a comment
+	keepAlive time.Duration
 }
 
 // Execute implements Handler.
 func (h *HandlerImpl) Execute(w http.ResponseWriter, r *http.Request) {
 	id := mux.Vars(r)["id"]
-	h.log.Debug("Handling workflow execution for id", "id", id)
+	h.log.DebugContext(r.Context(), "Handling workflow execution for id", "id", id)
 
 	var input ExecutionInput
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
@@ -27,31 +45,382 @@ func (h *HandlerImpl) Execute(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	executionResult, err := h.svc.Execute(r.Context(), id, &input)
+	ctx := r.Context()
+	if dryRun := r.URL.Query().Get("dryRun"); dryRun != "" {
+		enabled, err := strconv.ParseBool(dryRun)
+		if err != nil {
+			render.Error(w, r, http.StatusBadRequest, render.ErrInvalidDryRun, h.log)
+			return
+		}
+		if enabled {
+			ctx = WithDryRun(ctx)
+		}
+	}
+
+	input.IdempotencyKey = r.Header.Get("Idempotency-Key")
+	if len(input.IdempotencyKey) > maxIdempotencyKeyLength {
+		render.Error(w, r, http.StatusBadRequest, render.ErrLongIdempotencyKey, h.log)
+		return
+	}
+
+	executionResult, err := h.svc.Execute(ctx, id, &input)
+
+	var validationErr *ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		render.ValidationError(w, r, validationErr.Fields, h.log)
+		return
+	case errors.Is(err, ErrIdempotencyConflict):
+		render.Error(w, r, http.StatusConflict, render.ErrIdempotencyConflict, h.log)
+		return
+	}
+
 	if err != nil {
-		h.log.Error("problem finishing workflow execution", slog.Any("ID", id), slog.Any("ERROR", err))
+		h.log.ErrorContext(r.Context(), "problem finishing workflow execution", slog.Any("ID", id), slog.Any("ERROR", err))
 	}
 	if executionResult == nil {
 		render.Error(w, r, http.StatusBadRequest, fmt.Errorf("got empty executionResult"), h.log)
 		return
 	}
 
-	render.JSON(w, r, http.StatusOK, executionResult)
+	render.JSON(w, r, ExecutionStatusCode(executionResult), executionResult)
+}
+
+// Resume implements Handler.
+func (h *HandlerImpl) Resume(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.executionID(w, r)
+	if !ok {
+		return
+	}
+	h.log.DebugContext(r.Context(), "Handling execution resume for id", "id", id)
+
+	var input ResumeInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		render.Error(w, r, http.StatusBadRequest, err, h.log)
+		return
+	}
+
+	executionResult, err := h.svc.Resume(r.Context(), id, &input)
+
+	var validationErr *ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		render.ValidationError(w, r, validationErr.Fields, h.log)
+		return
+	case errors.Is(err, ErrExecutionNotFound):
+		render.Error(w, r, http.StatusNotFound, render.ErrNotFound, h.log)
+		return
+	case errors.Is(err, ErrExecutionNotWaiting):
+		render.Error(w, r, http.StatusConflict, render.ErrExecutionNotWaiting, h.log)
+		return
+	}
+
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "problem resuming execution", slog.Any("ID", id), slog.Any("ERROR", err))
+	}
+	if executionResult == nil {
+		render.Error(w, r, http.StatusInternalServerError, err, h.log)
+		return
+	}
+
+	render.JSON(w, r, ExecutionStatusCode(executionResult), executionResult)
+}
+
+// Retry implements Handler.
+func (h *HandlerImpl) Retry(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.executionID(w, r)
+	if !ok {
+		return
+	}
+
+	executionResult, err := h.svc.Retry(r.Context(), id)
+
+	switch {
+	case errors.Is(err, ErrExecutionNotFound):
+		render.Error(w, r, http.StatusNotFound, render.ErrNotFound, h.log)
+		return
+	case errors.Is(err, ErrExecutionNotFailed):
+		render.Error(w, r, http.StatusConflict, render.ErrExecutionNotFailed, h.log)
+		return
+	}
+
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "problem retrying execution", slog.Any("ID", id), slog.Any("ERROR", err))
+	}
+	if executionResult == nil {
+		render.Error(w, r, http.StatusInternalServerError, err, h.log)
+		return
+	}
+
+	render.JSON(w, r, ExecutionStatusCode(executionResult), executionResult)
+}
+
+// Cancel implements Handler.
+func (h *HandlerImpl) Cancel(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.executionID(w, r)
+	if !ok {
+		return
+	}
+
+	executionResult, err := h.svc.Cancel(r.Context(), id)
+
+	switch {
+	case errors.Is(err, ErrExecutionNotFound):
+		render.Error(w, r, http.StatusNotFound, render.ErrNotFound, h.log)
+		return
+	case errors.Is(err, ErrExecutionFinished):
+		render.Error(w, r, http.StatusConflict, render.ErrExecutionFinished, h.log)
+		return
+	case err != nil:
+		h.log.ErrorContext(r.Context(), "problem cancelling execution", slog.Any("ID", id), slog.Any("ERROR", err))
+		render.Error(w, r, http.StatusInternalServerError, err, h.log)
+		return
+	}
+
+	render.JSON(w, r, ExecutionStatusCode(executionResult), executionResult)
+}
+
+// Rerun implements Handler.
+func (h *HandlerImpl) Rerun(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.executionID(w, r)
+	if !ok {
+		return
+	}
+
+	// The body is optional, the original input being replayed as is without it
+	var input RerunInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil && !errors.Is(err, io.EOF) {
+		render.Error(w, r, http.StatusBadRequest, err, h.log)
+		return
+	}
+
+	executionResult, err := h.svc.Rerun(r.Context(), id, &input)
+
+	var validationErr *ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		render.ValidationError(w, r, validationErr.Fields, h.log)
+		return
+	case errors.Is(err, ErrExecutionNotFound):
+		render.Error(w, r, http.StatusNotFound, render.ErrNotFound, h.log)
+		return
+	}
+
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "problem rerunning execution", slog.Any("ID", id), slog.Any("ERROR", err))
+	}
+	if executionResult == nil {
+		render.Error(w, r, http.StatusInternalServerError, err, h.log)
+		return
+	}
+
+	render.JSON(w, r, ExecutionStatusCode(executionResult), executionResult)
+}
+
+// Events implements Handler.
+func (h *HandlerImpl) Events(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.executionID(w, r)
+	if !ok {
+		return
+	}
+
+	events, err := h.svc.Events(r.Context(), id)
+	if errors.Is(err, ErrExecutionNotFound) {
+		render.Error(w, r, http.StatusNotFound, render.ErrNotFound, h.log)
+		return
+	}
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "problem streaming execution events", slog.Any("ID", id), slog.Any("ERROR", err))
+		render.Error(w, r, http.StatusInternalServerError, err, h.log)
+		return
+	}
+
+	h.streamEvents(w, r, events)
+}
+
+// WorkflowEvents implements Handler.
+func (h *HandlerImpl) WorkflowEvents(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.workflowID(w, r)
+	if !ok {
+		return
+	}
+
+	events, err := h.svc.WorkflowEvents(r.Context(), id)
+	if errors.Is(err, ErrWorkflowNotFound) {
+		render.Error(w, r, http.StatusNotFound, render.ErrNotFound, h.log)
+		return
+	}
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "problem streaming workflow events", slog.Any("ID", id), slog.Any("ERROR", err))
+		render.Error(w, r, http.StatusInternalServerError, err, h.log)
+		return
+	}
+
+	h.streamEvents(w, r, events)
+}
+
+// streamEvents writes the events as Server-Sent Events named after their type, until the channel is closed.
+func (h *HandlerImpl) streamEvents(w http.ResponseWriter, r *http.Request, events <-chan Event) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		render.Error(w, r, http.StatusInternalServerError, fmt.Errorf("streaming is not supported"), h.log)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	// Comments keep idle connections from being closed by proxies
+	keepAlive := time.NewTicker(h.keepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+		case event, open := <-events:
+			if !open {
+				return
+			}
+
+			data, err := json.Marshal(event)
+			if err != nil {
+				h.log.ErrorContext(r.Context(), "problem encoding execution event", slog.Any("ID", event.ExecutionID), slog.Any("ERROR", err))
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+		}
+		flusher.Flush()
+	}
+}
+
+// Inspect implements Handler.
+func (h *HandlerImpl) Inspect(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.executionID(w, r)
+	if !ok {
+		return
+	}
+
+	snapshot, err := h.svc.Inspect(r.Context(), id)
+	if errors.Is(err, ErrExecutionNotFound) {
+		render.Error(w, r, http.StatusNotFound, render.ErrNotFound, h.log)
+		return
+	}
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "problem inspecting execution", slog.Any("ID", id), slog.Any("ERROR", err))
+		render.Error(w, r, http.StatusInternalServerError, err, h.log)
+		return
+	}
+
+	render.JSON(w, r, http.StatusOK, snapshot)
+}
+
+// UpdateDebug implements Handler.
+func (h *HandlerImpl) UpdateDebug(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.executionID(w, r)
+	if !ok {
+		return
+	}
+
+	var input DebugInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		render.Error(w, r, http.StatusBadRequest, err, h.log)
+		return
+	}
+
+	snapshot, err := h.svc.UpdateDebug(r.Context(), id, &input)
+	if h.debugError(w, r, id, err) {
+		return
+	}
+
+	render.JSON(w, r, http.StatusOK, snapshot)
+}
+
+// Step implements Handler.
+func (h *HandlerImpl) Step(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.executionID(w, r)
+	if !ok {
+		return
+	}
+
+	executionResult, err := h.svc.Step(r.Context(), id)
+	h.renderDebugResult(w, r, id, executionResult, err)
+}
+
+// Continue implements Handler.
+func (h *HandlerImpl) Continue(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.executionID(w, r)
+	if !ok {
+		return
+	}
+
+	executionResult, err := h.svc.Continue(r.Context(), id)
+	h.renderDebugResult(w, r, id, executionResult, err)
+}
+
+// renderDebugResult renders the result of an execution continued by the debugger.
+func (h *HandlerImpl) renderDebugResult(w http.ResponseWriter, r *http.Request, id string, executionResult *ExecutionResult, err error) {
+	if executionResult == nil {
+		h.debugError(w, r, id, err)
+		return
+	}
+
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "problem continuing execution", slog.Any("ID", id), slog.Any("ERROR", err))
+	}
+
+	render.JSON(w, r, ExecutionStatusCode(executionResult), executionResult)
+}
+
+// debugError renders the error of a debugger request, if any, and reports whether it did.
+func (h *HandlerImpl) debugError(w http.ResponseWriter, r *http.Request, id string, err error) bool {
+	var validationErr *ValidationError
+	switch {
+	case err == nil:
+		return false
+	case errors.As(err, &validationErr):
+		render.ValidationError(w, r, validationErr.Fields, h.log)
+	case errors.Is(err, ErrExecutionNotFound):
+		render.Error(w, r, http.StatusNotFound, render.ErrNotFound, h.log)
+	case errors.Is(err, ErrExecutionNotPaused):
+		render.Error(w, r, http.StatusConflict, render.ErrExecutionNotPaused, h.log)
+	default:
+		h.log.ErrorContext(r.Context(), "problem debugging execution", slog.Any("ID", id), slog.Any("ERROR", err))
+		render.Error(w, r, http.StatusInternalServerError, err, h.log)
+	}
+
+	return true
+}
+
+// ExecutionStatusCode returns the HTTP status of a response reporting the execution result:
+// 202 Accepted for executions waiting to continue in the background, paused by the debugger or still running,
+// such as one being cancelled, 200 OK otherwise.
+func ExecutionStatusCode(executionResult *ExecutionResult) int {
+	if executionResult.Status == ExecutionStatusWaiting || executionResult.Status == ExecutionStatusPaused ||
+		executionResult.Status == ExecutionStatusRunning {
+		return http.StatusAccepted
+	}
+
+	return http.StatusOK
 }
 
 // Workflow implements Handler.
 func (h *HandlerImpl) Workflow(w http.ResponseWriter, r *http.Request) {
-	id := mux.Vars(r)["id"]
-
-	if err := uuid.Validate(id); err != nil {
-		h.log.Error("problem validating workflow id", slog.Any("ID", id), slog.Any("ERROR", err))
-		render.Error(w, r, http.StatusBadRequest, render.ErrInvalidWorkflowID, h.log)
+	id, ok := h.workflowID(w, r)
+	if !ok {
 		return
 	}
 
 	workflow, err := h.svc.Workflow(r.Context(), id)
+	if errors.Is(err, ErrWorkflowNotFound) {
+		render.Error(w, r, http.StatusNotFound, render.ErrNotFound, h.log)
+		return
+	}
 	if err != nil {
-		h.log.Error("problem fetching workflow", slog.Any("ID", id), slog.Any("ERROR", err))
+		h.log.ErrorContext(r.Context(), "problem fetching workflow", slog.Any("ID", id), slog.Any("ERROR", err))
 		render.Error(w, r, http.StatusNotFound, err, h.log)
 		return
 	}
@@ -59,9 +428,306 @@ func (h *HandlerImpl) Workflow(w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, http.StatusOK, workflow)
 }
 
+// Versions implements Handler.
+func (h *HandlerImpl) Versions(w http.ResponseWriter, r *http.Request) {
+	workflowID, ok := h.workflowID(w, r)
+	if !ok {
+		return
+	}
+
+	versions, err := h.svc.Versions(r.Context(), workflowID)
+	if err != nil {
+		h.renderVersionError(w, r, err)
+		return
+	}
+
+	render.JSON(w, r, http.StatusOK, versions)
+}
+
+// Version implements Handler.
+// Routes without a version retrieve the draft.
+func (h *HandlerImpl) Version(w http.ResponseWriter, r *http.Request) {
+	workflowID, ok := h.workflowID(w, r)
+	if !ok {
+		return
+	}
+
+	number, ok := h.version(w, r, mux.Vars(r)["version"])
+	if !ok {
+		return
+	}
+
+	version, err := h.svc.Version(r.Context(), workflowID, number)
+	if err != nil {
+		h.renderVersionError(w, r, err)
+		return
+	}
+
+	render.JSON(w, r, http.StatusOK, version)
+}
+
+// SaveDraft implements Handler.
+func (h *HandlerImpl) SaveDraft(w http.ResponseWriter, r *http.Request) {
+	workflowID, ok := h.workflowID(w, r)
+	if !ok {
+		return
+	}
+
+	var input DraftInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		render.Error(w, r, http.StatusBadRequest, err, h.log)
+		return
+	}
+
+	version, err := h.svc.SaveDraft(r.Context(), workflowID, &input)
+	if err != nil {
+		h.renderVersionError(w, r, err)
+		return
+	}
+
+	render.JSON(w, r, http.StatusOK, version)
+}
+
+// Publish implements Handler.
+func (h *HandlerImpl) Publish(w http.ResponseWriter, r *http.Request) {
+	workflowID, ok := h.workflowID(w, r)
+	if !ok {
+		return
+	}
+
+	input, ok := h.publishInput(w, r)
+	if !ok {
+		return
+	}
+
+	version, err := h.svc.Publish(r.Context(), workflowID, input)
+	if err != nil {
+		h.renderVersionError(w, r, err)
+		return
+	}
+
+	render.JSON(w, r, http.StatusCreated, version)
+}
+
+// Rollback implements Handler.
+func (h *HandlerImpl) Rollback(w http.ResponseWriter, r *http.Request) {
+	workflowID, ok := h.workflowID(w, r)
+	if !ok {
+		return
+	}
+
+	number, ok := h.version(w, r, mux.Vars(r)["version"])
+	if !ok {
+		return
+	}
+
+	input, ok := h.publishInput(w, r)
+	if !ok {
+		return
+	}
+
+	version, err := h.svc.Rollback(r.Context(), workflowID, number, input)
+	if err != nil {
+		h.renderVersionError(w, r, err)
+		return
+	}
+
+	render.JSON(w, r, http.StatusCreated, version)
+}
+
+// Diff implements Handler.
+func (h *HandlerImpl) Diff(w http.ResponseWriter, r *http.Request) {
+	workflowID, ok := h.workflowID(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+
+	// The draft is compared with the latest published version unless from is given
+	from := LatestVersion
+	if raw := query.Get("from"); raw != "" {
+		if from, ok = h.version(w, r, raw); !ok {
+			return
+		}
+	}
+
+	to, ok := h.version(w, r, query.Get("to"))
+	if !ok {
+		return
+	}
+
+	diff, err := h.svc.Diff(r.Context(), workflowID, from, to)
+	if err != nil {
+		h.renderVersionError(w, r, err)
+		return
+	}
+
+	render.JSON(w, r, http.StatusOK, diff)
+}
+
+// Export implements Handler.
+// The document is JSON unless the format query parameter asks for yaml.
+func (h *HandlerImpl) Export(w http.ResponseWriter, r *http.Request) {
+	workflowID, ok := h.workflowID(w, r)
+	if !ok {
+		return
+	}
+
+	format, err := document.ParseFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		render.Error(w, r, http.StatusBadRequest, render.ErrInvalidFormat, h.log)
+		return
+	}
+
+	doc, err := h.svc.Export(r.Context(), workflowID)
+	if err != nil {
+		h.renderVersionError(w, r, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := document.Encode(&buf, doc, format); err != nil {
+		h.log.ErrorContext(r.Context(), "problem encoding workflow document", slog.Any("ID", workflowID), slog.Any("ERROR", err))
+		render.Error(w, r, http.StatusInternalServerError, err, h.log)
+		return
+	}
+
+	w.Header().Set("Content-Type", documentContentTypes[format])
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, workflowID, format))
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+// Import implements Handler.
+// The body is a document, read as YAML when the format query parameter or the Content-Type header says so.
+// Referenced workflows are mapped to the workflows of this environment by remap query parameters, e.g.
+// ?remap=<source-id>=<target-id>.
+func (h *HandlerImpl) Import(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	name := query.Get("format")
+	if name == "" && strings.Contains(r.Header.Get("Content-Type"), "yaml") {
+		name = string(document.FormatYAML)
+	}
+
+	format, err := document.ParseFormat(name)
+	if err != nil {
+		render.Error(w, r, http.StatusBadRequest, render.ErrInvalidFormat, h.log)
+		return
+	}
+
+	input := &ImportInput{
+		Author:      query.Get("author"),
+		WorkflowIDs: map[string]string{},
+	}
+	for _, remap := range query["remap"] {
+		source, target, _ := strings.Cut(remap, "=")
+		if uuid.Validate(source) != nil || uuid.Validate(target) != nil {
+			render.Error(w, r, http.StatusBadRequest, render.ErrInvalidWorkflowID, h.log)
+			return
+		}
+		input.WorkflowIDs[source] = target
+	}
+
+	doc, err := document.Decode(r.Body, format)
+
+	var documentErr *document.ValidationError
+	if errors.As(err, &documentErr) {
+		render.ValidationError(w, r, documentErr.Fields, h.log)
+		return
+	}
+	if err != nil {
+		h.log.ErrorContext(r.Context(), "problem decoding workflow document", slog.Any("ERROR", err))
+		render.Error(w, r, http.StatusBadRequest, render.ErrInvalidDocument, h.log)
+		return
+	}
+
+	result, err := h.svc.Import(r.Context(), doc, input)
+	if err != nil {
+		h.renderVersionError(w, r, err)
+		return
+	}
+
+	render.JSON(w, r, http.StatusCreated, result)
+}
+
+// workflowID returns the validated workflow ID of the request, rendering an error if it is invalid.
+func (h *HandlerImpl) workflowID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	id := mux.Vars(r)["id"]
+
+	if err := uuid.Validate(id); err != nil {
+		h.log.ErrorContext(r.Context(), "problem validating workflow id", slog.Any("ID", id), slog.Any("ERROR", err))
+		render.Error(w, r, http.StatusBadRequest, render.ErrInvalidWorkflowID, h.log)
+		return "", false
+	}
+
+	return id, true
+}
+
+// executionID returns the execution ID of the request, rendering an error if it is not a UUID.
+func (h *HandlerImpl) executionID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	id := mux.Vars(r)["id"]
+
+	if err := uuid.Validate(id); err != nil {
+		h.log.ErrorContext(r.Context(), "problem validating execution id", slog.Any("ID", id), slog.Any("ERROR", err))
+		render.Error(w, r, http.StatusBadRequest, render.ErrInvalidExecutionID, h.log)
+		return "", false
+	}
+
+	return id, true
+}
+
+// version parses a version number, where "draft" or no version selects the draft.
+// Renders an error if it is invalid.
+func (h *HandlerImpl) version(w http.ResponseWriter, r *http.Request, raw string) (int, bool) {
+	if raw == "" || raw == string(VersionStatusDraft) {
+		return DraftVersion, true
+	}
+
+	version, err := strconv.Atoi(raw)
+	if err != nil || version < 1 {
+		render.Error(w, r, http.StatusBadRequest, render.ErrInvalidVersion, h.log)
+		return 0, false
+	}
+
+	return version, true
+}
+
+// publishInput decodes the optional body describing a published version.
+func (h *HandlerImpl) publishInput(w http.ResponseWriter, r *http.Request) (*PublishInput, bool) {
+	var input PublishInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil && !errors.Is(err, io.EOF) {
+		render.Error(w, r, http.StatusBadRequest, err, h.log)
+		return nil, false
+	}
+
+	return &input, true
+}
+
+func (h *HandlerImpl) renderVersionError(w http.ResponseWriter, r *http.Request, err error) {
+	var validationErr *ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		render.ValidationError(w, r, validationErr.Fields, h.log)
+	case errors.Is(err, ErrWorkflowNotFound), errors.Is(err, ErrVersionNotFound):
+		render.Error(w, r, http.StatusNotFound, render.ErrNotFound, h.log)
+	default:
+		h.log.ErrorContext(r.Context(), "problem managing workflow versions", slog.Any("ERROR", err))
+		render.Error(w, r, http.StatusInternalServerError, err, h.log)
+	}
+}
+
+var documentContentTypes = map[document.Format]string{
+	document.FormatJSON: "application/json",
+	document.FormatYAML: "application/yaml",
+}
+
 func NewHandler(svc Service, log *slog.Logger) Handler {
 	return &HandlerImpl{
 		svc: svc,
 		log: log,
+
+		keepAlive: eventsKeepAlive,
 	}
 }
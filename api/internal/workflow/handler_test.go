@@ -0,0 +1,191 @@
+package workflow_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"workflow-code-test/api/internal/edge"
+	"workflow-code-test/api/internal/node"
+	"workflow-code-test/api/internal/workflow"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+// newServer serves the execution event streams and the version diffs of the service, sending keep-alive comments
+// every keepAlive.
+func newServer(t *testing.T, svc workflow.Service, keepAlive time.Duration) *httptest.Server {
+	t.Helper()
+
+	handler := workflow.NewHandler(svc, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	workflow.SetEventsKeepAlive(handler, keepAlive)
+
+	router := mux.NewRouter()
+	router.HandleFunc("/executions/{id}/events", handler.Events).Methods(http.MethodGet)
+	router.HandleFunc("/workflows/{id}/versions/diff", handler.Diff).Methods(http.MethodGet)
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHandler_Events(t *testing.T) {
+	repo := workflow.NewMemoryRepository()
+	addApproval(t, repo)
+	svc := newService(t, repo, approvalKinds())
+	server := newServer(t, svc, 10*time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	waiting, err := svc.Execute(ctx, "approved", &workflow.ExecutionInput{})
+	require.NoError(t, err)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/executions/"+waiting.ExecutionID+"/events", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// The waiting execution is idle, so the stream is kept alive until it is resumed
+	lines := bufio.NewScanner(resp.Body)
+	require.True(t, lines.Scan())
+	require.Equal(t, ": keep-alive", lines.Text())
+
+	_, err = svc.Resume(ctx, waiting.ExecutionID, &workflow.ResumeInput{
+		Decision: workflow.DecisionApprove,
+		FormData: map[string]any{"reason": "Checked"},
+	})
+	require.NoError(t, err)
+
+	// The stream ends once the execution finished
+	var names []string
+	var last string
+	for lines.Scan() {
+		if name, ok := strings.CutPrefix(lines.Text(), "event: "); ok {
+			names = append(names, name)
+		}
+		if data, ok := strings.CutPrefix(lines.Text(), "data: "); ok {
+			last = data
+		}
+	}
+	require.NoError(t, lines.Err())
+	require.Equal(t, []string{"execution.resumed", "node.started", "node.completed", "execution.finished"}, names)
+	require.Contains(t, last, `"status":"completed"`)
+}
+
+func TestHandler_Events_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		executionID    string
+		expectedStatus int
+	}{
+		{
+			name:           "unknown execution",
+			executionID:    uuid.NewString(),
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "invalid execution ID",
+			executionID:    "invalid",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newService(t, workflow.NewMemoryRepository(), nil)
+			server := newServer(t, svc, time.Minute)
+
+			resp, err := http.Get(server.URL + "/executions/" + tt.executionID + "/events")
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			require.Equal(t, tt.expectedStatus, resp.StatusCode)
+			require.NotEqual(t, "text/event-stream", resp.Header.Get("Content-Type"))
+		})
+	}
+}
+
+func TestHandler_Diff(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedFrom   int
+		expectedNodes  []workflow.Change
+	}{
+		{
+			name:           "latest published version and draft",
+			expectedStatus: http.StatusOK,
+			expectedFrom:   2,
+			expectedNodes:  []workflow.Change{{ID: "c", Type: workflow.ChangeTypeAdded}},
+		},
+		{
+			name:           "given version",
+			query:          "?from=1&to=2",
+			expectedStatus: http.StatusOK,
+			expectedFrom:   1,
+			expectedNodes:  []workflow.Change{{ID: "a", Type: workflow.ChangeTypeAdded}},
+		},
+		{
+			name:           "invalid version",
+			query:          "?from=first",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing version",
+			query:          "?from=3",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workflowID := uuid.NewString()
+			repo := workflow.NewMemoryRepository()
+			addChain(t, repo, workflowID)
+			svc := newService(t, repo, map[string]*testKind{"work": {}})
+			server := newServer(t, svc, time.Minute)
+			ctx := context.Background()
+
+			// Version 2 adds a, and the draft adds c
+			draft := &workflow.DraftInput{
+				Nodes: []node.Node{newNode("start", "start", nil), newNode("a", "work", nil), newNode("end", "end", nil)},
+				Edges: []edge.Edge{{Source: "start", Target: "a"}, {Source: "a", Target: "end"}},
+			}
+			_, err := svc.SaveDraft(ctx, workflowID, draft)
+			require.NoError(t, err)
+			_, err = svc.Publish(ctx, workflowID, &workflow.PublishInput{})
+			require.NoError(t, err)
+
+			draft.Nodes = append(draft.Nodes, newNode("c", "work", nil))
+			_, err = svc.SaveDraft(ctx, workflowID, draft)
+			require.NoError(t, err)
+
+			resp, err := http.Get(server.URL + "/workflows/" + workflowID + "/versions/diff" + tt.query)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			require.Equal(t, tt.expectedStatus, resp.StatusCode)
+
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var diff workflow.VersionDiff
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&diff))
+			require.Equal(t, tt.expectedFrom, diff.From)
+			require.Equal(t, tt.expectedNodes, diff.Nodes)
+		})
+	}
+}
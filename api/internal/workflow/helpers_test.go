@@ -0,0 +1,128 @@
+package workflow_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"maps"
+	"sync"
+	"testing"
+	"workflow-code-test/api/internal/edge"
+	"workflow-code-test/api/internal/node"
+	"workflow-code-test/api/internal/workflow"
+	"workflow-code-test/api/pkg/nodes"
+	"workflow-code-test/api/pkg/nodes/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testKind is a node kind whose behaviour is set by the test. It records the arguments of every run.
+type testKind struct {
+	// run returns the output of the n-th run of the kind, counting from 1. Nil runs return no output.
+	run func(ctx context.Context, n int, args map[string]any) (any, error)
+	// descriptor declares the inputs, outputs and metadata of the kind, whose name is set by newService.
+	descriptor types.Descriptor
+	// simulate, when set, makes the kind a types.Simulator simulated with it in dry runs.
+	simulate func(ctx context.Context, args map[string]any) (*types.Simulation, error)
+
+	mu   sync.Mutex
+	runs []map[string]any
+}
+
+// Runs returns the arguments of every run so far.
+func (k *testKind) Runs() []map[string]any {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	return k.runs
+}
+
+func (k *testKind) executor() types.NodeExecutor {
+	if k.simulate != nil {
+		return &simulatingExecutor{testExecutor{kind: k}}
+	}
+
+	return &testExecutor{kind: k}
+}
+
+type testExecutor struct {
+	kind *testKind
+	args map[string]any
+}
+
+func (e *testExecutor) SetArgs(args map[string]any)       { e.args = args }
+func (e *testExecutor) SetOutputFields(fields []string)   {}
+func (e *testExecutor) ValidateAndParse(_ []string) error { return nil }
+func (e *testExecutor) ID() string                        { return "test" }
+
+func (e *testExecutor) Execute(ctx context.Context) (any, error) {
+	e.kind.mu.Lock()
+	e.kind.runs = append(e.kind.runs, maps.Clone(e.args))
+	n := len(e.kind.runs)
+	e.kind.mu.Unlock()
+
+	if e.kind.run == nil {
+		return nil, nil
+	}
+
+	return e.kind.run(ctx, n, e.args)
+}
+
+type simulatingExecutor struct {
+	testExecutor
+}
+
+func (e *simulatingExecutor) Simulate(ctx context.Context) (*types.Simulation, error) {
+	return e.kind.simulate(ctx, e.args)
+}
+
+// newService returns a service running workflows from repo, with the test kinds registered next to the built-in ones.
+func newService(t *testing.T, repo workflow.Repository, kinds map[string]*testKind) workflow.Service {
+	t.Helper()
+
+	registry := nodes.DefaultRegistry().Clone()
+	for name, kind := range kinds {
+		descriptor := kind.descriptor
+		descriptor.Kind = name
+		registry.Register(name, kind.executor, descriptor)
+	}
+
+	return workflow.NewService(repo, nodes.NewService(registry), slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+// newNode returns a node of the kind with the metadata.
+func newNode(id, kind string, metadata map[string]any) node.Node {
+	if metadata == nil {
+		metadata = map[string]any{}
+	}
+
+	return node.Node{ID: id, Kind: kind, Data: node.Data{Label: id, Metadata: metadata}}
+}
+
+// addChain adds a workflow running the nodes one after the other, between a start and an end node.
+func addChain(t *testing.T, repo *workflow.MemoryRepository, workflowID string, chain ...node.Node) {
+	t.Helper()
+
+	wf := &workflow.Workflow{
+		ID:    workflowID,
+		Name:  workflowID,
+		Nodes: append([]node.Node{newNode("start", "start", nil)}, chain...),
+	}
+	wf.Nodes = append(wf.Nodes, newNode("end", "end", nil))
+
+	for i := 1; i < len(wf.Nodes); i++ {
+		wf.Edges = append(wf.Edges, edge.Edge{ID: wf.Nodes[i-1].ID + "-" + wf.Nodes[i].ID, Source: wf.Nodes[i-1].ID, Target: wf.Nodes[i].ID})
+	}
+
+	require.NoError(t, repo.AddWorkflow(wf))
+}
+
+// stepIDs returns the IDs of the nodes of the steps, in the order they ran.
+func stepIDs(steps []workflow.Step) []string {
+	ids := make([]string, 0, len(steps))
+	for _, step := range steps {
+		ids = append(ids, step.NodeID)
+	}
+
+	return ids
+}
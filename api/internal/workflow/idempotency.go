@@ -0,0 +1,62 @@
+package workflow
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// idempotencyRetention is how long an idempotency key returns the execution it started, after which it starts
+// a new execution.
+const idempotencyRetention = 24 * time.Hour
+
+// replay returns the result of the execution started with the idempotency key of the input, or nil if the key
+// has not started an execution of the workflow within idempotencyRetention. The result is the execution's current
+// one, e.g. running when the original request has not finished yet.
+// Returns ErrIdempotencyConflict if the key started an execution with a different input.
+func (s *ServiceImpl) replay(ctx context.Context, workflowID string, executionInput *ExecutionInput) (*ExecutionResult, error) {
+	hash, err := requestHash(executionInput)
+	if err != nil {
+		return nil, err
+	}
+	executionInput.requestHash = hash
+
+	execution, err := s.repo.IdempotentExecution(ctx, workflowID, executionInput.IdempotencyKey)
+	if errors.Is(err, ErrExecutionNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Expired keys are released for the new execution
+	if time.Since(execution.CreatedAt) > idempotencyRetention {
+		return nil, s.repo.ReleaseIdempotencyKey(ctx, execution.ID)
+	}
+
+	if execution.RequestHash == nil || *execution.RequestHash != hash {
+		return nil, ErrIdempotencyConflict
+	}
+
+	s.log.InfoContext(ctx, "replaying execution", slog.Any("ID", execution.ID), slog.Any("IDEMPOTENCY_KEY", executionInput.IdempotencyKey))
+
+	return execution.Result(), nil
+}
+
+// requestHash hashes the input an execution starts with, to tell requests repeating an idempotency key apart.
+func requestHash(executionInput *ExecutionInput) (string, error) {
+	// Maps are encoded with sorted keys, so equal inputs hash the same
+	encoded, err := json.Marshal(executionInput)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash execution input: %w", err)
+	}
+
+	sum := sha256.Sum256(encoded)
+
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,166 @@
+package workflow_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+	"workflow-code-test/api/internal/workflow"
+
+	"github.com/stretchr/testify/require"
+)
+
+// agedRepository reports the executions started with an idempotency key as created age ago.
+type agedRepository struct {
+	workflow.Repository
+	age time.Duration
+}
+
+func (r *agedRepository) IdempotentExecution(ctx context.Context, workflowID string, key string) (*workflow.Execution, error) {
+	execution, err := r.Repository.IdempotentExecution(ctx, workflowID, key)
+	if err != nil {
+		return nil, err
+	}
+	execution.CreatedAt = execution.CreatedAt.Add(-r.age)
+
+	return execution, nil
+}
+
+func TestExecute_IdempotencyKey(t *testing.T) {
+	tests := []struct {
+		name           string
+		age            time.Duration
+		replayed       map[string]any
+		expectedReplay bool
+		expectedError  error
+	}{
+		{
+			name:           "same key and input replays the execution",
+			replayed:       map[string]any{"city": "Sydney"},
+			expectedReplay: true,
+		},
+		{
+			name:          "same key with another input conflicts",
+			replayed:      map[string]any{"city": "Perth"},
+			expectedError: workflow.ErrIdempotencyConflict,
+		},
+		{
+			name:           "key within the retention replays the execution",
+			age:            23 * time.Hour,
+			replayed:       map[string]any{"city": "Sydney"},
+			expectedReplay: true,
+		},
+		{
+			name:     "key past the retention starts a new execution",
+			age:      25 * time.Hour,
+			replayed: map[string]any{"city": "Sydney"},
+		},
+		{
+			name:     "key past the retention starts a new execution with another input",
+			age:      25 * time.Hour,
+			replayed: map[string]any{"city": "Perth"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memory := workflow.NewMemoryRepository()
+			addChain(t, memory, "keyed", newNode("work", "work", nil))
+			kinds := map[string]*testKind{"work": {}}
+			svc := newService(t, &agedRepository{Repository: memory, age: tt.age}, kinds)
+			ctx := context.Background()
+
+			first, err := svc.Execute(ctx, "keyed", &workflow.ExecutionInput{
+				FormData:       map[string]any{"city": "Sydney"},
+				IdempotencyKey: "key",
+			})
+			require.NoError(t, err)
+			require.Equal(t, workflow.ExecutionStatusCompleted, first.Status)
+
+			second, err := svc.Execute(ctx, "keyed", &workflow.ExecutionInput{
+				FormData:       tt.replayed,
+				IdempotencyKey: "key",
+			})
+			if tt.expectedError != nil {
+				require.ErrorIs(t, err, tt.expectedError)
+				require.Len(t, kinds["work"].Runs(), 1)
+				return
+			}
+			require.NoError(t, err)
+
+			if tt.expectedReplay {
+				require.Equal(t, first.ExecutionID, second.ExecutionID)
+				require.Len(t, kinds["work"].Runs(), 1)
+				return
+			}
+
+			require.NotEqual(t, first.ExecutionID, second.ExecutionID)
+			require.Len(t, kinds["work"].Runs(), 2)
+
+			// The key moved to the new execution
+			released, err := memory.Execution(ctx, first.ExecutionID)
+			require.NoError(t, err)
+			require.Nil(t, released.IdempotencyKey)
+
+			keyed, err := memory.Execution(ctx, second.ExecutionID)
+			require.NoError(t, err)
+			require.NotNil(t, keyed.IdempotencyKey)
+			require.Equal(t, "key", *keyed.IdempotencyKey)
+		})
+	}
+}
+
+func TestExecute_ConcurrentIdempotencyKey(t *testing.T) {
+	const requests = 5
+
+	repo := workflow.NewMemoryRepository()
+	addChain(t, repo, "keyed", newNode("work", "work", nil))
+
+	// The execution started first runs until every other request got its result
+	release := make(chan struct{})
+	kinds := map[string]*testKind{"work": {run: func(ctx context.Context, _ int, _ map[string]any) (any, error) {
+		<-release
+		return nil, nil
+	}}}
+	svc := newService(t, repo, kinds)
+
+	type outcome struct {
+		result *workflow.ExecutionResult
+		err    error
+	}
+	outcomes := make(chan outcome, requests)
+
+	var ready sync.WaitGroup
+	ready.Add(requests)
+	start := make(chan struct{})
+	for range requests {
+		go func() {
+			ready.Done()
+			<-start
+			result, err := svc.Execute(context.Background(), "keyed", &workflow.ExecutionInput{
+				FormData:       map[string]any{"city": "Sydney"},
+				IdempotencyKey: "key",
+			})
+			outcomes <- outcome{result: result, err: err}
+		}()
+	}
+	ready.Wait()
+	close(start)
+
+	ids := map[string]bool{}
+	for range requests - 1 {
+		o := <-outcomes
+		require.NoError(t, o.err)
+		require.Equal(t, workflow.ExecutionStatusRunning, o.result.Status)
+		ids[o.result.ExecutionID] = true
+	}
+
+	close(release)
+	o := <-outcomes
+	require.NoError(t, o.err)
+	require.Equal(t, workflow.ExecutionStatusCompleted, o.result.Status)
+	ids[o.result.ExecutionID] = true
+
+	require.Len(t, ids, 1)
+	require.Len(t, kinds["work"].Runs(), 1)
+}
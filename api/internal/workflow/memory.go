@@ -0,0 +1,524 @@
+package workflow
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MemoryRepository is a Repository keeping workflows and executions in memory, used to run workflows without Postgres.
+// Values are stored as JSON, like the jsonb columns of RepositoryImpl, so numbers read back as float64 and callers
+// never share maps with the repository. It is safe for concurrent use.
+type MemoryRepository struct {
+	mu         sync.Mutex
+	workflows  map[string]*memoryWorkflow
+	executions map[string]*Execution
+	// claims holds until when executions resumed by ClaimDueExecutions are claimed
+	claims map[string]time.Time
+}
+
+type memoryWorkflow struct {
+	workflow *Workflow
+	versions map[int]*Version
+	draft    *Version
+}
+
+// WorkflowWithNodesAndEdges implements Repository.
+func (r *MemoryRepository) WorkflowWithNodesAndEdges(ctx context.Context, workflowID string) (*Workflow, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	wf, ok := r.workflows[workflowID]
+	if !ok {
+		return nil, ErrWorkflowNotFound
+	}
+
+	return jsonClone(wf.workflow)
+}
+
+// CreateExecution implements Repository.
+func (r *MemoryRepository) CreateExecution(ctx context.Context, execution *Execution) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if key := execution.IdempotencyKey; key != nil {
+		for _, stored := range r.executions {
+			if stored.WorkflowID == execution.WorkflowID && stored.IdempotencyKey != nil && *stored.IdempotencyKey == *key {
+				return ErrIdempotencyKeyUsed
+			}
+		}
+	}
+
+	execution.ID = uuid.NewString()
+	execution.CreatedAt = time.Now()
+	execution.UpdatedAt = execution.CreatedAt
+
+	return r.storeExecution(execution)
+}
+
+// UpdateExecution implements Repository.
+func (r *MemoryRepository) UpdateExecution(ctx context.Context, execution *Execution) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.executions[execution.ID]; !ok {
+		return fmt.Errorf("failed to update execution %s: %w", execution.ID, ErrExecutionNotFound)
+	}
+
+	delete(r.claims, execution.ID)
+	execution.UpdatedAt = time.Now()
+	return r.storeExecution(execution)
+}
+
+// ClaimDueExecutions implements Repository.
+func (r *MemoryRepository) ClaimDueExecutions(ctx context.Context, now, claimedUntil time.Time, limit int) ([]Execution, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var due []*Execution
+	for _, execution := range r.executions {
+		if execution.ResumeAt == nil {
+			continue
+		}
+
+		claim, claimed := r.claims[execution.ID]
+		waiting := execution.Status == ExecutionStatusWaiting && !execution.ResumeAt.After(now)
+		stale := execution.Status == ExecutionStatusRunning && claimed && claim.Before(now)
+		if waiting || stale {
+			due = append(due, execution)
+		}
+	}
+
+	slices.SortFunc(due, func(a, b *Execution) int {
+		return a.ResumeAt.Compare(*b.ResumeAt)
+	})
+
+	executions := []Execution{}
+	for _, execution := range due[:min(limit, len(due))] {
+		execution.Status = ExecutionStatusRunning
+		execution.UpdatedAt = time.Now()
+		r.claims[execution.ID] = claimedUntil
+
+		claimed, err := jsonClone(execution)
+		if err != nil {
+			return nil, err
+		}
+		executions = append(executions, *claimed)
+	}
+
+	return executions, nil
+}
+
+// Execution implements Repository.
+func (r *MemoryRepository) Execution(ctx context.Context, executionID string) (*Execution, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	execution, ok := r.executions[executionID]
+	if !ok {
+		return nil, ErrExecutionNotFound
+	}
+
+	return jsonClone(execution)
+}
+
+// RenewClaim implements Repository.
+func (r *MemoryRepository) RenewClaim(ctx context.Context, executionID string, claimedUntil time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.claims[executionID]; ok {
+		r.claims[executionID] = claimedUntil
+	}
+
+	return nil
+}
+
+// ClaimWaitingExecution implements Repository.
+func (r *MemoryRepository) ClaimWaitingExecution(ctx context.Context, executionID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	execution, ok := r.executions[executionID]
+	if !ok || execution.Status != ExecutionStatusWaiting || execution.ResumeAt != nil {
+		return false, nil
+	}
+
+	execution.Status = ExecutionStatusRunning
+	execution.UpdatedAt = time.Now()
+
+	return true, nil
+}
+
+// ClaimFailedExecution implements Repository.
+func (r *MemoryRepository) ClaimFailedExecution(ctx context.Context, executionID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	execution, ok := r.executions[executionID]
+	if !ok || execution.Status != ExecutionStatusFailed {
+		return false, nil
+	}
+
+	execution.Status = ExecutionStatusRunning
+	execution.CancelRequested = false
+	execution.UpdatedAt = time.Now()
+
+	return true, nil
+}
+
+// ClaimPausedExecution implements Repository.
+func (r *MemoryRepository) ClaimPausedExecution(ctx context.Context, executionID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	execution, ok := r.executions[executionID]
+	if !ok || execution.Status != ExecutionStatusPaused {
+		return false, nil
+	}
+
+	execution.Status = ExecutionStatusRunning
+	execution.UpdatedAt = time.Now()
+
+	return true, nil
+}
+
+// UpdatePausedExecution implements Repository.
+func (r *MemoryRepository) UpdatePausedExecution(ctx context.Context, execution *Execution) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.executions[execution.ID]
+	if !ok || stored.Status != ExecutionStatusPaused {
+		return false, nil
+	}
+
+	updated, err := jsonClone(execution)
+	if err != nil {
+		return false, err
+	}
+
+	execution.UpdatedAt = time.Now()
+	stored.State = updated.State
+	stored.Debug = updated.Debug
+	stored.UpdatedAt = execution.UpdatedAt
+
+	return true, nil
+}
+
+// CancelExecution implements Repository.
+func (r *MemoryRepository) CancelExecution(ctx context.Context, executionID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	execution, ok := r.executions[executionID]
+	if !ok || (execution.Status != ExecutionStatusWaiting && execution.Status != ExecutionStatusPaused) {
+		return false, nil
+	}
+
+	now := time.Now()
+	execution.Status = ExecutionStatusCancelled
+	execution.ResumeAt = nil
+	execution.WaitingFor = nil
+	execution.FinishedAt = &now
+	execution.UpdatedAt = now
+
+	return true, nil
+}
+
+// RequestCancel implements Repository.
+func (r *MemoryRepository) RequestCancel(ctx context.Context, executionID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	execution, ok := r.executions[executionID]
+	if !ok || execution.Status != ExecutionStatusRunning {
+		return false, nil
+	}
+
+	execution.CancelRequested = true
+	execution.UpdatedAt = time.Now()
+
+	return true, nil
+}
+
+// CancelRequested implements Repository.
+func (r *MemoryRepository) CancelRequested(ctx context.Context, executionID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	execution, ok := r.executions[executionID]
+	if !ok {
+		return false, ErrExecutionNotFound
+	}
+
+	return execution.CancelRequested, nil
+}
+
+// IdempotentExecution implements Repository.
+func (r *MemoryRepository) IdempotentExecution(ctx context.Context, workflowID string, key string) (*Execution, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, execution := range r.executions {
+		if execution.WorkflowID == workflowID && execution.IdempotencyKey != nil && *execution.IdempotencyKey == key {
+			return jsonClone(execution)
+		}
+	}
+
+	return nil, ErrExecutionNotFound
+}
+
+// ReleaseIdempotencyKey implements Repository.
+func (r *MemoryRepository) ReleaseIdempotencyKey(ctx context.Context, executionID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if execution, ok := r.executions[executionID]; ok {
+		execution.IdempotencyKey = nil
+		execution.UpdatedAt = time.Now()
+	}
+
+	return nil
+}
+
+// Versions implements Repository.
+func (r *MemoryRepository) Versions(ctx context.Context, workflowID string) ([]Version, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	versions := []Version{}
+
+	wf, ok := r.workflows[workflowID]
+	if !ok {
+		return versions, nil
+	}
+
+	if wf.draft != nil {
+		versions = append(versions, *wf.draft)
+	}
+	for _, number := range slices.Backward(slices.Sorted(maps.Keys(wf.versions))) {
+		versions = append(versions, *wf.versions[number])
+	}
+
+	// Definitions are not listed, as with RepositoryImpl
+	for i := range versions {
+		versions[i].Definition = nil
+	}
+
+	return versions, nil
+}
+
+// Version implements Repository.
+func (r *MemoryRepository) Version(ctx context.Context, workflowID string, version int) (*Version, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	wf, ok := r.workflows[workflowID]
+	if !ok {
+		return nil, ErrVersionNotFound
+	}
+
+	v := wf.versions[version]
+	if version == DraftVersion {
+		v = wf.draft
+	}
+	if v == nil {
+		return nil, ErrVersionNotFound
+	}
+
+	return jsonClone(v)
+}
+
+// SaveDraft implements Repository.
+func (r *MemoryRepository) SaveDraft(ctx context.Context, workflowID string, input *DraftInput) (*Version, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	wf, ok := r.workflows[workflowID]
+	if !ok {
+		return nil, ErrWorkflowNotFound
+	}
+
+	definition, err := jsonClone(&Definition{Nodes: input.Nodes, Edges: input.Edges})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if wf.draft == nil {
+		wf.draft = &Version{WorkflowID: workflowID, Status: VersionStatusDraft, CreatedAt: now}
+	}
+	wf.draft.Author = input.Author
+	wf.draft.ChangeNote = input.ChangeNote
+	wf.draft.Definition = definition
+	wf.draft.UpdatedAt = now
+
+	return jsonClone(wf.draft)
+}
+
+// PublishDraft implements Repository.
+func (r *MemoryRepository) PublishDraft(ctx context.Context, workflowID string, input *PublishInput) (*Version, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	wf, ok := r.workflows[workflowID]
+	if !ok || wf.draft == nil {
+		return nil, ErrVersionNotFound
+	}
+
+	version := wf.draft
+	version.Author = cmp.Or(input.Author, version.Author)
+	version.ChangeNote = cmp.Or(input.ChangeNote, version.ChangeNote)
+	wf.draft = nil
+
+	return r.publish(wf, version)
+}
+
+// PublishVersion implements Repository.
+func (r *MemoryRepository) PublishVersion(ctx context.Context, workflowID string, definition *Definition, input *PublishInput) (*Version, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	wf, ok := r.workflows[workflowID]
+	if !ok {
+		return nil, ErrWorkflowNotFound
+	}
+
+	definition, err := jsonClone(definition)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.publish(wf, &Version{
+		WorkflowID: workflowID,
+		Author:     input.Author,
+		ChangeNote: input.ChangeNote,
+		Definition: definition,
+		CreatedAt:  time.Now(),
+	})
+}
+
+// CreateWorkflow implements Repository.
+func (r *MemoryRepository) CreateWorkflow(ctx context.Context, workflowID string, name string, definition *Definition, input *PublishInput) (*Version, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.workflows[workflowID]; exists {
+		return nil, fmt.Errorf("failed to create workflow: workflow %s already exists", workflowID)
+	}
+
+	definition, err := jsonClone(definition)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	wf := &memoryWorkflow{
+		workflow: &Workflow{ID: workflowID, Name: name, CreatedAt: now, UpdatedAt: now},
+		versions: map[int]*Version{},
+	}
+	r.workflows[workflowID] = wf
+
+	return r.publish(wf, &Version{
+		WorkflowID: workflowID,
+		Author:     input.Author,
+		ChangeNote: input.ChangeNote,
+		Definition: definition,
+		CreatedAt:  now,
+	})
+}
+
+// AddWorkflow stores a workflow, publishing its graph as its version. Workflows without a version start at version 1.
+// A workflow stored with the same ID is replaced.
+func (r *MemoryRepository) AddWorkflow(workflow *Workflow) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	wf, err := jsonClone(workflow)
+	if err != nil {
+		return err
+	}
+	wf.Version = max(wf.Version, 1)
+
+	now := time.Now()
+	r.workflows[wf.ID] = &memoryWorkflow{
+		workflow: wf,
+		versions: map[int]*Version{
+			wf.Version: {
+				WorkflowID:  wf.ID,
+				Version:     wf.Version,
+				Status:      VersionStatusPublished,
+				Definition:  &Definition{Nodes: wf.Nodes, Edges: wf.Edges},
+				PublishedAt: &now,
+				CreatedAt:   now,
+				UpdatedAt:   now,
+			},
+		},
+	}
+
+	return nil
+}
+
+// publish numbers the version as the next version of the workflow and makes its graph the graph of the workflow.
+func (r *MemoryRepository) publish(wf *memoryWorkflow, version *Version) (*Version, error) {
+	now := time.Now()
+
+	version.Version = 1
+	if len(wf.versions) > 0 {
+		version.Version = slices.Max(slices.Collect(maps.Keys(wf.versions))) + 1
+	}
+	version.Status = VersionStatusPublished
+	version.PublishedAt = &now
+	version.UpdatedAt = now
+	wf.versions[version.Version] = version
+
+	wf.workflow.Version = version.Version
+	wf.workflow.Nodes = version.Definition.Nodes
+	wf.workflow.Edges = version.Definition.Edges
+	wf.workflow.UpdatedAt = now
+
+	return jsonClone(version)
+}
+
+// storeExecution saves a copy of the execution.
+func (r *MemoryRepository) storeExecution(execution *Execution) error {
+	stored, err := jsonClone(execution)
+	if err != nil {
+		return err
+	}
+
+	r.executions[execution.ID] = stored
+	return nil
+}
+
+// jsonClone copies v through its JSON encoding.
+func jsonClone[T any](v *T) (*T, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy %T: %w", v, err)
+	}
+
+	var clone T
+	if err := json.Unmarshal(b, &clone); err != nil {
+		return nil, fmt.Errorf("failed to copy %T: %w", v, err)
+	}
+
+	return &clone, nil
+}
+
+// NewMemoryRepository creates an empty in-memory repository. Workflows are added with AddWorkflow.
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		workflows:  map[string]*memoryWorkflow{},
+		executions: map[string]*Execution{},
+		claims:     map[string]time.Time{},
+	}
+}
@@ -0,0 +1,24 @@
+package workflow
+
+import (
+	"workflow-code-test/api/pkg/metrics"
+)
+
+// observeFinished counts the execution, which just finished, unless it is a dry run.
+func observeFinished(execution *Execution) {
+	if execution.DryRun {
+		return
+	}
+
+	metrics.ExecutionFinished(execution.WorkflowID, string(execution.Status))
+}
+
+// observeStep observes the time taken to run the node of the step, unless the execution is a dry run.
+// Steps still waiting have not finished and are not observed.
+func observeStep(execution *Execution, step *Step) {
+	if execution.DryRun || step.StartedAt == nil || step.FinishedAt == nil {
+		return
+	}
+
+	metrics.NodeExecuted(step.Type, string(step.Status), step.FinishedAt.Sub(*step.StartedAt))
+}
@@ -3,6 +3,9 @@ package workflow
 import (
 	"context"
 	"net/http"
+	"time"
+	"workflow-code-test/api/pkg/document"
+	"workflow-code-test/api/pkg/nodes/types"
 )
 
 // Service defines the interface for workflow-related operations.
@@ -15,7 +18,106 @@ type Service interface {
 	// Execute runs a workflow with the given ID using the provided input data.
 	// It takes a context for cancellation, the workflow ID to execute, and input data containing form fields.
 	// Returns the execution result with status and steps, or an error if execution fails.
+	// A *ValidationError is returned, before any node runs, when the input does not satisfy the form node's fields.
+	// Inputs with an idempotency key return the execution the key started instead, if any, or ErrIdempotencyConflict
+	// if the key started an execution with a different input.
 	Execute(ctx context.Context, workflowID string, input *ExecutionInput) (*ExecutionResult, error)
+
+	// ResumeDue continues the waiting executions whose delay has elapsed, such as executions
+	// suspended by a delay node. Each due execution is claimed by a single caller, so it is safe
+	// to call concurrently from several API instances.
+	// Returns the number of executions resumed, or an error if they could not be claimed.
+	ResumeDue(ctx context.Context) (int, error)
+
+	// Resume continues an execution waiting for approval, such as one suspended by an approval node.
+	// The decision and the form data become the waiting node's outputs, and the execution continues along
+	// the handle matching the decision. The form data is checked against the fields the node asked for.
+	// Returns ErrExecutionNotFound if the execution does not exist, ErrExecutionNotWaiting if it is not
+	// waiting for input, or a *ValidationError if the input is invalid.
+	Resume(ctx context.Context, executionID string, input *ResumeInput) (*ExecutionResult, error)
+
+	// Retry continues a failed execution from the node that failed, with the variables saved before the node ran.
+	// Returns ErrExecutionNotFound if the execution does not exist, or ErrExecutionNotFailed if it has not failed.
+	Retry(ctx context.Context, executionID string) (*ExecutionResult, error)
+
+	// Cancel stops an execution and keeps the steps that already ran. Waiting and paused executions are cancelled
+	// at once, while a running execution is flagged and cancelled by the API instance running it, which stops the
+	// running node and checks the flag every second.
+	// Returns ErrExecutionNotFound if the execution does not exist, or ErrExecutionFinished if it already finished.
+	Cancel(ctx context.Context, executionID string) (*ExecutionResult, error)
+
+	// Rerun starts a new execution replaying an execution on the same workflow version, with its original input
+	// overridden by the fields of the input. Returns ErrExecutionNotFound if the execution does not exist, or a
+	// *ValidationError if the input is invalid.
+	Rerun(ctx context.Context, executionID string, input *RerunInput) (*ExecutionResult, error)
+
+	// Events streams the events of an execution as it runs, until it finishes or ctx is done, when the channel is
+	// closed. Events are published in process, so an execution run by another instance only yields its
+	// execution.finished event, polled from the repository once it finished, as does an execution already finished.
+	// Returns ErrExecutionNotFound if the execution does not exist.
+	Events(ctx context.Context, executionID string) (<-chan Event, error)
+
+	// WorkflowEvents streams the events of every execution of a workflow run by this process from now on, until ctx
+	// is done, so clients can follow an execution before its ID is known.
+	// Returns ErrWorkflowNotFound if the workflow does not exist.
+	WorkflowEvents(ctx context.Context, workflowID string) (<-chan Event, error)
+
+	// Inspect returns the variables and steps of an execution, such as one paused by the debugger.
+	// Returns ErrExecutionNotFound if it does not exist.
+	Inspect(ctx context.Context, executionID string) (*DebugSnapshot, error)
+
+	// UpdateDebug modifies the variables and breakpoints of an execution paused by the debugger.
+	// Returns ErrExecutionNotFound if the execution does not exist, ErrExecutionNotPaused if it is not paused,
+	// or a *ValidationError if a breakpoint is not a node of the workflow.
+	UpdateDebug(ctx context.Context, executionID string, input *DebugInput) (*DebugSnapshot, error)
+
+	// Step runs the node an execution is paused at and pauses it again before the next node.
+	// Returns ErrExecutionNotFound if the execution does not exist, or ErrExecutionNotPaused if it is not paused.
+	Step(ctx context.Context, executionID string) (*ExecutionResult, error)
+
+	// Continue runs an execution paused by the debugger until its next breakpoint.
+	// Returns ErrExecutionNotFound if the execution does not exist, or ErrExecutionNotPaused if it is not paused.
+	Continue(ctx context.Context, executionID string) (*ExecutionResult, error)
+
+	// Versions lists the draft and the published versions of a workflow, without their definitions.
+	Versions(ctx context.Context, workflowID string) ([]Version, error)
+
+	// Version retrieves a version of a workflow along with its definition, or its draft for DraftVersion.
+	// Returns ErrVersionNotFound if it does not exist.
+	Version(ctx context.Context, workflowID string, version int) (*Version, error)
+
+	// SaveDraft creates or replaces the draft of a workflow. Drafts do not affect executions until published.
+	// Returns ErrWorkflowNotFound if the workflow does not exist, or a *ValidationError if the graph is invalid.
+	SaveDraft(ctx context.Context, workflowID string, input *DraftInput) (*Version, error)
+
+	// Publish publishes the draft of a workflow as its next version, which new executions then run.
+	// Returns ErrVersionNotFound if the workflow has no draft.
+	Publish(ctx context.Context, workflowID string, input *PublishInput) (*Version, error)
+
+	// Rollback publishes the graph of a previously published version as the next version.
+	// Returns ErrVersionNotFound if the version was not published.
+	Rollback(ctx context.Context, workflowID string, version int, input *PublishInput) (*Version, error)
+
+	// Diff lists the nodes and edges changed from one version of a workflow to another.
+	// Either version may be DraftVersion or LatestVersion, which the diff reports as the version number.
+	// Returns ErrVersionNotFound if either does not exist.
+	Diff(ctx context.Context, workflowID string, from int, to int) (*VersionDiff, error)
+
+	// Export returns the published graph of a workflow as a portable document.
+	// Returns ErrWorkflowNotFound if the workflow does not exist.
+	Export(ctx context.Context, workflowID string) (*document.Document, error)
+
+	// Import creates a new workflow from a document, publishing its graph as version 1. The workflow is given a new ID,
+	// and the workflows referenced by its nodes are remapped as described by the input.
+	// Returns a *ValidationError if the graph cannot run in this environment.
+	Import(ctx context.Context, doc *document.Document, input *ImportInput) (*ImportResult, error)
+
+	// RunWorkflow executes a workflow as the child of the execution in ctx, for the nodes running other workflows
+	// such as subworkflow and foreach nodes. It implements types.WorkflowRunner on top of Execute.
+	RunWorkflow(ctx context.Context, workflowID string, input *types.WorkflowInput) (*types.WorkflowResult, error)
+
+	// CancelWorkflow cancels an execution started by RunWorkflow. It implements types.WorkflowRunner on top of Cancel.
+	CancelWorkflow(ctx context.Context, executionID string) error
 }
 
 // Repository is an interface that provides methods to retrieve workflow data,
@@ -29,6 +131,85 @@ type Repository interface {
 	//   - A pointer to the Workflow object if found.
 	//   - An error if the retrieval fails or the workflow does not exist.
 	WorkflowWithNodesAndEdges(ctx context.Context, workflowID string) (*Workflow, error)
+
+	// CreateExecution persists a new execution and sets its ID, CreatedAt and UpdatedAt.
+	// Returns ErrIdempotencyKeyUsed if its idempotency key already started an execution of the workflow.
+	CreateExecution(ctx context.Context, execution *Execution) error
+
+	// UpdateExecution saves the status, state, steps, resume time, debugger and finish time of an existing execution.
+	UpdateExecution(ctx context.Context, execution *Execution) error
+
+	// ClaimDueExecutions marks up to limit waiting executions whose ResumeAt is not after now
+	// as running, claimed until claimedUntil, and returns them, oldest first. Rows locked by another caller
+	// are skipped, so an execution is only claimed once at a time. Executions whose claim expired before now,
+	// as the instance resuming them stopped, are claimed again.
+	ClaimDueExecutions(ctx context.Context, now, claimedUntil time.Time, limit int) ([]Execution, error)
+
+	// RenewClaim extends the claim of an execution resumed by ClaimDueExecutions until claimedUntil.
+	// Executions that are not claimed are left as they are.
+	RenewClaim(ctx context.Context, executionID string, claimedUntil time.Time) error
+
+	// Execution retrieves an execution by its ID.
+	// Returns ErrExecutionNotFound if it does not exist.
+	Execution(ctx context.Context, executionID string) (*Execution, error)
+
+	// ClaimWaitingExecution marks an execution waiting for input as running.
+	// Returns false if the execution is not waiting for input, e.g. because it was already resumed.
+	ClaimWaitingExecution(ctx context.Context, executionID string) (bool, error)
+
+	// ClaimFailedExecution marks a failed execution as running.
+	// Returns false if the execution has not failed, e.g. because it is already retried.
+	ClaimFailedExecution(ctx context.Context, executionID string) (bool, error)
+
+	// ClaimPausedExecution marks an execution paused by the debugger as running.
+	// Returns false if the execution is not paused, e.g. because it was already continued.
+	ClaimPausedExecution(ctx context.Context, executionID string) (bool, error)
+
+	// UpdatePausedExecution saves the state and debugger of an execution paused by the debugger.
+	// Returns false if the execution is not paused.
+	UpdatePausedExecution(ctx context.Context, execution *Execution) (bool, error)
+
+	// IdempotentExecution retrieves the execution of a workflow started with an idempotency key.
+	// Returns ErrExecutionNotFound if the key did not start an execution of the workflow.
+	IdempotentExecution(ctx context.Context, workflowID string, key string) (*Execution, error)
+
+	// ReleaseIdempotencyKey unsets the idempotency key of an execution, so the key can start another execution.
+	ReleaseIdempotencyKey(ctx context.Context, executionID string) error
+
+	// CancelExecution marks an execution waiting or paused by the debugger as cancelled.
+	// Returns false if the execution is neither waiting nor paused.
+	CancelExecution(ctx context.Context, executionID string) (bool, error)
+
+	// RequestCancel flags a running execution to be cancelled by the API instance running it.
+	// Returns false if the execution is not running.
+	RequestCancel(ctx context.Context, executionID string) (bool, error)
+
+	// CancelRequested reports whether cancelling the execution was requested.
+	// Returns ErrExecutionNotFound if it does not exist.
+	CancelRequested(ctx context.Context, executionID string) (bool, error)
+
+	// Versions lists the versions of a workflow, the draft first followed by the latest published versions.
+	// Definitions are not loaded.
+	Versions(ctx context.Context, workflowID string) ([]Version, error)
+
+	// Version retrieves a version with its definition, or the draft for DraftVersion.
+	// Returns ErrVersionNotFound if it does not exist.
+	Version(ctx context.Context, workflowID string, version int) (*Version, error)
+
+	// SaveDraft creates the draft of a workflow or replaces the existing one.
+	// Returns ErrWorkflowNotFound if the workflow does not exist.
+	SaveDraft(ctx context.Context, workflowID string, input *DraftInput) (*Version, error)
+
+	// PublishDraft numbers the draft as the next version and makes its graph the graph of the workflow.
+	// Returns ErrVersionNotFound if the workflow has no draft.
+	PublishDraft(ctx context.Context, workflowID string, input *PublishInput) (*Version, error)
+
+	// PublishVersion publishes definition as the next version and makes it the graph of the workflow.
+	// Returns ErrWorkflowNotFound if the workflow does not exist.
+	PublishVersion(ctx context.Context, workflowID string, definition *Definition, input *PublishInput) (*Version, error)
+
+	// CreateWorkflow creates a workflow with the given ID and name, publishing definition as its first version.
+	CreateWorkflow(ctx context.Context, workflowID string, name string, definition *Definition, input *PublishInput) (*Version, error)
 }
 
 // Handler is an interface that defines HTTP handler functions for managing workflows
@@ -43,4 +224,62 @@ type Handler interface {
 	// It uses the http.ResponseWriter to send responses and the *http.Request to read
 	// input parameters or payload.
 	Execute(w http.ResponseWriter, r *http.Request)
+
+	// Resume handles HTTP requests approving or rejecting an execution waiting for approval,
+	// continuing it from the waiting node.
+	Resume(w http.ResponseWriter, r *http.Request)
+
+	// Retry handles HTTP requests continuing a failed execution from the node that failed.
+	Retry(w http.ResponseWriter, r *http.Request)
+
+	// Cancel handles HTTP requests stopping an execution.
+	Cancel(w http.ResponseWriter, r *http.Request)
+
+	// Rerun handles HTTP requests replaying an execution with its original or overridden input.
+	Rerun(w http.ResponseWriter, r *http.Request)
+
+	// Events handles HTTP requests streaming the events of an execution as Server-Sent Events. Executions run by
+	// another instance only stream their execution.finished event.
+	Events(w http.ResponseWriter, r *http.Request)
+
+	// WorkflowEvents handles HTTP requests streaming the events of every execution of a workflow run by this instance
+	// as Server-Sent Events.
+	WorkflowEvents(w http.ResponseWriter, r *http.Request)
+
+	// Inspect handles HTTP requests retrieving the variables and steps of an execution.
+	Inspect(w http.ResponseWriter, r *http.Request)
+
+	// UpdateDebug handles HTTP requests modifying the variables and breakpoints of a paused execution.
+	UpdateDebug(w http.ResponseWriter, r *http.Request)
+
+	// Step handles HTTP requests running the node a paused execution is paused at.
+	Step(w http.ResponseWriter, r *http.Request)
+
+	// Continue handles HTTP requests running a paused execution until its next breakpoint.
+	Continue(w http.ResponseWriter, r *http.Request)
+
+	// Versions handles HTTP requests listing the versions of a workflow.
+	Versions(w http.ResponseWriter, r *http.Request)
+
+	// Version handles HTTP requests retrieving a version of a workflow, or its draft.
+	Version(w http.ResponseWriter, r *http.Request)
+
+	// SaveDraft handles HTTP requests creating or replacing the draft of a workflow.
+	SaveDraft(w http.ResponseWriter, r *http.Request)
+
+	// Publish handles HTTP requests publishing the draft of a workflow.
+	Publish(w http.ResponseWriter, r *http.Request)
+
+	// Rollback handles HTTP requests publishing a previous version of a workflow again.
+	Rollback(w http.ResponseWriter, r *http.Request)
+
+	// Diff handles HTTP requests comparing two versions of a workflow, by default the latest published version
+	// with the draft.
+	Diff(w http.ResponseWriter, r *http.Request)
+
+	// Export handles HTTP requests downloading a workflow as a JSON or YAML document.
+	Export(w http.ResponseWriter, r *http.Request)
+
+	// Import handles HTTP requests creating a workflow from a JSON or YAML document.
+	Import(w http.ResponseWriter, r *http.Request)
 }
@@ -2,27 +2,37 @@ package workflow
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
 	"workflow-code-test/api/internal/edge"
 	"workflow-code-test/api/internal/node"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// foreignKeyViolation is the Postgres error code raised when the workflow does not exist.
+const foreignKeyViolation = "23503"
+
+// uniqueViolation is the Postgres error code raised when an idempotency key is already used.
+const uniqueViolation = "23505"
+
 type RepositoryImpl struct {
 	pool *pgxpool.Pool
 }
 
 // WorkflowWithNodesAndEdges implements Repository.
 func (r *RepositoryImpl) WorkflowWithNodesAndEdges(ctx context.Context, workflowID string) (*Workflow, error) {
-	// Acquire a connection from the pool
-	conn, err := r.pool.Acquire(ctx)
+	// Read nodes and edges from the same snapshot, so a version published meanwhile is not half read
+	tx, err := r.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
 	if err != nil {
-		return nil, fmt.Errorf("failed to acquire database connection: %w", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
-	defer conn.Release()
+	defer tx.Rollback(ctx)
 
 	args := pgx.NamedArgs{
 		"workflowID": workflowID,
@@ -31,6 +41,7 @@ func (r *RepositoryImpl) WorkflowWithNodesAndEdges(ctx context.Context, workflow
 	queryNodes := `select
 			w.id,
 			w.name,
+			coalesce(w.published_version, 0),
 			w.created_at,
 			w.updated_at,
 			wn.node_id,
@@ -49,7 +60,7 @@ func (r *RepositoryImpl) WorkflowWithNodesAndEdges(ctx context.Context, workflow
 			wn.workflow_id = w.id
 		and w.id = @workflowID`
 
-	rows, err := conn.Query(ctx, queryNodes, args)
+	rows, err := tx.Query(ctx, queryNodes, args)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query nodes: %w", err)
 	}
@@ -63,6 +74,7 @@ func (r *RepositoryImpl) WorkflowWithNodesAndEdges(ctx context.Context, workflow
 		err := rows.Scan(
 			&workflow.ID,
 			&workflow.Name,
+			&workflow.Version,
 			&workflow.CreatedAt,
 			&workflow.UpdatedAt,
 			&node.ID,
@@ -103,7 +115,7 @@ func (r *RepositoryImpl) WorkflowWithNodesAndEdges(ctx context.Context, workflow
 			we.workflow_id = w.id
 		and w.id = @workflowID`
 
-	rows, err = conn.Query(ctx, queryEdges, args)
+	rows, err = tx.Query(ctx, queryEdges, args)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query edges: %w", err)
 	}
@@ -144,12 +156,701 @@ func (r *RepositoryImpl) WorkflowWithNodesAndEdges(ctx context.Context, workflow
 	}
 
 	if workflow.ID == "" {
-		return nil, fmt.Errorf("workflow not found")
+		return nil, ErrWorkflowNotFound
 	}
 
 	return &workflow, nil
 }
 
+// CreateExecution implements Repository.
+func (r *RepositoryImpl) CreateExecution(ctx context.Context, execution *Execution) error {
+	args := pgx.NamedArgs{
+		"workflowID": execution.WorkflowID,
+		"version":    execution.WorkflowVersion,
+		"parentID":   execution.ParentExecutionID,
+		"rerunOf":    execution.RerunOf,
+		"status":     execution.Status,
+		"input":      execution.Input,
+		"state":      execution.State,
+		"steps":      execution.Steps,
+		"resumeAt":   execution.ResumeAt,
+		"waitingFor": execution.WaitingFor,
+		"debug":      execution.Debug,
+		"key":        execution.IdempotencyKey,
+		"hash":       execution.RequestHash,
+		"executedAt": execution.ExecutedAt,
+	}
+
+	query := `insert into executions (workflow_id, workflow_version, parent_execution_id, rerun_of, status, input, state, steps, resume_at, waiting_for, debug, idempotency_key, request_hash, executed_at)
+		values (@workflowID, @version, @parentID, @rerunOf, @status, @input, @state, @steps, @resumeAt, @waitingFor, @debug, @key, @hash, @executedAt)
+		returning id, created_at, updated_at`
+
+	err := r.pool.QueryRow(ctx, query, args).Scan(&execution.ID, &execution.CreatedAt, &execution.UpdatedAt)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return ErrIdempotencyKeyUsed
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create execution: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateExecution implements Repository.
+func (r *RepositoryImpl) UpdateExecution(ctx context.Context, execution *Execution) error {
+	args := pgx.NamedArgs{
+		"id":         execution.ID,
+		"status":     execution.Status,
+		"state":      execution.State,
+		"steps":      execution.Steps,
+		"resumeAt":   execution.ResumeAt,
+		"waitingFor": execution.WaitingFor,
+		"debug":      execution.Debug,
+		"finishedAt": execution.FinishedAt,
+	}
+
+	// Saving the execution ends the run, and the claim of a timer with it
+	query := `update executions
+		set status = @status, state = @state, steps = @steps, resume_at = @resumeAt, waiting_for = @waitingFor,
+			debug = @debug, finished_at = @finishedAt, claimed_until = null, updated_at = now()
+		where id = @id
+		returning updated_at`
+
+	if err := r.pool.QueryRow(ctx, query, args).Scan(&execution.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to update execution %s: %w", execution.ID, err)
+	}
+
+	return nil
+}
+
+// ClaimDueExecutions implements Repository.
+func (r *RepositoryImpl) ClaimDueExecutions(ctx context.Context, now, claimedUntil time.Time, limit int) ([]Execution, error) {
+	args := pgx.NamedArgs{
+		"now":          now,
+		"claimedUntil": claimedUntil,
+		"limit":        limit,
+		"waiting":      ExecutionStatusWaiting,
+		"running":      ExecutionStatusRunning,
+	}
+
+	// resume_at is kept until the resumed execution is saved, so a stale claim resumes it from the same point
+	query := `update executions e
+		set status = @running, claimed_until = @claimedUntil, updated_at = now()
+		where e.id in (
+			select id from executions
+			where (status = @waiting and resume_at <= @now)
+				or (status = @running and claimed_until < @now)
+			order by resume_at
+			limit @limit
+			for update skip locked
+		)
+		returning ` + executionColumns("e")
+
+	rows, err := r.pool.Query(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim due executions: %w", err)
+	}
+	defer rows.Close()
+
+	var executions []Execution
+	for rows.Next() {
+		execution, err := scanExecution(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		executions = append(executions, *execution)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("claimDueExecutions: failed to iterate over rows: %w", err)
+	}
+
+	return executions, nil
+}
+
+// Execution implements Repository.
+func (r *RepositoryImpl) Execution(ctx context.Context, executionID string) (*Execution, error) {
+	args := pgx.NamedArgs{
+		"id": executionID,
+	}
+
+	query := `select ` + executionColumns("e") + ` from executions e where e.id = @id`
+
+	execution, err := scanExecution(r.pool.QueryRow(ctx, query, args))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrExecutionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return execution, nil
+}
+
+// RenewClaim implements Repository.
+func (r *RepositoryImpl) RenewClaim(ctx context.Context, executionID string, claimedUntil time.Time) error {
+	args := pgx.NamedArgs{
+		"id":           executionID,
+		"claimedUntil": claimedUntil,
+		"running":      ExecutionStatusRunning,
+	}
+
+	query := `update executions
+		set claimed_until = @claimedUntil
+		where id = @id and status = @running and claimed_until is not null`
+
+	if _, err := r.pool.Exec(ctx, query, args); err != nil {
+		return fmt.Errorf("failed to renew claim of execution %s: %w", executionID, err)
+	}
+
+	return nil
+}
+
+// ClaimWaitingExecution implements Repository.
+func (r *RepositoryImpl) ClaimWaitingExecution(ctx context.Context, executionID string) (bool, error) {
+	args := pgx.NamedArgs{
+		"id":      executionID,
+		"waiting": ExecutionStatusWaiting,
+		"running": ExecutionStatusRunning,
+	}
+
+	query := `update executions
+		set status = @running, updated_at = now()
+		where id = @id and status = @waiting and resume_at is null`
+
+	tag, err := r.pool.Exec(ctx, query, args)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim execution %s: %w", executionID, err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// ClaimFailedExecution implements Repository.
+func (r *RepositoryImpl) ClaimFailedExecution(ctx context.Context, executionID string) (bool, error) {
+	args := pgx.NamedArgs{
+		"id":      executionID,
+		"failed":  ExecutionStatusFailed,
+		"running": ExecutionStatusRunning,
+	}
+
+	// A cancellation requested before the execution failed does not apply to the retry
+	query := `update executions
+		set status = @running, cancel_requested = false, updated_at = now()
+		where id = @id and status = @failed`
+
+	tag, err := r.pool.Exec(ctx, query, args)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim execution %s: %w", executionID, err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// ClaimPausedExecution implements Repository.
+func (r *RepositoryImpl) ClaimPausedExecution(ctx context.Context, executionID string) (bool, error) {
+	args := pgx.NamedArgs{
+		"id":      executionID,
+		"paused":  ExecutionStatusPaused,
+		"running": ExecutionStatusRunning,
+	}
+
+	query := `update executions
+		set status = @running, updated_at = now()
+		where id = @id and status = @paused`
+
+	tag, err := r.pool.Exec(ctx, query, args)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim execution %s: %w", executionID, err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// UpdatePausedExecution implements Repository.
+func (r *RepositoryImpl) UpdatePausedExecution(ctx context.Context, execution *Execution) (bool, error) {
+	args := pgx.NamedArgs{
+		"id":     execution.ID,
+		"state":  execution.State,
+		"debug":  execution.Debug,
+		"paused": ExecutionStatusPaused,
+	}
+
+	query := `update executions
+		set state = @state, debug = @debug, updated_at = now()
+		where id = @id and status = @paused
+		returning updated_at`
+
+	err := r.pool.QueryRow(ctx, query, args).Scan(&execution.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to update execution %s: %w", execution.ID, err)
+	}
+
+	return true, nil
+}
+
+// CancelExecution implements Repository.
+func (r *RepositoryImpl) CancelExecution(ctx context.Context, executionID string) (bool, error) {
+	args := pgx.NamedArgs{
+		"id":        executionID,
+		"waiting":   ExecutionStatusWaiting,
+		"paused":    ExecutionStatusPaused,
+		"cancelled": ExecutionStatusCancelled,
+	}
+
+	query := `update executions
+		set status = @cancelled, resume_at = null, waiting_for = null, finished_at = now(), updated_at = now()
+		where id = @id and status in (@waiting, @paused)`
+
+	tag, err := r.pool.Exec(ctx, query, args)
+	if err != nil {
+		return false, fmt.Errorf("failed to cancel execution %s: %w", executionID, err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// RequestCancel implements Repository.
+func (r *RepositoryImpl) RequestCancel(ctx context.Context, executionID string) (bool, error) {
+	args := pgx.NamedArgs{
+		"id":      executionID,
+		"running": ExecutionStatusRunning,
+	}
+
+	query := `update executions
+		set cancel_requested = true, updated_at = now()
+		where id = @id and status = @running`
+
+	tag, err := r.pool.Exec(ctx, query, args)
+	if err != nil {
+		return false, fmt.Errorf("failed to request cancelling execution %s: %w", executionID, err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+// CancelRequested implements Repository.
+func (r *RepositoryImpl) CancelRequested(ctx context.Context, executionID string) (bool, error) {
+	args := pgx.NamedArgs{
+		"id": executionID,
+	}
+
+	var requested bool
+	err := r.pool.QueryRow(ctx, `select cancel_requested from executions where id = @id`, args).Scan(&requested)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, ErrExecutionNotFound
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check cancellation of execution %s: %w", executionID, err)
+	}
+
+	return requested, nil
+}
+
+// IdempotentExecution implements Repository.
+func (r *RepositoryImpl) IdempotentExecution(ctx context.Context, workflowID string, key string) (*Execution, error) {
+	args := pgx.NamedArgs{
+		"workflowID": workflowID,
+		"key":        key,
+	}
+
+	query := `select ` + executionColumns("e") + ` from executions e
+		where e.workflow_id = @workflowID and e.idempotency_key = @key`
+
+	execution, err := scanExecution(r.pool.QueryRow(ctx, query, args))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrExecutionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return execution, nil
+}
+
+// ReleaseIdempotencyKey implements Repository.
+func (r *RepositoryImpl) ReleaseIdempotencyKey(ctx context.Context, executionID string) error {
+	args := pgx.NamedArgs{
+		"id": executionID,
+	}
+
+	query := `update executions set idempotency_key = null, updated_at = now() where id = @id`
+
+	if _, err := r.pool.Exec(ctx, query, args); err != nil {
+		return fmt.Errorf("failed to release idempotency key of execution %s: %w", executionID, err)
+	}
+
+	return nil
+}
+
+// Versions implements Repository.
+func (r *RepositoryImpl) Versions(ctx context.Context, workflowID string) ([]Version, error) {
+	args := pgx.NamedArgs{
+		"workflowID": workflowID,
+	}
+
+	// The draft comes first, followed by the published versions from the latest
+	query := `select ` + versionColumns("v", false) + ` from workflow_versions v
+		where v.workflow_id = @workflowID
+		order by v."version" desc nulls first`
+
+	rows, err := r.pool.Query(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query versions: %w", err)
+	}
+	defer rows.Close()
+
+	versions := []Version{}
+	for rows.Next() {
+		version, err := scanVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, *version)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("queryVersions: failed to iterate over rows: %w", err)
+	}
+
+	return versions, nil
+}
+
+// Version implements Repository.
+func (r *RepositoryImpl) Version(ctx context.Context, workflowID string, version int) (*Version, error) {
+	args := pgx.NamedArgs{
+		"workflowID": workflowID,
+		"version":    version,
+		"draft":      VersionStatusDraft,
+	}
+
+	query := `select ` + versionColumns("v", true) + ` from workflow_versions v
+		where v.workflow_id = @workflowID
+		and (v."version" = @version or (@version = 0 and v.status = @draft))`
+
+	v, err := scanVersion(r.pool.QueryRow(ctx, query, args))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrVersionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// SaveDraft implements Repository.
+func (r *RepositoryImpl) SaveDraft(ctx context.Context, workflowID string, input *DraftInput) (*Version, error) {
+	args := pgx.NamedArgs{
+		"workflowID": workflowID,
+		"draft":      VersionStatusDraft,
+		"author":     input.Author,
+		"changeNote": input.ChangeNote,
+		"definition": Definition{Nodes: input.Nodes, Edges: input.Edges},
+	}
+
+	query := `insert into workflow_versions as v (workflow_id, status, author, change_note, definition)
+		values (@workflowID, @draft, @author, @changeNote, @definition)
+		on conflict (workflow_id) where status = 'draft'
+		do update set author = excluded.author, change_note = excluded.change_note, definition = excluded.definition,
+			updated_at = now()
+		returning ` + versionColumns("v", true)
+
+	version, err := scanVersion(r.pool.QueryRow(ctx, query, args))
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+		return nil, ErrWorkflowNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to save draft: %w", err)
+	}
+
+	return version, nil
+}
+
+// PublishDraft implements Repository.
+func (r *RepositoryImpl) PublishDraft(ctx context.Context, workflowID string, input *PublishInput) (*Version, error) {
+	var version *Version
+
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		if err := lockWorkflow(ctx, tx, workflowID); err != nil {
+			return err
+		}
+
+		args := pgx.NamedArgs{
+			"workflowID": workflowID,
+			"draft":      VersionStatusDraft,
+			"published":  VersionStatusPublished,
+			"author":     input.Author,
+			"changeNote": input.ChangeNote,
+		}
+
+		query := `update workflow_versions v
+			set status = @published,
+				"version" = (select coalesce(max("version"), 0) + 1 from workflow_versions where workflow_id = @workflowID),
+				author = coalesce(nullif(@author, ''), v.author),
+				change_note = coalesce(nullif(@changeNote, ''), v.change_note),
+				published_at = now(), updated_at = now()
+			where v.workflow_id = @workflowID and v.status = @draft
+			returning ` + versionColumns("v", true)
+
+		var err error
+		version, err = scanVersion(tx.QueryRow(ctx, query, args))
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrVersionNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		return replaceGraph(ctx, tx, workflowID, version)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return version, nil
+}
+
+// PublishVersion implements Repository.
+func (r *RepositoryImpl) PublishVersion(ctx context.Context, workflowID string, definition *Definition, input *PublishInput) (*Version, error) {
+	var version *Version
+
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		if err := lockWorkflow(ctx, tx, workflowID); err != nil {
+			return err
+		}
+
+		var err error
+		version, err = publishDefinition(ctx, tx, workflowID, definition, input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return version, nil
+}
+
+// CreateWorkflow implements Repository.
+func (r *RepositoryImpl) CreateWorkflow(ctx context.Context, workflowID string, name string, definition *Definition, input *PublishInput) (*Version, error) {
+	var version *Version
+
+	err := pgx.BeginFunc(ctx, r.pool, func(tx pgx.Tx) error {
+		args := pgx.NamedArgs{
+			"id":   workflowID,
+			"name": name,
+		}
+
+		if _, err := tx.Exec(ctx, `insert into workflows (id, "name") values (@id, @name)`, args); err != nil {
+			return fmt.Errorf("failed to create workflow: %w", err)
+		}
+
+		var err error
+		version, err = publishDefinition(ctx, tx, workflowID, definition, input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return version, nil
+}
+
+// publishDefinition inserts definition as the next published version of the workflow and makes it its graph.
+// The workflow must be locked or created by the transaction.
+func publishDefinition(ctx context.Context, tx pgx.Tx, workflowID string, definition *Definition, input *PublishInput) (*Version, error) {
+	args := pgx.NamedArgs{
+		"workflowID": workflowID,
+		"published":  VersionStatusPublished,
+		"author":     input.Author,
+		"changeNote": input.ChangeNote,
+		"definition": definition,
+	}
+
+	query := `insert into workflow_versions as v (workflow_id, "version", status, author, change_note, definition, published_at)
+		values (
+			@workflowID,
+			(select coalesce(max("version"), 0) + 1 from workflow_versions where workflow_id = @workflowID),
+			@published, @author, @changeNote, @definition, now()
+		)
+		returning ` + versionColumns("v", true)
+
+	version, err := scanVersion(tx.QueryRow(ctx, query, args))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := replaceGraph(ctx, tx, workflowID, version); err != nil {
+		return nil, err
+	}
+
+	return version, nil
+}
+
+// lockWorkflow locks the workflow row until the transaction ends, so versions are numbered one after the other.
+func lockWorkflow(ctx context.Context, tx pgx.Tx, workflowID string) error {
+	var id string
+	err := tx.QueryRow(ctx, `select id from workflows where id = @id for update`, pgx.NamedArgs{"id": workflowID}).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrWorkflowNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to lock workflow %s: %w", workflowID, err)
+	}
+
+	return nil
+}
+
+// replaceGraph makes the published version the graph of the workflow, read by WorkflowWithNodesAndEdges.
+func replaceGraph(ctx context.Context, tx pgx.Tx, workflowID string, version *Version) error {
+	batch := &pgx.Batch{}
+
+	batch.Queue(`delete from workflow_edges where workflow_id = @workflowID`, pgx.NamedArgs{"workflowID": workflowID})
+	batch.Queue(`delete from workflow_nodes where workflow_id = @workflowID`, pgx.NamedArgs{"workflowID": workflowID})
+
+	for _, n := range version.Definition.Nodes {
+		batch.Queue(`insert into workflow_nodes
+			(workflow_id, node_id, kind, position_x, position_y, data_label, data_description, data_metadata)
+			values (@workflowID, @id, @kind, @x, @y, @label, @description, @metadata)`, pgx.NamedArgs{
+			"workflowID":  workflowID,
+			"id":          n.ID,
+			"kind":        n.Kind,
+			"x":           n.Position.X,
+			"y":           n.Position.Y,
+			"label":       n.Data.Label,
+			"description": n.Data.Description,
+			"metadata":    n.Data.Metadata,
+		})
+	}
+
+	for _, e := range version.Definition.Edges {
+		// Convert nullable string pointer to nullable boolean
+		var isSourceHandle *bool
+		if e.SourceHandle != nil {
+			val, err := strconv.ParseBool(*e.SourceHandle)
+			if err != nil {
+				return fmt.Errorf("invalid source handle of edge %s: %w", e.ID, err)
+			}
+			isSourceHandle = &val
+		}
+
+		batch.Queue(`insert into workflow_edges
+			(workflow_id, node_source, node_target, kind, is_animated, is_source_handle, label, label_style, style)
+			values (@workflowID, @source, @target, @kind, @animated, @isSourceHandle, @label, @labelStyle, @style)`, pgx.NamedArgs{
+			"workflowID":     workflowID,
+			"source":         e.Source,
+			"target":         e.Target,
+			"kind":           e.Kind,
+			"animated":       e.Animated,
+			"isSourceHandle": isSourceHandle,
+			"label":          e.Label,
+			"labelStyle":     e.LabelStyle,
+			"style":          e.Style,
+		})
+	}
+
+	batch.Queue(`update workflows set published_version = @version, updated_at = now() where id = @workflowID`, pgx.NamedArgs{
+		"workflowID": workflowID,
+		"version":    version.Version,
+	})
+
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("failed to publish version %d of workflow %s: %w", version.Version, workflowID, err)
+	}
+
+	return nil
+}
+
+// versionColumns lists the columns read by scanVersion, qualified by the table alias.
+// The definition is only read when withDefinition is set, and is nil otherwise.
+func versionColumns(alias string, withDefinition bool) string {
+	definition := "null::jsonb"
+	if withDefinition {
+		definition = alias + ".definition"
+	}
+
+	columns := []string{"workflow_id", `"version"`, "status", "author", "change_note", "published_at", "created_at", "updated_at"}
+	for i, column := range columns {
+		columns[i] = alias + "." + column
+	}
+
+	// Drafts are not numbered yet
+	columns[1] = "coalesce(" + columns[1] + ", 0)"
+
+	return strings.Join(append(columns, definition), ", ")
+}
+
+func scanVersion(row pgx.Row) (*Version, error) {
+	var version Version
+
+	err := row.Scan(
+		&version.WorkflowID,
+		&version.Version,
+		&version.Status,
+		&version.Author,
+		&version.ChangeNote,
+		&version.PublishedAt,
+		&version.CreatedAt,
+		&version.UpdatedAt,
+		&version.Definition,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan version: %w", err)
+	}
+
+	return &version, nil
+}
+
+// executionColumns lists the columns read by scanExecution, qualified by the table alias.
+func executionColumns(alias string) string {
+	columns := []string{"id", "workflow_id", "workflow_version", "parent_execution_id", "rerun_of", "status", "input", "state", "steps", "resume_at", "waiting_for", "debug", "cancel_requested", "idempotency_key", "request_hash", "executed_at", "finished_at", "created_at", "updated_at"}
+	for i, column := range columns {
+		columns[i] = alias + "." + column
+	}
+
+	return strings.Join(columns, ", ")
+}
+
+func scanExecution(row pgx.Row) (*Execution, error) {
+	var execution Execution
+
+	err := row.Scan(
+		&execution.ID,
+		&execution.WorkflowID,
+		&execution.WorkflowVersion,
+		&execution.ParentExecutionID,
+		&execution.RerunOf,
+		&execution.Status,
+		&execution.Input,
+		&execution.State,
+		&execution.Steps,
+		&execution.ResumeAt,
+		&execution.WaitingFor,
+		&execution.Debug,
+		&execution.CancelRequested,
+		&execution.IdempotencyKey,
+		&execution.RequestHash,
+		&execution.ExecutedAt,
+		&execution.FinishedAt,
+		&execution.CreatedAt,
+		&execution.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan execution: %w", err)
+	}
+
+	return &execution, nil
+}
+
 func NewRepository(pool *pgxpool.Pool) Repository {
 	return &RepositoryImpl{
 		pool: pool,
@@ -0,0 +1,199 @@
+package workflow_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"workflow-code-test/api/internal/edge"
+	"workflow-code-test/api/internal/node"
+	"workflow-code-test/api/internal/workflow"
+	_ "workflow-code-test/api/pkg/nodes/approval"
+
+	"github.com/stretchr/testify/require"
+)
+
+// addApproval adds a workflow waiting for an approval, asking for a reason, before running the approved or the
+// rejected node.
+func addApproval(t *testing.T, repo *workflow.MemoryRepository) {
+	t.Helper()
+
+	yes, no := "true", "false"
+	require.NoError(t, repo.AddWorkflow(&workflow.Workflow{
+		ID:   "approved",
+		Name: "approved",
+		Nodes: []node.Node{
+			newNode("start", "start", nil),
+			newNode("approval", "approval", map[string]any{"inputFields": []any{"reason"}}),
+			newNode("approved", "approved", nil),
+			newNode("rejected", "rejected", nil),
+			newNode("end", "end", nil),
+		},
+		Edges: []edge.Edge{
+			{ID: "start-approval", Source: "start", Target: "approval"},
+			{ID: "approval-approved", Source: "approval", Target: "approved", SourceHandle: &yes},
+			{ID: "approval-rejected", Source: "approval", Target: "rejected", SourceHandle: &no},
+			{ID: "approved-end", Source: "approved", Target: "end"},
+			{ID: "rejected-end", Source: "rejected", Target: "end"},
+		},
+	}))
+}
+
+func approvalKinds() map[string]*testKind {
+	return map[string]*testKind{"approved": {}, "rejected": {}}
+}
+
+func TestResume(t *testing.T) {
+	tests := []struct {
+		name          string
+		decision      workflow.Decision
+		expectedNode  string
+		expectedValue bool
+	}{
+		{
+			name:          "approved",
+			decision:      workflow.DecisionApprove,
+			expectedNode:  "approved",
+			expectedValue: true,
+		},
+		{
+			name:         "rejected",
+			decision:     workflow.DecisionReject,
+			expectedNode: "rejected",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := workflow.NewMemoryRepository()
+			addApproval(t, repo)
+			kinds := approvalKinds()
+			svc := newService(t, repo, kinds)
+			ctx := context.Background()
+
+			result, err := svc.Execute(ctx, "approved", &workflow.ExecutionInput{})
+			require.NoError(t, err)
+			require.Equal(t, workflow.ExecutionStatusWaiting, result.Status)
+			require.NotNil(t, result.WaitingFor)
+
+			result, err = svc.Resume(ctx, result.ExecutionID, &workflow.ResumeInput{
+				Decision: tt.decision,
+				FormData: map[string]any{"reason": "Checked"},
+			})
+			require.NoError(t, err)
+			require.Equal(t, workflow.ExecutionStatusCompleted, result.Status)
+			require.Equal(t, []string{"start", "approval", tt.expectedNode, "end"}, stepIDs(result.Steps))
+
+			// The decision and the form data are the outputs of the approval node
+			runs := kinds[tt.expectedNode].Runs()
+			require.Len(t, runs, 1)
+			require.Equal(t, tt.expectedValue, runs[0]["approved"])
+			require.Equal(t, "Checked", runs[0]["reason"])
+
+			for name, kind := range kinds {
+				if name != tt.expectedNode {
+					require.Empty(t, kind.Runs())
+				}
+			}
+		})
+	}
+}
+
+func TestResume_Invalid(t *testing.T) {
+	repo := workflow.NewMemoryRepository()
+	addApproval(t, repo)
+	kinds := approvalKinds()
+	svc := newService(t, repo, kinds)
+	ctx := context.Background()
+
+	waiting, err := svc.Execute(ctx, "approved", &workflow.ExecutionInput{})
+	require.NoError(t, err)
+
+	_, err = svc.Resume(ctx, waiting.ExecutionID, &workflow.ResumeInput{Decision: "maybe"})
+	var validationErr *workflow.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Equal(t, map[string]string{"decision": "must be approve or reject", "reason": "is required"}, validationErr.Fields)
+
+	execution, err := repo.Execution(ctx, waiting.ExecutionID)
+	require.NoError(t, err)
+	require.Equal(t, workflow.ExecutionStatusWaiting, execution.Status)
+
+	_, err = svc.Resume(ctx, "missing", &workflow.ResumeInput{Decision: workflow.DecisionApprove})
+	require.ErrorIs(t, err, workflow.ErrExecutionNotFound)
+}
+
+func TestResume_NotWaiting(t *testing.T) {
+	repo := workflow.NewMemoryRepository()
+	addApproval(t, repo)
+	addChain(t, repo, "delayed", newNode("delay", "delay", map[string]any{"duration": "1h"}))
+	kinds := approvalKinds()
+	svc := newService(t, repo, kinds)
+	ctx := context.Background()
+	input := &workflow.ResumeInput{Decision: workflow.DecisionApprove, FormData: map[string]any{"reason": "Checked"}}
+
+	result, err := svc.Execute(ctx, "approved", &workflow.ExecutionInput{})
+	require.NoError(t, err)
+	_, err = svc.Resume(ctx, result.ExecutionID, input)
+	require.NoError(t, err)
+
+	// A completed execution is not resumed again
+	_, err = svc.Resume(ctx, result.ExecutionID, input)
+	require.ErrorIs(t, err, workflow.ErrExecutionNotWaiting)
+	require.Len(t, kinds["approved"].Runs(), 1)
+
+	// Executions waiting for a delay are resumed by timers only
+	delayed, err := svc.Execute(ctx, "delayed", &workflow.ExecutionInput{})
+	require.NoError(t, err)
+	require.Equal(t, workflow.ExecutionStatusWaiting, delayed.Status)
+
+	_, err = svc.Resume(ctx, delayed.ExecutionID, input)
+	require.ErrorIs(t, err, workflow.ErrExecutionNotWaiting)
+}
+
+// concurrentRepository holds the callers reading an execution until all of them read it, so they all find it waiting.
+type concurrentRepository struct {
+	*workflow.MemoryRepository
+	read sync.WaitGroup
+}
+
+// Execution implements workflow.Repository.
+func (r *concurrentRepository) Execution(ctx context.Context, executionID string) (*workflow.Execution, error) {
+	execution, err := r.MemoryRepository.Execution(ctx, executionID)
+	r.read.Done()
+	r.read.Wait()
+
+	return execution, err
+}
+
+func TestResume_Concurrent(t *testing.T) {
+	repo := workflow.NewMemoryRepository()
+	addApproval(t, repo)
+	kinds := approvalKinds()
+	ctx := context.Background()
+
+	waiting, err := newService(t, repo, kinds).Execute(ctx, "approved", &workflow.ExecutionInput{})
+	require.NoError(t, err)
+
+	concurrent := &concurrentRepository{MemoryRepository: repo}
+	concurrent.read.Add(2)
+	svc := newService(t, concurrent, kinds)
+
+	errs := make(chan error, 2)
+	for range 2 {
+		go func() {
+			_, err := svc.Resume(ctx, waiting.ExecutionID, &workflow.ResumeInput{
+				Decision: workflow.DecisionApprove,
+				FormData: map[string]any{"reason": "Checked"},
+			})
+			errs <- err
+		}()
+	}
+
+	// Both find the execution waiting, only one claims it
+	first, second := <-errs, <-errs
+	if first != nil {
+		first, second = second, first
+	}
+	require.NoError(t, first)
+	require.ErrorIs(t, second, workflow.ErrExecutionNotWaiting)
+	require.Len(t, kinds["approved"].Runs(), 1)
+}
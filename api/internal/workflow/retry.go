@@ -0,0 +1,69 @@
+package workflow
+
+import (
+	"context"
+	"maps"
+)
+
+// Retry implements Service.
+func (s *ServiceImpl) Retry(ctx context.Context, executionID string) (*ExecutionResult, error) {
+	execution, err := s.repo.Execution(ctx, executionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if execution.Status != ExecutionStatusFailed {
+		return nil, ErrExecutionNotFailed
+	}
+
+	claimed, err := s.repo.ClaimFailedExecution(ctx, executionID)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, ErrExecutionNotFailed
+	}
+
+	// The state was saved after the last completed node, and the metadata of the failed node was merged into a copy
+	// of its variables, so the failed node runs again with the same variables
+	if execution.State.Variables == nil {
+		execution.State.Variables = map[string]any{}
+	}
+	execution.Status = ExecutionStatusRunning
+	execution.FinishedAt = nil
+	s.publish(execution, Event{Type: EventExecutionResumed, Status: execution.Status})
+
+	wf, err := s.executionWorkflow(ctx, execution)
+	if err != nil {
+		execution.Status = ExecutionStatusFailed
+		return s.save(ctx, execution, err)
+	}
+
+	return s.run(ctx, wf, execution)
+}
+
+// Rerun implements Service.
+func (s *ServiceImpl) Rerun(ctx context.Context, executionID string, input *RerunInput) (*ExecutionResult, error) {
+	execution, err := s.repo.Execution(ctx, executionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Executions started before inputs were recorded can only be replayed with a new input
+	if execution.Input == nil && input.FormData == nil {
+		return nil, &ValidationError{Fields: map[string]string{"formData": "the input of the execution was not recorded and must be given"}}
+	}
+
+	formData := maps.Clone(execution.Input)
+	if formData == nil {
+		formData = map[string]any{}
+	}
+	maps.Copy(formData, input.FormData)
+
+	wf, err := s.executionWorkflow(ctx, execution)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.start(ctx, wf, &ExecutionInput{FormData: formData}, &execution.ID)
+}
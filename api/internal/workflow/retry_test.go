@@ -0,0 +1,259 @@
+package workflow_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"workflow-code-test/api/internal/edge"
+	"workflow-code-test/api/internal/node"
+	"workflow-code-test/api/internal/workflow"
+	_ "workflow-code-test/api/pkg/nodes/delay"
+
+	"github.com/stretchr/testify/require"
+)
+
+// flakyKinds returns the kinds of a workflow preparing a variable before a flaky node, which fails on its first run.
+func flakyKinds() map[string]*testKind {
+	return map[string]*testKind{
+		"prepare": {run: func(ctx context.Context, _ int, _ map[string]any) (any, error) {
+			return map[string]any{"prepared": "yes"}, nil
+		}},
+		"flaky": {run: func(ctx context.Context, n int, _ map[string]any) (any, error) {
+			if n == 1 {
+				return nil, errors.New("temporarily unavailable")
+			}
+			return map[string]any{"result": "ok"}, nil
+		}},
+	}
+}
+
+func TestRetry(t *testing.T) {
+	repo := workflow.NewMemoryRepository()
+	addChain(t, repo, "flaky",
+		newNode("prepare", "prepare", map[string]any{"source": "form"}),
+		newNode("flaky", "flaky", map[string]any{"region": "apac"}),
+	)
+	kinds := flakyKinds()
+	svc := newService(t, repo, kinds)
+	ctx := context.Background()
+
+	failed, err := svc.Execute(ctx, "flaky", &workflow.ExecutionInput{FormData: map[string]any{"city": "Sydney"}})
+	require.ErrorContains(t, err, "temporarily unavailable")
+	require.Equal(t, workflow.ExecutionStatusFailed, failed.Status)
+
+	// The state was saved after the last completed node, without the metadata of the nodes
+	execution, err := repo.Execution(ctx, failed.ExecutionID)
+	require.NoError(t, err)
+	require.Equal(t, "prepare", execution.State.Source)
+	require.Equal(t, map[string]any{"city": "Sydney", "prepared": "yes"}, execution.State.Variables)
+
+	result, err := svc.Retry(ctx, failed.ExecutionID)
+	require.NoError(t, err)
+	require.Equal(t, failed.ExecutionID, result.ExecutionID)
+	require.Equal(t, workflow.ExecutionStatusCompleted, result.Status)
+	require.Equal(t, []string{"start", "prepare", "flaky", "flaky", "end"}, stepIDs(result.Steps))
+	require.Equal(t, map[string]any{"city": "Sydney", "prepared": "yes", "result": "ok"}, result.Variables)
+
+	// Only the failed node ran again, with the same variables and its own metadata
+	require.Len(t, kinds["prepare"].Runs(), 1)
+	runs := kinds["flaky"].Runs()
+	require.Len(t, runs, 2)
+	require.Equal(t, runs[0], runs[1])
+	require.Equal(t, "yes", runs[1]["prepared"])
+	require.Equal(t, "apac", runs[1]["region"])
+	require.NotContains(t, runs[1], "source")
+
+	require.Equal(t, workflow.StepStatusFailed, result.Steps[2].Status)
+	require.Equal(t, 1, result.Steps[2].Attempt)
+	require.Equal(t, workflow.StepStatusCompleted, result.Steps[3].Status)
+	require.Equal(t, 2, result.Steps[3].Attempt)
+
+	t.Run("completed execution is rejected", func(t *testing.T) {
+		_, err := svc.Retry(ctx, failed.ExecutionID)
+		require.ErrorIs(t, err, workflow.ErrExecutionNotFailed)
+		require.Len(t, kinds["flaky"].Runs(), 2)
+	})
+
+	t.Run("missing execution", func(t *testing.T) {
+		_, err := svc.Retry(ctx, "missing")
+		require.ErrorIs(t, err, workflow.ErrExecutionNotFound)
+	})
+}
+
+// requireTimed checks that the step started after earliest and finished after it started, taking durationMs.
+func requireTimed(t *testing.T, step workflow.Step, earliest time.Time) {
+	t.Helper()
+
+	require.NotNil(t, step.StartedAt)
+	require.NotNil(t, step.FinishedAt)
+	require.False(t, step.StartedAt.Before(earliest))
+	require.False(t, step.FinishedAt.Before(*step.StartedAt))
+	require.Equal(t, step.FinishedAt.Sub(*step.StartedAt).Milliseconds(), step.DurationMs)
+}
+
+func TestRetry_Steps(t *testing.T) {
+	// The check node reads the prepared variable and routes on its result, failing its first run
+	repo := workflow.NewMemoryRepository()
+	trueHandle, falseHandle := "true", "false"
+	require.NoError(t, repo.AddWorkflow(&workflow.Workflow{
+		ID:   "routed",
+		Name: "routed",
+		Nodes: []node.Node{
+			newNode("start", "start", nil),
+			newNode("prepare", "prepare", map[string]any{"outputVariables": []any{"prepared"}}),
+			newNode("check", "check", map[string]any{"inputVariables": []any{"city", "prepared"}}),
+			newNode("fallback", "fallback", nil),
+			newNode("end", "end", nil),
+		},
+		Edges: []edge.Edge{
+			{ID: "start-prepare", Source: "start", Target: "prepare"},
+			{ID: "prepare-check", Source: "prepare", Target: "check"},
+			{ID: "check-end", Source: "check", Target: "end", SourceHandle: &trueHandle},
+			{ID: "check-fallback", Source: "check", Target: "fallback", SourceHandle: &falseHandle},
+			{ID: "fallback-end", Source: "fallback", Target: "end"},
+		},
+	}))
+	kinds := map[string]*testKind{
+		"prepare": flakyKinds()["prepare"],
+		"check": {run: func(ctx context.Context, n int, _ map[string]any) (any, error) {
+			time.Sleep(5 * time.Millisecond)
+			if n == 1 {
+				return nil, errors.New("temporarily unavailable")
+			}
+			return map[string]any{"valid": true}, nil
+		}},
+		"fallback": {},
+	}
+	svc := newService(t, repo, kinds)
+	ctx := context.Background()
+
+	startedAt := time.Now()
+	failed, err := svc.Execute(ctx, "routed", &workflow.ExecutionInput{FormData: map[string]any{"city": "Sydney"}})
+	require.ErrorContains(t, err, "temporarily unavailable")
+	require.Equal(t, []string{"start", "prepare", "check"}, stepIDs(failed.Steps))
+
+	failedStep := failed.Steps[2]
+	require.Equal(t, workflow.StepStatusFailed, failedStep.Status)
+	require.Equal(t, "check", failedStep.Type)
+	require.Equal(t, 1, failedStep.Attempt)
+	require.Equal(t, map[string]any{"city": "Sydney", "prepared": "yes"}, failedStep.Input)
+	require.Nil(t, failedStep.Output)
+	require.Nil(t, failedStep.Handle)
+	require.Equal(t, "failed to execute node check: temporarily unavailable", failedStep.Error)
+	requireTimed(t, failedStep, *failed.Steps[1].FinishedAt)
+	require.GreaterOrEqual(t, failedStep.DurationMs, int64(5))
+
+	prepared := failed.Steps[1]
+	require.Equal(t, workflow.StepStatusCompleted, prepared.Status)
+	require.Equal(t, 1, prepared.Attempt)
+	require.Empty(t, prepared.Error)
+	requireTimed(t, prepared, startedAt)
+
+	result, err := svc.Retry(ctx, failed.ExecutionID)
+	require.NoError(t, err)
+	require.Equal(t, workflow.ExecutionStatusCompleted, result.Status)
+	require.Equal(t, []string{"start", "prepare", "check", "check", "end"}, stepIDs(result.Steps))
+	require.Empty(t, kinds["fallback"].Runs())
+
+	// The failed step is kept as it was, followed by the retried one
+	require.Equal(t, failedStep.Status, result.Steps[2].Status)
+	require.Equal(t, failedStep.Error, result.Steps[2].Error)
+	require.Equal(t, failedStep.Attempt, result.Steps[2].Attempt)
+
+	retried := result.Steps[3]
+	require.Equal(t, workflow.StepStatusCompleted, retried.Status)
+	require.Equal(t, 2, retried.Attempt)
+	require.Equal(t, map[string]any{"city": "Sydney", "prepared": "yes"}, retried.Input)
+	require.Equal(t, map[string]any{"valid": true}, retried.Output)
+	require.NotNil(t, retried.Handle)
+	require.Equal(t, "true", *retried.Handle)
+	require.Empty(t, retried.Error)
+	requireTimed(t, retried, *failedStep.FinishedAt)
+	require.GreaterOrEqual(t, retried.DurationMs, int64(5))
+}
+
+func TestRetry_NotFailed(t *testing.T) {
+	repo := workflow.NewMemoryRepository()
+	addChain(t, repo, "delayed", newNode("delay", "delay", map[string]any{"duration": "1h"}))
+	svc := newService(t, repo, nil)
+	ctx := context.Background()
+
+	waiting, err := svc.Execute(ctx, "delayed", &workflow.ExecutionInput{})
+	require.NoError(t, err)
+	require.Equal(t, workflow.ExecutionStatusWaiting, waiting.Status)
+
+	_, err = svc.Retry(ctx, waiting.ExecutionID)
+	require.ErrorIs(t, err, workflow.ErrExecutionNotFailed)
+
+	execution, err := repo.Execution(ctx, waiting.ExecutionID)
+	require.NoError(t, err)
+	require.Equal(t, workflow.ExecutionStatusWaiting, execution.Status)
+}
+
+func TestRerun(t *testing.T) {
+	tests := []struct {
+		name          string
+		overrides     map[string]any
+		expectedInput map[string]any
+	}{
+		{
+			name:          "original input",
+			expectedInput: map[string]any{"city": "Sydney", "units": "metric"},
+		},
+		{
+			name:          "overridden field",
+			overrides:     map[string]any{"city": "Perth"},
+			expectedInput: map[string]any{"city": "Perth", "units": "metric"},
+		},
+		{
+			name:          "added field",
+			overrides:     map[string]any{"threshold": 25.0},
+			expectedInput: map[string]any{"city": "Sydney", "units": "metric", "threshold": 25.0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := workflow.NewMemoryRepository()
+			addChain(t, repo, "rerun", newNode("work", "work", nil))
+			kinds := map[string]*testKind{"work": {}}
+			svc := newService(t, repo, kinds)
+			ctx := context.Background()
+
+			original, err := svc.Execute(ctx, "rerun", &workflow.ExecutionInput{
+				FormData: map[string]any{"city": "Sydney", "units": "metric"},
+			})
+			require.NoError(t, err)
+
+			result, err := svc.Rerun(ctx, original.ExecutionID, &workflow.RerunInput{FormData: tt.overrides})
+			require.NoError(t, err)
+			require.NotEqual(t, original.ExecutionID, result.ExecutionID)
+			require.Equal(t, workflow.ExecutionStatusCompleted, result.Status)
+			require.NotNil(t, result.RerunOf)
+			require.Equal(t, original.ExecutionID, *result.RerunOf)
+
+			execution, err := repo.Execution(ctx, result.ExecutionID)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedInput, execution.Input)
+
+			runs := kinds["work"].Runs()
+			require.Len(t, runs, 2)
+			for key, value := range tt.expectedInput {
+				require.Equal(t, value, runs[1][key])
+			}
+
+			// The original execution is left untouched
+			execution, err = repo.Execution(ctx, original.ExecutionID)
+			require.NoError(t, err)
+			require.Equal(t, map[string]any{"city": "Sydney", "units": "metric"}, execution.Input)
+		})
+	}
+
+	t.Run("missing execution", func(t *testing.T) {
+		svc := newService(t, workflow.NewMemoryRepository(), nil)
+
+		_, err := svc.Rerun(context.Background(), "missing", &workflow.RerunInput{})
+		require.ErrorIs(t, err, workflow.ErrExecutionNotFound)
+	})
+}
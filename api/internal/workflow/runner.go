@@ -0,0 +1,35 @@
+package workflow
+
+import (
+	"context"
+	"workflow-code-test/api/pkg/nodes/types"
+)
+
+var _ types.WorkflowRunner = (*ServiceImpl)(nil)
+
+// RunWorkflow implements types.WorkflowRunner for the nodes running other workflows.
+func (s *ServiceImpl) RunWorkflow(ctx context.Context, workflowID string, input *types.WorkflowInput) (*types.WorkflowResult, error) {
+	result, err := s.Execute(ctx, workflowID, &ExecutionInput{
+		FormData: input.Variables,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := make([]map[string]any, 0, len(result.Steps))
+	for _, step := range result.Steps {
+		outputs = append(outputs, step.Output)
+	}
+
+	return &types.WorkflowResult{
+		ExecutionID: result.ExecutionID,
+		Status:      types.WorkflowStatus(result.Status),
+		Outputs:     outputs,
+	}, nil
+}
+
+// CancelWorkflow implements types.WorkflowRunner.
+func (s *ServiceImpl) CancelWorkflow(ctx context.Context, executionID string) error {
+	_, err := s.Cancel(ctx, executionID)
+	return err
+}
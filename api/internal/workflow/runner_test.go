@@ -0,0 +1,53 @@
+package workflow_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"workflow-code-test/api/internal/workflow"
+	"workflow-code-test/api/pkg/nodes"
+	_ "workflow-code-test/api/pkg/nodes/delay"
+	"workflow-code-test/api/pkg/nodes/subworkflow"
+	"workflow-code-test/api/pkg/nodes/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunWorkflow_ChildPaused(t *testing.T) {
+	repo := workflow.NewMemoryRepository()
+	addChain(t, repo, "child", newNode("record", "record", nil), newNode("delay", "delay", map[string]any{"duration": "1h"}))
+	addChain(t, repo, "parent",
+		newNode("sub", subworkflow.Descriptor.Kind, map[string]any{subworkflow.WorkflowIDKey: "child"}),
+		newNode("after", "after", nil),
+	)
+
+	var childID string
+	record := &testKind{run: func(ctx context.Context, _ int, _ map[string]any) (any, error) {
+		childID = types.ExecutionID(ctx)
+		return nil, nil
+	}}
+	after := &testKind{}
+
+	registry := nodes.DefaultRegistry().Clone()
+	registry.Register("record", record.executor, types.Descriptor{})
+	registry.Register("after", after.executor, types.Descriptor{})
+	svc := workflow.NewService(repo, nodes.NewService(registry), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	registry.Register(subworkflow.Descriptor.Kind, func() types.NodeExecutor {
+		return &subworkflow.Executor{Opts: &subworkflow.Options{Workflows: svc}}
+	}, subworkflow.Descriptor)
+	ctx := context.Background()
+
+	result, err := svc.Execute(ctx, "parent", &workflow.ExecutionInput{})
+	require.ErrorContains(t, err, "which was cancelled: sub-workflows must complete without pausing")
+	require.Equal(t, workflow.ExecutionStatusFailed, result.Status)
+	require.Empty(t, after.Runs())
+
+	// The child is not left waiting for a parent that failed
+	require.NotEmpty(t, childID)
+	child, err := repo.Execution(ctx, childID)
+	require.NoError(t, err)
+	require.Equal(t, workflow.ExecutionStatusCancelled, child.Status)
+	require.Equal(t, &result.ExecutionID, child.ParentExecutionID)
+	require.Nil(t, child.ResumeAt)
+}
@@ -2,12 +2,14 @@ package workflow
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"maps"
 	"strconv"
 	"time"
 	"workflow-code-test/api/internal/node"
+	"workflow-code-test/api/pkg/eventbus"
 	"workflow-code-test/api/pkg/nodes"
 	"workflow-code-test/api/pkg/nodes/types"
 )
@@ -15,12 +17,22 @@ import (
 const (
 	startNode = "start"
 	endNode   = "end"
+
+	// resumeBatchSize is the maximum number of due executions resumed by a single ResumeDue call.
+	resumeBatchSize = 50
+	// claimLease is how long a due execution is claimed for, renewed while it runs. Executions whose claim is not
+	// renewed, as the API instance resuming them stopped, are resumed again by another timer.
+	claimLease = time.Minute
 )
 
 type ServiceImpl struct {
 	repo        Repository
 	nodeService *nodes.Service
+	events      *eventbus.Bus[Event]
 	log         *slog.Logger
+
+	// eventsPollInterval is how often execution event streams poll the execution, see forward
+	eventsPollInterval time.Duration
 }
 
 // optimizedWorkflow contains pre-built indexes for lookups
@@ -34,14 +46,6 @@ type outData struct {
 	nextNode node.Node
 }
 
-type inData struct {
-	source             string
-	sourceHandleResult bool
-}
-
-// Alias for better readability in refactored code
-type executionState = inData
-
 // Workflow implements Service.
 func (s *ServiceImpl) Workflow(ctx context.Context, workflowID string) (*Workflow, error) {
 	workflow, err := s.repo.WorkflowWithNodesAndEdges(ctx, workflowID)
@@ -58,80 +62,372 @@ func (s *ServiceImpl) Execute(ctx context.Context, workflowID string, executionI
 		return nil, err
 	}
 
-	return s.executeWorkflow(ctx, wf, executionInput)
+	return s.start(ctx, wf, executionInput, nil)
+}
+
+// start validates the input and runs a new execution of the workflow.
+// rerunOf, if any, is the execution the new execution replays.
+func (s *ServiceImpl) start(ctx context.Context, wf *Workflow, executionInput *ExecutionInput, rerunOf *string) (*ExecutionResult, error) {
+	if executionInput.FormData == nil {
+		executionInput.FormData = map[string]any{}
+	}
+
+	// Requests repeating an idempotency key get the execution it started, dry runs having no effect to repeat
+	if executionInput.IdempotencyKey != "" && !isDryRun(ctx) {
+		result, err := s.replay(ctx, wf.ID, executionInput)
+		if err != nil || result != nil {
+			return result, err
+		}
+	}
+
+	if err := s.validateInput(wf, executionInput.FormData); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateWorkflow(wf, executionInput.FormData); err != nil {
+		return nil, err
+	}
+
+	if err := s.validateMocks(ctx, wf, executionInput.Mocks); err != nil {
+		return nil, err
+	}
+
+	if executionInput.Debug != nil {
+		if isDryRun(ctx) {
+			return nil, &ValidationError{Fields: map[string]string{"debug": "not allowed in dry runs"}}
+		}
+		if err := validateBreakpoints(wf, "debug.breakpoints", executionInput.Debug.Breakpoints); err != nil {
+			return nil, err
+		}
+	}
+
+	if isDryRun(ctx) {
+		return s.dryRun().executeWorkflow(ctx, wf, executionInput, rerunOf)
+	}
+
+	return s.executeWorkflow(ctx, wf, executionInput, rerunOf)
 }
 
 func (s *ServiceImpl) loadWorkflow(ctx context.Context, workflowID string) (*Workflow, error) {
 	return s.repo.WorkflowWithNodesAndEdges(ctx, workflowID)
 }
 
-func (s *ServiceImpl) executeWorkflow(ctx context.Context, wf *Workflow, executionInput *ExecutionInput) (*ExecutionResult, error) {
-	executionResult := &ExecutionResult{
+func (s *ServiceImpl) executeWorkflow(ctx context.Context, wf *Workflow, executionInput *ExecutionInput, rerunOf *string) (*ExecutionResult, error) {
+	execution := &Execution{
+		WorkflowID: wf.ID,
+		RerunOf:    rerunOf,
+		Status:     ExecutionStatusRunning,
+		Input:      maps.Clone(executionInput.FormData),
+		DryRun:     isDryRun(ctx),
+		Mocks:      executionInput.Mocks,
 		ExecutedAt: time.Now(),
-		Steps:      make([]Step, 0),
+		State: ExecutionState{
+			Source:             startNode,
+			SourceHandleResult: false,
+			Variables:          executionInput.FormData,
+		},
+		// Add start node to execution steps
+		Steps: []Step{instantStep(startNode)},
 	}
 
-	input := executionInput.FormData
+	// Executions stay pinned to the published version they started on
+	if wf.Version > 0 {
+		version := wf.Version
+		execution.WorkflowVersion = &version
+	}
 
-	// Build optimized workflow structure for lookups
-	optimizedWf := s.buildOptimizedWorkflow(wf)
+	// Debugged executions pause before their breakpoints
+	if debug := executionInput.Debug; debug != nil {
+		execution.Debug = &Debug{Breakpoints: debug.Breakpoints, Stepping: debug.Stepping}
+		if execution.Debug.Breakpoints == nil {
+			execution.Debug.Breakpoints = []string{}
+		}
+	}
+
+	// Executions started by a subworkflow node are linked to the execution running it
+	if parentID := types.ExecutionID(ctx); parentID != "" {
+		execution.ParentExecutionID = &parentID
+	}
+
+	if key := executionInput.IdempotencyKey; key != "" && !execution.DryRun {
+		execution.IdempotencyKey = &key
+		execution.RequestHash = &executionInput.requestHash
+	}
+
+	err := s.repo.CreateExecution(ctx, execution)
+	// A concurrent request with the same idempotency key started its execution first
+	if errors.Is(err, ErrIdempotencyKeyUsed) {
+		result, err := s.replay(ctx, wf.ID, executionInput)
+		if err == nil && result == nil {
+			err = ErrIdempotencyKeyUsed
+		}
+		return result, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	notifyStep(ctx, execution.ID, execution.Steps[0])
+	s.publish(execution, Event{Type: EventExecutionStarted, Status: execution.Status})
+
+	return s.run(ctx, wf, execution)
+}
+
+// ResumeDue implements Service.
+func (s *ServiceImpl) ResumeDue(ctx context.Context) (int, error) {
+	now := time.Now()
+	executions, err := s.repo.ClaimDueExecutions(ctx, now, now.Add(claimLease), resumeBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range executions {
+		if err := s.resumeClaimed(ctx, &executions[i]); err != nil {
+			s.log.Error("problem resuming execution", slog.Any("ID", executions[i].ID), slog.Any("ERROR", err))
+		}
+	}
 
-	// Add start node to execution steps
-	executionResult.Steps = append(executionResult.Steps, Step{
-		NodeID: startNode,
-		Type:   startNode,
-		Label:  startNode,
-		Status: StepStatusCompleted,
-	})
+	return len(executions), nil
+}
 
-	executionState := &executionState{
-		source:             startNode,
-		sourceHandleResult: false,
+// Resume implements Service.
+func (s *ServiceImpl) Resume(ctx context.Context, executionID string, input *ResumeInput) (*ExecutionResult, error) {
+	execution, err := s.repo.Execution(ctx, executionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if execution.Status != ExecutionStatusWaiting || execution.WaitingFor == nil {
+		return nil, ErrExecutionNotWaiting
+	}
+
+	if input.FormData == nil {
+		input.FormData = map[string]any{}
+	}
+
+	output, err := s.validateResume(execution.WaitingFor, input)
+	if err != nil {
+		return nil, err
+	}
+
+	claimed, err := s.repo.ClaimWaitingExecution(ctx, executionID)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, ErrExecutionNotWaiting
+	}
+
+	// Route along the handle matching the decision
+	execution.State.SourceHandleResult = input.Decision == DecisionApprove
+
+	return s.resume(ctx, execution, output)
+}
+
+// resume continues a claimed execution from the node it was suspended on.
+// output, if any, is the input the node was waiting for and is recorded as the node's output.
+func (s *ServiceImpl) resume(ctx context.Context, execution *Execution, output map[string]any) (*ExecutionResult, error) {
+	if execution.State.Variables == nil {
+		execution.State.Variables = map[string]any{}
+	}
+
+	wf, err := s.executionWorkflow(ctx, execution)
+	if err != nil {
+		execution.Status = ExecutionStatusFailed
+		return s.save(ctx, execution, err)
 	}
 
+	// The suspended node ran before the execution was persisted, only its step is left to complete
+	if last := len(execution.Steps) - 1; last >= 0 && execution.Steps[last].Status == StepStatusWaiting {
+		step := &execution.Steps[last]
+		step.Status = StepStatusCompleted
+		step.Handle = handleTaken(wf, step.NodeID, execution.State.SourceHandleResult)
+		step.finish(time.Now())
+
+		if output != nil {
+			if step.Output == nil {
+				step.Output = map[string]any{}
+			}
+			maps.Copy(step.Output, output)
+			maps.Copy(execution.State.Variables, output)
+		}
+		notifyStep(ctx, execution.ID, *step)
+	}
+
+	execution.Status = ExecutionStatusRunning
+	execution.ResumeAt = nil
+	execution.WaitingFor = nil
+	s.publish(execution, Event{Type: EventExecutionResumed, Status: execution.Status})
+
+	return s.run(ctx, wf, execution)
+}
+
+// executionWorkflow loads the workflow an execution runs: the version it is pinned to,
+// or the current graph for executions started before the workflow was versioned.
+func (s *ServiceImpl) executionWorkflow(ctx context.Context, execution *Execution) (*Workflow, error) {
+	if execution.WorkflowVersion == nil {
+		return s.loadWorkflow(ctx, execution.WorkflowID)
+	}
+
+	version, err := s.repo.Version(ctx, execution.WorkflowID, *execution.WorkflowVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	return versionWorkflow(version), nil
+}
+
+// run executes the workflow from the execution state until the end is reached, a node fails, a node suspends the
+// execution, a breakpoint pauses it or it is cancelled. The execution is saved before run returns.
+func (s *ServiceImpl) run(ctx context.Context, wf *Workflow, execution *Execution) (*ExecutionResult, error) {
+	ctx = types.WithExecutionID(ctx, execution.ID)
+
+	ctx, span := startRunSpan(ctx, wf, execution)
+	defer endRunSpan(span, execution)
+
+	ctx, stop := s.watchCancellation(ctx, execution.ID)
+	defer stop()
+
+	// Build optimized workflow structure for lookups
+	optimizedWf := s.buildOptimizedWorkflow(wf)
+
+	state := &execution.State
+
 	nextNodeData := outData{}
-	for nextOptimized(optimizedWf, executionState, &nextNodeData) {
-		step, err := s.executeNode(ctx, nextNodeData.nextNode, input)
+	for nextOptimized(optimizedWf, state, &nextNodeData) {
+		if cancelRequested(ctx) {
+			return s.cancelled(ctx, execution)
+		}
+
+		if pauseBefore(execution, nextNodeData.nextNode.ID) {
+			execution.Status = ExecutionStatusPaused
+			return s.save(ctx, execution, nil)
+		}
+
+		s.publish(execution, Event{Type: EventNodeStarted, NodeID: nextNodeData.nextNode.ID})
+
+		step, suspension, err := s.executeNode(ctx, execution, nextNodeData.nextNode, state.Variables)
 		if err != nil {
-			executionResult.Status = ExecutionStatusFailed
-			return executionResult, err
+			execution.Steps = append(execution.Steps, *step)
+			notifyStep(ctx, execution.ID, *step)
+			s.publish(execution, Event{Type: EventNodeFailed, NodeID: step.NodeID, Step: step, Error: err.Error()})
+
+			// Nodes interrupted by the cancellation fail with it
+			if cancelRequested(ctx) {
+				return s.cancelled(ctx, execution)
+			}
+
+			execution.Status = ExecutionStatusFailed
+			return s.save(ctx, execution, err)
 		}
 
 		// Update input with node output
 		if step.Output != nil {
-			maps.Copy(input, step.Output)
+			maps.Copy(state.Variables, step.Output)
 		}
 
 		// Update execution state for next iteration
-		s.updateExecutionState(step, executionState)
+		s.updateExecutionState(step, state)
+		if suspension == nil {
+			step.Handle = handleTaken(wf, step.NodeID, state.SourceHandleResult)
+		}
+
+		execution.Steps = append(execution.Steps, *step)
+		notifyStep(ctx, execution.ID, *step)
+		s.publish(execution, Event{Type: EventNodeCompleted, NodeID: step.NodeID, Step: step})
 
-		executionResult.Steps = append(executionResult.Steps, *step)
+		if suspension != nil {
+			execution.Status = ExecutionStatusWaiting
+			execution.WaitingFor = suspension.WaitingFor
+			if !suspension.ResumeAt.IsZero() {
+				resumeAt := suspension.ResumeAt
+				execution.ResumeAt = &resumeAt
+			}
+			return s.save(ctx, execution, nil)
+		}
 	}
 
 	// Add end node to execution steps
-	executionResult.Steps = append(executionResult.Steps, Step{
-		NodeID: endNode,
-		Type:   endNode,
-		Label:  endNode,
-		Status: StepStatusCompleted,
-	})
-	executionResult.Status = ExecutionStatusCompleted
-	return executionResult, nil
+	end := instantStep(endNode)
+	execution.Steps = append(execution.Steps, end)
+	notifyStep(ctx, execution.ID, end)
+	execution.Status = ExecutionStatusCompleted
+
+	return s.save(ctx, execution, nil)
 }
 
-func (s *ServiceImpl) executeNode(ctx context.Context, node node.Node, input map[string]any) (*Step, error) {
-	s.log.Info("starting node execution",
-		slog.Any("node", node),
-		slog.Any("input", input),
-	)
+// save persists the execution and returns its result along with execErr, the error that ended the run.
+// The execution is saved even if ctx was cancelled, so a client disconnecting does not lose it.
+// Subscribers to the events of the execution are then told how the run ended.
+func (s *ServiceImpl) save(ctx context.Context, execution *Execution, execErr error) (*ExecutionResult, error) {
+	if finished(execution.Status) && execution.FinishedAt == nil {
+		finishedAt := time.Now()
+		execution.FinishedAt = &finishedAt
+		observeFinished(execution)
+	}
 
-	// Merge node metadata into input
-	if node.Data.Metadata != nil {
-		maps.Copy(input, node.Data.Metadata)
+	err := s.repo.UpdateExecution(context.WithoutCancel(ctx), execution)
+	s.publishStatus(execution, errors.Join(execErr, err))
+
+	if err != nil {
+		return execution.Result(), errors.Join(execErr, err)
 	}
 
+	return execution.Result(), execErr
+}
+
+// executeNode runs the node and returns its step, which is failed with the error if the node failed.
+// The returned suspension is non-nil when the node paused the execution, in which case the step is waiting.
+func (s *ServiceImpl) executeNode(ctx context.Context, execution *Execution, node node.Node, input map[string]any) (*Step, *types.Suspension, error) {
+	// The variables hold form data, so they are only logged when debugging, with sensitive keys redacted
+	s.log.InfoContext(ctx, "starting node execution", slog.String("nodeID", node.ID), slog.String("type", node.Kind))
+	s.log.DebugContext(ctx, "node input", slog.String("nodeID", node.ID), slog.Any("input", input))
+
+	ctx, span := startNodeSpan(ctx, execution, node)
+	defer span.End()
+
+	startedAt := time.Now()
+	step := &Step{
+		NodeID:      node.ID,
+		Type:        node.Kind,
+		Label:       node.Data.Label,
+		Status:      StepStatusCompleted,
+		Description: node.Data.Description,
+		Attempt:     attempt(execution, node.ID),
+		StartedAt:   &startedAt,
+	}
+
+	suspension, err := s.runNode(ctx, execution, node, input, step)
+	if err != nil {
+		step.Status = StepStatusFailed
+		step.Error = err.Error()
+	}
+	if step.Status != StepStatusWaiting {
+		step.finish(time.Now())
+	}
+	endNodeSpan(span, step, err)
+	observeStep(execution, step)
+
+	return step, suspension, err
+}
+
+// runNode runs the node, recording its inputs and outputs in the step.
+// In dry runs, mocked nodes are not run and executors with side effects are simulated.
+func (s *ServiceImpl) runNode(ctx context.Context, execution *Execution, node node.Node, input map[string]any, step *Step) (*types.Suspension, error) {
+	if mock, ok := execution.Mocks[node.ID]; ok {
+		step.Output = maps.Clone(mock)
+		step.Mocked = true
+		return nil, nil
+	}
+
+	// Merge node metadata into a copy of the input, so it does not leak into the variables of the next nodes
+	merged := make(map[string]any, len(input)+len(node.Data.Metadata))
+	maps.Copy(merged, input)
+	maps.Copy(merged, node.Data.Metadata)
+	input = merged
+	step.Input = s.resolveInputs(node, input)
+
 	// Get and validate executor
-	executor := s.nodeService.LoadNode(node.ID)
+	executor := s.nodeService.LoadNode(s.executorKind(node))
 	if executor == nil {
 		return nil, fmt.Errorf("executor not found with ID: %v", node.ID)
 	}
@@ -141,41 +437,136 @@ func (s *ServiceImpl) executeNode(ctx context.Context, node node.Node, input map
 		return nil, fmt.Errorf("failed to configure executor for node %v: %w", node.ID, err)
 	}
 
+	if simulator, ok := executor.(types.Simulator); ok && execution.DryRun {
+		simulation, err := simulator.Simulate(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to simulate node %v: %w", node.ID, err)
+		}
+
+		// Outputs only known once the side effect happened are mocked, so the nodes reading them can run
+		outputFields := s.extractOutputFields(node.Data.Metadata)
+		if simulation.Output == nil && len(outputFields) > 0 {
+			return nil, fmt.Errorf("failed to simulate node %v: outputs %v are only known once it runs, mock them", node.ID, outputFields)
+		}
+
+		step.Output = s.processNodeOutput(simulation.Output)
+		step.Simulation = simulation.Effect
+		return nil, nil
+	}
+
 	// Execute node
 	output, err := executor.Execute(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute node %v: %w", node.ID, err)
 	}
 
-	// Process output and create step
-	outputMap := s.processNodeOutput(output)
-	step := &Step{
-		NodeID:      node.ID,
-		Type:        node.Kind,
-		Label:       node.Data.Label,
-		Status:      StepStatusCompleted,
-		Description: node.Data.Description,
-		Output:      outputMap,
+	suspension, suspended := output.(*types.Suspension)
+	if suspended {
+		step.Output = suspension.Output
+
+		// Dry runs do not wait for timers, the step reports when the execution would have resumed
+		if execution.DryRun && suspension.WaitingFor == nil {
+			step.Simulation = map[string]any{"resumeAt": suspension.ResumeAt}
+			return nil, nil
+		}
+
+		step.Status = StepStatusWaiting
+		return suspension, nil
+	}
+
+	step.Output = s.processNodeOutput(output)
+
+	return nil, nil
+}
+
+// resolveInputs returns the values of the inputs the node declares, through its descriptor or its input variables.
+func (s *ServiceImpl) resolveInputs(node node.Node, input map[string]any) map[string]any {
+	resolved := map[string]any{}
+	for _, prop := range s.declaredInputs(node) {
+		if value, ok := input[prop.Name]; ok {
+			resolved[prop.Name] = value
+		}
+	}
+
+	return resolved
+}
+
+// attempt returns the attempt of the next run of the node: the execution retries its last step if it failed.
+func attempt(execution *Execution, nodeID string) int {
+	if last := len(execution.Steps) - 1; last >= 0 {
+		if step := execution.Steps[last]; step.NodeID == nodeID && step.Status == StepStatusFailed {
+			return max(step.Attempt, 1) + 1
+		}
+	}
+
+	return 1
+}
+
+// instantStep returns the completed step of the start or end node, which take no time.
+func instantStep(nodeID string) Step {
+	now := time.Now()
+	step := Step{
+		NodeID:    nodeID,
+		Type:      nodeID,
+		Label:     nodeID,
+		Status:    StepStatusCompleted,
+		Attempt:   1,
+		StartedAt: &now,
+	}
+	step.finish(now)
+
+	return step
+}
+
+// handleTaken returns the handle of the edge leaving the node along the routing result,
+// or nil if the edges leaving the node have no handle.
+func handleTaken(wf *Workflow, nodeID string, result bool) *string {
+	for _, e := range wf.Edges {
+		if e.Source == nodeID && e.SourceHandle != nil && handleResult(e.SourceHandle) == result {
+			handle := *e.SourceHandle
+			return &handle
+		}
 	}
 
-	return step, nil
+	return nil
+}
+
+// handleResult returns the routing result an edge handle matches. Handles that are not booleans match false.
+func handleResult(sourceHandle *string) bool {
+	if sourceHandle == nil {
+		return false
+	}
+
+	result, err := strconv.ParseBool(*sourceHandle)
+	return err == nil && result
+}
+
+// executorKind returns the registered node kind executing the node.
+// Nodes are matched by kind, falling back to the node ID for nodes whose ID names the executor,
+// such as the seeded "weather-api" node rendered with the "integration" kind.
+func (s *ServiceImpl) executorKind(node node.Node) string {
+	if s.nodeService.Has(node.Kind) {
+		return node.Kind
+	}
+
+	return node.ID
 }
 
 func (s *ServiceImpl) configureExecutor(executor types.NodeExecutor, node node.Node, input map[string]any) error {
 	executor.SetArgs(input)
 
+	// Handle output variables, before validation so executors can check them
+	outputFields := s.extractOutputFields(node.Data.Metadata)
+	if len(outputFields) > 0 {
+		executor.SetOutputFields(outputFields)
+	}
+
 	// Handle input variables
 	inputFields := s.extractInputFields(node.Data.Metadata)
 	if err := executor.ValidateAndParse(inputFields); err != nil {
 		return fmt.Errorf("validation failed: %w", err)
 	}
 
-	// Handle output variables
-	outputFields := s.extractOutputFields(node.Data.Metadata)
-	if len(outputFields) > 0 {
-		executor.SetOutputFields(outputFields)
-	}
-
 	return nil
 }
 
@@ -208,18 +599,18 @@ func (s *ServiceImpl) processNodeOutput(output any) map[string]any {
 	return nil
 }
 
-func (s *ServiceImpl) updateExecutionState(step *Step, state *executionState) {
+func (s *ServiceImpl) updateExecutionState(step *Step, state *ExecutionState) {
 	if step.Output != nil {
 		// Look for boolean output fields that affect routing
 		for _, val := range step.Output {
 			if result, ok := val.(bool); ok {
 				// Update state with boolean result for conditional routing
-				state.sourceHandleResult = result
+				state.SourceHandleResult = result
 				break // Take first boolean for now
 			}
 		}
 	}
-	state.source = step.NodeID
+	state.Source = step.NodeID
 }
 
 // buildOptimizedWorkflow creates optimized data structures for lookups
@@ -242,27 +633,20 @@ func (s *ServiceImpl) buildOptimizedWorkflow(wf *Workflow) *optimizedWorkflow {
 		}
 
 		// Parse handle as boolean
-		handle := false
-		if e.SourceHandle != nil {
-			if parsed, err := strconv.ParseBool(*e.SourceHandle); err == nil {
-				handle = parsed
-			}
-		}
-
-		optimized.edgesBySource[e.Source][handle] = e.Target
+		optimized.edgesBySource[e.Source][handleResult(e.SourceHandle)] = e.Target
 	}
 
 	return optimized
 }
 
 // nextOptimized performs lookup using pre-built indexes
-func nextOptimized(wf *optimizedWorkflow, in *inData, out *outData) bool {
-	sourceEdges, sourceExists := wf.edgesBySource[in.source]
+func nextOptimized(wf *optimizedWorkflow, in *ExecutionState, out *outData) bool {
+	sourceEdges, sourceExists := wf.edgesBySource[in.Source]
 	if !sourceExists {
 		return false
 	}
 
-	targetNodeID, edgeExists := sourceEdges[in.sourceHandleResult]
+	targetNodeID, edgeExists := sourceEdges[in.SourceHandleResult]
 	if !edgeExists {
 		return false
 	}
@@ -289,6 +673,9 @@ func NewService(repo Repository, nodeService *nodes.Service, log *slog.Logger) S
 	return &ServiceImpl{
 		repo:        repo,
 		nodeService: nodeService,
+		events:      eventbus.New[Event](),
 		log:         log,
+
+		eventsPollInterval: eventsPollInterval,
 	}
 }
@@ -0,0 +1,87 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"time"
+)
+
+// RunTimers resumes due executions every interval until ctx is done.
+// Due executions are claimed with row locks, so timers can run on every API instance.
+func RunTimers(ctx context.Context, svc Service, interval time.Duration, log *slog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+			resumeDue(ctx, svc, log)
+		}
+	}
+}
+
+// resumeDue resumes the executions due, recovering from panics so that timers keep running.
+func resumeDue(ctx context.Context, svc Service, log *slog.Logger) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			log.Error("panic resuming due executions", slog.Any("PANIC", rec), slog.String("stack", string(debug.Stack())))
+		}
+	}()
+
+	resumed, err := svc.ResumeDue(ctx)
+	if err != nil {
+		log.Error("problem resuming due executions", slog.Any("ERROR", err))
+		return
+	}
+	if resumed > 0 {
+		log.Info("resumed due executions", slog.Int("count", resumed))
+	}
+}
+
+// resumeClaimed resumes an execution claimed by ResumeDue, renewing its claim while it runs.
+// A panic while resuming fails the execution rather than leaving it claimed until the claim expires.
+func (s *ServiceImpl) resumeClaimed(ctx context.Context, execution *Execution) (err error) {
+	stop := s.renewClaim(ctx, execution.ID)
+	defer stop()
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("panic resuming execution: %v", rec)
+			s.log.Error("panic resuming execution", slog.Any("ID", execution.ID), slog.String("stack", string(debug.Stack())))
+
+			execution.Status = ExecutionStatusFailed
+			_, err = s.save(ctx, execution, err)
+		}
+	}()
+
+	_, err = s.resume(ctx, execution, nil)
+	return err
+}
+
+// renewClaim renews the claim of the execution until the returned function is called.
+func (s *ServiceImpl) renewClaim(ctx context.Context, executionID string) context.CancelFunc {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+
+	go func() {
+		ticker := time.NewTicker(claimLease / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.repo.RenewClaim(ctx, executionID, time.Now().Add(claimLease)); err != nil {
+					s.log.Warn("problem renewing execution claim", slog.Any("ID", executionID), slog.Any("ERROR", err))
+				}
+			}
+		}
+	}()
+
+	return cancel
+}
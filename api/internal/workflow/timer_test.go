@@ -0,0 +1,93 @@
+package workflow_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"workflow-code-test/api/internal/workflow"
+	_ "workflow-code-test/api/pkg/nodes/delay"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResumeDue(t *testing.T) {
+	repo := workflow.NewMemoryRepository()
+	addChain(t, repo, "delayed", newNode("delay", "delay", map[string]any{"duration": "2s"}), newNode("after", "after", nil))
+	kinds := map[string]*testKind{"after": {}}
+	svc := newService(t, repo, kinds)
+	ctx := context.Background()
+
+	result, err := svc.Execute(ctx, "delayed", &workflow.ExecutionInput{FormData: map[string]any{"city": "Sydney"}})
+	require.NoError(t, err)
+	require.Equal(t, workflow.ExecutionStatusWaiting, result.Status)
+	require.NotNil(t, result.ResumeAt)
+
+	// The delay, rounded down to the second, has not elapsed yet
+	resumed, err := svc.ResumeDue(ctx)
+	require.NoError(t, err)
+	require.Zero(t, resumed)
+	require.Empty(t, kinds["after"].Runs())
+
+	time.Sleep(time.Until(*result.ResumeAt))
+
+	resumed, err = svc.ResumeDue(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, resumed)
+
+	runs := kinds["after"].Runs()
+	require.Len(t, runs, 1)
+	require.Equal(t, "Sydney", runs[0]["city"])
+
+	execution, err := repo.Execution(ctx, result.ExecutionID)
+	require.NoError(t, err)
+	require.Equal(t, workflow.ExecutionStatusCompleted, execution.Status)
+	require.Nil(t, execution.ResumeAt)
+	require.Equal(t, []string{"start", "delay", "after", "end"}, stepIDs(execution.Steps))
+
+	// Resumed executions are not resumed again
+	resumed, err = svc.ResumeDue(ctx)
+	require.NoError(t, err)
+	require.Zero(t, resumed)
+	require.Len(t, kinds["after"].Runs(), 1)
+}
+
+// TestResumeDue_ExpiredClaim resumes an execution claimed by an instance that stopped before resuming it,
+// once the claim expired.
+func TestResumeDue_ExpiredClaim(t *testing.T) {
+	repo := workflow.NewMemoryRepository()
+	addChain(t, repo, "delayed", newNode("delay", "delay", map[string]any{"duration": "1ms"}), newNode("after", "after", nil))
+	kinds := map[string]*testKind{"after": {}}
+	svc := newService(t, repo, kinds)
+	ctx := context.Background()
+
+	result, err := svc.Execute(ctx, "delayed", &workflow.ExecutionInput{})
+	require.NoError(t, err)
+	time.Sleep(time.Until(*result.ResumeAt))
+
+	now := time.Now()
+	claimed, err := repo.ClaimDueExecutions(ctx, now, now.Add(20*time.Millisecond), 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	require.Equal(t, workflow.ExecutionStatusRunning, claimed[0].Status)
+
+	// The execution is not claimed again while its claim holds
+	claimed, err = repo.ClaimDueExecutions(ctx, now, now.Add(time.Minute), 10)
+	require.NoError(t, err)
+	require.Empty(t, claimed)
+
+	resumed, err := svc.ResumeDue(ctx)
+	require.NoError(t, err)
+	require.Zero(t, resumed)
+	require.Empty(t, kinds["after"].Runs())
+
+	time.Sleep(30 * time.Millisecond)
+
+	resumed, err = svc.ResumeDue(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, resumed)
+	require.Len(t, kinds["after"].Runs(), 1)
+
+	execution, err := repo.Execution(ctx, result.ExecutionID)
+	require.NoError(t, err)
+	require.Equal(t, workflow.ExecutionStatusCompleted, execution.Status)
+}
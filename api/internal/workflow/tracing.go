@@ -0,0 +1,50 @@
+package workflow
+
+import (
+	"context"
+	"workflow-code-test/api/internal/node"
+	"workflow-code-test/api/pkg/telemetry"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startRunSpan starts the span of a run of the execution, from when it starts or resumes until it ends or suspends.
+func startRunSpan(ctx context.Context, wf *Workflow, execution *Execution) (context.Context, trace.Span) {
+	return telemetry.Tracer().Start(ctx, "workflow.run", trace.WithAttributes(
+		telemetry.WorkflowID.String(wf.ID),
+		telemetry.WorkflowVersion.Int(wf.Version),
+		telemetry.ExecutionID.String(execution.ID),
+		telemetry.ExecutionDryRun.Bool(execution.DryRun),
+	))
+}
+
+// endRunSpan ends the span of a run with the status the execution ended the run with.
+func endRunSpan(span trace.Span, execution *Execution) {
+	span.SetAttributes(telemetry.ExecutionStatus.String(string(execution.Status)))
+	if execution.Status == ExecutionStatusFailed {
+		span.SetStatus(codes.Error, "execution failed")
+	}
+
+	span.End()
+}
+
+// startNodeSpan starts the span of a node run by the execution.
+func startNodeSpan(ctx context.Context, execution *Execution, node node.Node) (context.Context, trace.Span) {
+	return telemetry.Tracer().Start(ctx, "node.execute", trace.WithAttributes(
+		telemetry.WorkflowID.String(execution.WorkflowID),
+		telemetry.ExecutionID.String(execution.ID),
+		telemetry.NodeID.String(node.ID),
+		telemetry.NodeType.String(node.Kind),
+		telemetry.NodeAttempt.Int(attempt(execution, node.ID)),
+	))
+}
+
+// endNodeSpan records how the node ran on its span, which is ended by the caller.
+func endNodeSpan(span trace.Span, step *Step, err error) {
+	span.SetAttributes(telemetry.NodeStatus.String(string(step.Status)))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
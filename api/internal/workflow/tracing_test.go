@@ -0,0 +1,94 @@
+package workflow_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"workflow-code-test/api/internal/workflow"
+	"workflow-code-test/api/pkg/telemetry"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// record installs a tracer provider recording the spans ended during the test.
+func record(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	provider := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(provider) })
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	return recorder
+}
+
+func attributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	values := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		values[kv.Key] = kv.Value
+	}
+
+	return values
+}
+
+func TestTracing(t *testing.T) {
+	recorder := record(t)
+
+	repo := workflow.NewMemoryRepository()
+	addChain(t, repo, "traced", newNode("a", "work", nil), newNode("b", "broken", nil))
+	svc := newService(t, repo, map[string]*testKind{
+		"work": {},
+		"broken": {run: func(ctx context.Context, _ int, _ map[string]any) (any, error) {
+			return nil, errors.New("out of order")
+		}},
+	})
+
+	result, err := svc.Execute(context.Background(), "traced", &workflow.ExecutionInput{})
+	require.ErrorContains(t, err, "out of order")
+	require.Equal(t, workflow.ExecutionStatusFailed, result.Status)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	a, b, run := spans[0], spans[1], spans[2]
+
+	require.Equal(t, "workflow.run", run.Name())
+	require.False(t, run.Parent().IsValid())
+	require.Equal(t, codes.Error, run.Status().Code)
+	require.Equal(t, map[attribute.Key]attribute.Value{
+		telemetry.WorkflowID:      attribute.StringValue("traced"),
+		telemetry.WorkflowVersion: attribute.IntValue(1),
+		telemetry.ExecutionID:     attribute.StringValue(result.ExecutionID),
+		telemetry.ExecutionDryRun: attribute.BoolValue(false),
+		telemetry.ExecutionStatus: attribute.StringValue(string(workflow.ExecutionStatusFailed)),
+	}, attributes(run))
+
+	for _, node := range []sdktrace.ReadOnlySpan{a, b} {
+		require.Equal(t, "node.execute", node.Name())
+		require.Equal(t, run.SpanContext().TraceID(), node.SpanContext().TraceID())
+		require.Equal(t, run.SpanContext().SpanID(), node.Parent().SpanID())
+	}
+
+	require.Equal(t, codes.Unset, a.Status().Code)
+	require.Equal(t, map[attribute.Key]attribute.Value{
+		telemetry.WorkflowID:  attribute.StringValue("traced"),
+		telemetry.ExecutionID: attribute.StringValue(result.ExecutionID),
+		telemetry.NodeID:      attribute.StringValue("a"),
+		telemetry.NodeType:    attribute.StringValue("work"),
+		telemetry.NodeAttempt: attribute.IntValue(1),
+		telemetry.NodeStatus:  attribute.StringValue(string(workflow.StepStatusCompleted)),
+	}, attributes(a))
+
+	// The failed node records its error
+	require.Equal(t, codes.Error, b.Status().Code)
+	require.Contains(t, b.Status().Description, "out of order")
+	require.Equal(t, attribute.StringValue("b"), attributes(b)[telemetry.NodeID])
+	require.Equal(t, attribute.StringValue(string(workflow.StepStatusFailed)), attributes(b)[telemetry.NodeStatus])
+	require.Len(t, b.Events(), 1)
+	require.Equal(t, "exception", b.Events()[0].Name)
+}
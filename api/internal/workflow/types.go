@@ -1,27 +1,170 @@
 package workflow
 
 import (
+	"errors"
+	"fmt"
 	"time"
 	"workflow-code-test/api/internal/edge"
 	"workflow-code-test/api/internal/node"
+	"workflow-code-test/api/pkg/nodes/types"
 )
 
 // ExecutionStatus represents the status of an execution
 type ExecutionStatus string
 
 const (
+	ExecutionStatusRunning   ExecutionStatus = "running"
+	ExecutionStatusWaiting   ExecutionStatus = "waiting"
 	ExecutionStatusCompleted ExecutionStatus = "completed"
 	ExecutionStatusFailed    ExecutionStatus = "failed"
+	ExecutionStatusPaused    ExecutionStatus = "paused"
+	ExecutionStatusCancelled ExecutionStatus = "cancelled"
 )
 
 // ExecutionResult represents the immediate result of starting a workflow execution
 type ExecutionResult struct {
-	Status     ExecutionStatus `json:"status"`
-	ExecutedAt time.Time       `json:"executedAt"`
-	Steps      []Step          `json:"steps"`
+	ExecutionID       string          `json:"executionId"`
+	ParentExecutionID *string         `json:"parentExecutionId,omitempty"`
+	RerunOf           *string         `json:"rerunOf,omitempty"`
+	WorkflowVersion   *int            `json:"workflowVersion,omitempty"`
+	Status            ExecutionStatus `json:"status"`
+	DryRun            bool            `json:"dryRun,omitempty"`
+	ExecutedAt        time.Time       `json:"executedAt"`
+	FinishedAt        *time.Time      `json:"finishedAt,omitempty"`
+	DurationMs        *int64          `json:"durationMs,omitempty"`
+	ResumeAt          *time.Time      `json:"resumeAt,omitempty"`
+	WaitingFor        *types.Wait     `json:"waitingFor,omitempty"`
+	Debug             *Debug          `json:"debug,omitempty"`
+	CancelRequested   bool            `json:"cancelRequested,omitempty"`
+	Variables         map[string]any  `json:"variables,omitempty"`
+	Steps             []Step          `json:"steps"`
 }
 
+// Execution is a workflow execution persisted in the executions table.
+// Waiting executions are continued from their State once resumed.
+// ParentExecutionID links executions started by a subworkflow node to the execution running the node.
+// WorkflowVersion pins the execution to the published version it started on, so resuming it runs the same graph.
+// Dry runs are never persisted, Mocks holds the outputs replacing the nodes they mock.
+// Debug is set for executions started in debug mode, which pause before their breakpoints.
+// Input is the form data the execution started with, and RerunOf links executions replaying another execution to it.
+// CancelRequested is set when a running execution is asked to stop, which the API instance running it acts on.
+// IdempotencyKey and RequestHash identify the request that started the execution, if it had a key.
+// FinishedAt is set once the execution completed, failed or was cancelled.
+type Execution struct {
+	ID                string
+	WorkflowID        string
+	WorkflowVersion   *int
+	ParentExecutionID *string
+	RerunOf           *string
+	Status            ExecutionStatus
+	Input             map[string]any
+	State             ExecutionState
+	Steps             []Step
+	ResumeAt          *time.Time
+	WaitingFor        *types.Wait
+	Debug             *Debug
+	CancelRequested   bool
+	IdempotencyKey    *string
+	RequestHash       *string
+	DryRun            bool
+	Mocks             map[string]map[string]any
+	ExecutedAt        time.Time
+	FinishedAt        *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// Result returns the execution result reported to clients.
+// The duration of finished executions spans from when they started to when they finished, waits included.
+func (e *Execution) Result() *ExecutionResult {
+	var duration *int64
+	if e.FinishedAt != nil {
+		ms := e.FinishedAt.Sub(e.ExecutedAt).Milliseconds()
+		duration = &ms
+	}
+
+	return &ExecutionResult{
+		ExecutionID:       e.ID,
+		ParentExecutionID: e.ParentExecutionID,
+		RerunOf:           e.RerunOf,
+		WorkflowVersion:   e.WorkflowVersion,
+		Status:            e.Status,
+		DryRun:            e.DryRun,
+		ExecutedAt:        e.ExecutedAt,
+		FinishedAt:        e.FinishedAt,
+		DurationMs:        duration,
+		ResumeAt:          e.ResumeAt,
+		WaitingFor:        e.WaitingFor,
+		Debug:             e.Debug,
+		CancelRequested:   e.CancelRequested,
+		Variables:         e.State.Variables,
+		Steps:             e.Steps,
+	}
+}
+
+// ExecutionState is the position of an execution in the workflow graph along with its variables.
+// The next node to run is the target of the edge leaving Source through the SourceHandleResult handle.
+type ExecutionState struct {
+	Source             string         `json:"source"`
+	SourceHandleResult bool           `json:"sourceHandleResult"`
+	Variables          map[string]any `json:"variables"`
+}
+
+// ValidationError reports a request rejected on validation, such as execution input, a resume decision or a draft graph.
+// Nothing is run or stored for rejected requests.
+// Fields maps each offending field to a human readable message.
+type ValidationError struct {
+	Fields map[string]string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("validation failed for %d field(s)", len(e.Fields))
+}
+
+// ExecutionInput is the input starting an execution.
+// Mocks maps node IDs to the output the node returns in a dry run instead of running, only allowed in dry runs.
+// Debug starts the execution in debug mode, pausing before the breakpoints it lists.
 type ExecutionInput struct {
+	FormData map[string]any            `json:"formData"`
+	Mocks    map[string]map[string]any `json:"mocks,omitempty"`
+	Debug    *Debug                    `json:"debug,omitempty"`
+
+	// IdempotencyKey, if any, identifies the request starting the execution, which is only started once
+	// for requests repeating the key within idempotencyRetention.
+	IdempotencyKey string `json:"-"`
+	// requestHash is the hash of the input the execution starts with, set once the key is checked.
+	requestHash string
+}
+
+var (
+	ErrWorkflowNotFound    = errors.New("workflow not found")
+	ErrVersionNotFound     = errors.New("workflow version not found")
+	ErrExecutionNotFound   = errors.New("execution not found")
+	ErrExecutionNotWaiting = errors.New("execution is not waiting for input")
+	ErrExecutionNotPaused  = errors.New("execution is not paused")
+	ErrExecutionNotFailed  = errors.New("execution has not failed")
+	ErrExecutionFinished   = errors.New("execution has already finished")
+	ErrExecutionCancelled  = errors.New("execution cancelled")
+	ErrIdempotencyKeyUsed  = errors.New("idempotency key was already used")
+	ErrIdempotencyConflict = errors.New("idempotency key was used with a different request")
+)
+
+// Decision is the outcome submitted when resuming an execution waiting for approval.
+type Decision string
+
+const (
+	DecisionApprove Decision = "approve"
+	DecisionReject  Decision = "reject"
+)
+
+// ResumeInput is the input resuming an execution waiting for approval.
+type ResumeInput struct {
+	Decision Decision       `json:"decision"`
+	FormData map[string]any `json:"formData"`
+}
+
+// RerunInput is the input replaying an execution. FormData overrides the fields of the execution's original input.
+type RerunInput struct {
 	FormData map[string]any `json:"formData"`
 }
 
@@ -31,15 +174,37 @@ type StepStatus string
 const (
 	StepStatusCompleted StepStatus = "completed"
 	StepStatusFailed    StepStatus = "failed"
+	StepStatusWaiting   StepStatus = "waiting"
 )
 
+// Step is the run of a node. Input holds the values of the inputs the node declares, as the node received them,
+// and Handle the handle of the edge the execution left the node through, for nodes routing on their result.
+// Attempt counts the runs of the node, retrying a failed execution running its failed node again.
+// Waiting steps finish once the execution resumes, so their duration includes the wait.
 type Step struct {
 	NodeID      string         `json:"nodeId"`
 	Type        string         `json:"type"`
 	Label       string         `json:"label"`
 	Description string         `json:"description"`
 	Status      StepStatus     `json:"status"`
+	Input       map[string]any `json:"input,omitempty"`
 	Output      map[string]any `json:"output"`
+	Handle      *string        `json:"handle,omitempty"`
+	Error       string         `json:"error,omitempty"`
+	Attempt     int            `json:"attempt"`
+	StartedAt   *time.Time     `json:"startedAt,omitempty"`
+	FinishedAt  *time.Time     `json:"finishedAt,omitempty"`
+	DurationMs  int64          `json:"durationMs"`
+	Mocked      bool           `json:"mocked,omitempty"`
+	Simulation  map[string]any `json:"simulation,omitempty"`
+}
+
+// finish records the step finished at t.
+func (s *Step) finish(t time.Time) {
+	s.FinishedAt = &t
+	if s.StartedAt != nil {
+		s.DurationMs = t.Sub(*s.StartedAt).Milliseconds()
+	}
 }
 
 type EmailDraft struct {
@@ -50,11 +215,157 @@ type EmailDraft struct {
 	Timestamp time.Time `json:"timestamp"`
 }
 
+// Workflow is the published graph of a workflow. Version is the published version, 0 if none was published.
 type Workflow struct {
 	ID        string      `json:"id"`
 	Name      string      `json:"name"`
+	Version   int         `json:"version"`
 	Nodes     []node.Node `json:"nodes"`
 	Edges     []edge.Edge `json:"edges"`
 	CreatedAt time.Time   `json:"createdAt"`
 	UpdatedAt time.Time   `json:"updatedAt"`
 }
+
+const (
+	// DraftVersion selects the draft of a workflow where a version number is expected.
+	DraftVersion = 0
+	// LatestVersion selects the latest published version of a workflow where a version number is expected.
+	LatestVersion = -1
+)
+
+// VersionStatus represents the status of a workflow version
+type VersionStatus string
+
+const (
+	VersionStatusDraft     VersionStatus = "draft"
+	VersionStatusPublished VersionStatus = "published"
+)
+
+// Definition is the graph of a workflow version.
+type Definition struct {
+	Nodes []node.Node `json:"nodes"`
+	Edges []edge.Edge `json:"edges"`
+}
+
+// Version is a snapshot of a workflow graph. A workflow has at most one draft, edited in place, while published
+// versions are immutable and numbered from 1. The latest published version is the one executions run.
+type Version struct {
+	WorkflowID  string        `json:"workflowId"`
+	Version     int           `json:"version,omitempty"`
+	Status      VersionStatus `json:"status"`
+	Author      string        `json:"author"`
+	ChangeNote  string        `json:"changeNote"`
+	Definition  *Definition   `json:"definition,omitempty"`
+	PublishedAt *time.Time    `json:"publishedAt,omitempty"`
+	CreatedAt   time.Time     `json:"createdAt"`
+	UpdatedAt   time.Time     `json:"updatedAt"`
+}
+
+// DraftInput is the graph saved as the draft of a workflow.
+type DraftInput struct {
+	Author     string      `json:"author"`
+	ChangeNote string      `json:"changeNote"`
+	Nodes      []node.Node `json:"nodes"`
+	Edges      []edge.Edge `json:"edges"`
+}
+
+// PublishInput describes a version being published. Empty fields keep the values of the draft.
+type PublishInput struct {
+	Author     string `json:"author"`
+	ChangeNote string `json:"changeNote"`
+}
+
+// ChangeType represents how a node or an edge changed between two versions
+type ChangeType string
+
+const (
+	ChangeTypeAdded   ChangeType = "added"
+	ChangeTypeRemoved ChangeType = "removed"
+	ChangeTypeChanged ChangeType = "changed"
+)
+
+// Change is a node or an edge that differs between two versions.
+// Fields lists the changed fields of a changed node or edge, e.g. position or data.metadata.city.
+type Change struct {
+	ID     string     `json:"id"`
+	Type   ChangeType `json:"type"`
+	Fields []string   `json:"fields,omitempty"`
+}
+
+// VersionDiff lists the changes from one version of a workflow to another, sorted by ID.
+type VersionDiff struct {
+	From  int      `json:"from"`
+	To    int      `json:"to"`
+	Nodes []Change `json:"nodes"`
+	Edges []Change `json:"edges"`
+}
+
+// ImportInput describes a workflow being imported from a document.
+// WorkflowIDs maps the IDs of the workflows referenced by the document, such as the workflow run by a subworkflow
+// node, to the IDs of the same workflows in this environment. References to the imported workflow itself are
+// mapped to its new ID.
+type ImportInput struct {
+	Author      string            `json:"author"`
+	WorkflowIDs map[string]string `json:"workflowIds"`
+}
+
+// ImportResult is a workflow created from a document.
+// WorkflowIDs maps the workflow IDs referenced by the document to the IDs they were replaced with.
+type ImportResult struct {
+	WorkflowID  string            `json:"workflowId"`
+	Name        string            `json:"name"`
+	Version     *Version          `json:"version"`
+	WorkflowIDs map[string]string `json:"workflowIds"`
+}
+
+// Debug is the debugger of an execution started in debug mode. The execution pauses before running any node listed
+// in Breakpoints, or before every node while Stepping. PausedAt is the node a paused execution runs next.
+type Debug struct {
+	Breakpoints []string `json:"breakpoints"`
+	Stepping    bool     `json:"stepping,omitempty"`
+	PausedAt    string   `json:"pausedAt,omitempty"`
+}
+
+// DebugInput modifies a paused execution. Variables are merged into the execution's variables, a null value unsetting
+// the variable. Breakpoints, if set, replace the breakpoints of the execution.
+type DebugInput struct {
+	Variables   map[string]any `json:"variables"`
+	Breakpoints []string       `json:"breakpoints"`
+}
+
+// DebugSnapshot is the current state of an execution as inspected by a debugger: the variables in scope of the next
+// node, along with the steps that ran so far.
+type DebugSnapshot struct {
+	ExecutionID string          `json:"executionId"`
+	Status      ExecutionStatus `json:"status"`
+	Debug       *Debug          `json:"debug,omitempty"`
+	Variables   map[string]any  `json:"variables"`
+	Steps       []Step          `json:"steps"`
+}
+
+// EventType is the type of an execution event.
+type EventType string
+
+const (
+	EventExecutionStarted   EventType = "execution.started"
+	EventExecutionResumed   EventType = "execution.resumed"
+	EventExecutionSuspended EventType = "execution.suspended"
+	EventExecutionFinished  EventType = "execution.finished"
+	EventNodeStarted        EventType = "node.started"
+	EventNodeCompleted      EventType = "node.completed"
+	EventNodeFailed         EventType = "node.failed"
+)
+
+// Event reports the progress of an execution as it runs. Node events name the node, node.completed carrying its step,
+// which is waiting when the node suspended the execution. Execution events carry the status of the execution,
+// execution.suspended being published when it waits or is paused by the debugger.
+type Event struct {
+	Type        EventType       `json:"type"`
+	ExecutionID string          `json:"executionId"`
+	WorkflowID  string          `json:"workflowId"`
+	NodeID      string          `json:"nodeId,omitempty"`
+	Step        *Step           `json:"step,omitempty"`
+	Status      ExecutionStatus `json:"status,omitempty"`
+	Error       string          `json:"error,omitempty"`
+	Time        time.Time       `json:"time"`
+}
@@ -0,0 +1,288 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"workflow-code-test/api/internal/node"
+	"workflow-code-test/api/pkg/nodes/form"
+	"workflow-code-test/api/pkg/nodes/types"
+)
+
+// scope maps the variables set at a point of the execution to the type of value they hold.
+type scope map[string]types.ValueType
+
+// validateInput checks the form data against the field schema declared by the workflow's form node,
+// so bad input is rejected before any node runs.
+func (s *ServiceImpl) validateInput(wf *Workflow, formData map[string]any) error {
+	fieldErrors := map[string]string{}
+
+	for _, n := range wf.Nodes {
+		if s.executorKind(n) != form.Descriptor.Kind {
+			continue
+		}
+
+		fields, err := form.ParseFields(n.Data.Metadata[form.FieldsKey])
+		if err != nil {
+			return fmt.Errorf("invalid form schema for node %v: %w", n.ID, err)
+		}
+
+		maps.Copy(fieldErrors, form.Validate(fields, formData))
+	}
+
+	if len(fieldErrors) > 0 {
+		return &ValidationError{Fields: fieldErrors}
+	}
+
+	return nil
+}
+
+// validateResume checks the input resuming an execution against what it is waiting for.
+// Returns the outputs of the waiting node: the decision along with the requested form fields.
+func (s *ServiceImpl) validateResume(wait *types.Wait, input *ResumeInput) (map[string]any, error) {
+	fieldErrors := map[string]string{}
+
+	if input.Decision != DecisionApprove && input.Decision != DecisionReject {
+		fieldErrors["decision"] = fmt.Sprintf("must be %s or %s", DecisionApprove, DecisionReject)
+	}
+
+	fields, err := form.ParseFields(wait.Fields)
+	if err != nil {
+		return nil, fmt.Errorf("invalid form schema of the waiting node: %w", err)
+	}
+
+	maps.Copy(fieldErrors, form.Validate(fields, input.FormData))
+
+	if len(fieldErrors) > 0 {
+		return nil, &ValidationError{Fields: fieldErrors}
+	}
+
+	output := map[string]any{
+		wait.Decision: input.Decision == DecisionApprove,
+	}
+	for _, field := range fields {
+		if value, ok := input.FormData[field.Name]; ok {
+			output[field.Name] = value
+		}
+	}
+
+	return output, nil
+}
+
+// validateWorkflow type-checks the data flow between nodes before execution.
+// The variables each node sets are propagated along the edges, and every node's declared inputs
+// and metadata are checked against the variables that are set on all paths leading to it.
+// Errors are keyed by "<nodeID>.<variable>".
+func (s *ServiceImpl) validateWorkflow(wf *Workflow, formData map[string]any) error {
+	nodesByID := make(map[string]node.Node, len(wf.Nodes))
+	for _, n := range wf.Nodes {
+		nodesByID[n.ID] = n
+	}
+
+	successors := map[string][]string{}
+	predecessors := map[string][]string{}
+	for _, e := range wf.Edges {
+		successors[e.Source] = append(successors[e.Source], e.Target)
+		predecessors[e.Target] = append(predecessors[e.Target], e.Source)
+	}
+
+	initial := scope{}
+	for key, value := range formData {
+		initial[key] = types.TypeOf(value)
+	}
+
+	outScopes := map[string]scope{startNode: initial}
+	nodeErrors := map[string]map[string]string{}
+
+	queue := append([]string{}, successors[startNode]...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+
+		n, ok := nodesByID[id]
+		if !ok || id == endNode {
+			continue
+		}
+
+		out, errs := s.checkNode(n, intersectScopes(predecessors[id], outScopes))
+		nodeErrors[id] = errs
+
+		// Scopes only shrink once computed, so revisiting successors on change terminates on cycles too
+		if prev, visited := outScopes[id]; visited && maps.Equal(prev, out) {
+			continue
+		}
+		outScopes[id] = out
+		queue = append(queue, successors[id]...)
+	}
+
+	fieldErrors := map[string]string{}
+	for _, errs := range nodeErrors {
+		maps.Copy(fieldErrors, errs)
+	}
+
+	if len(fieldErrors) > 0 {
+		return &ValidationError{Fields: fieldErrors}
+	}
+
+	return nil
+}
+
+// checkNode checks a node's metadata and inputs against its descriptor and the variables in scope.
+// Returns the scope after the node ran along with the errors found.
+func (s *ServiceImpl) checkNode(n node.Node, in scope) (scope, map[string]string) {
+	errs := map[string]string{}
+
+	kind := s.executorKind(n)
+	descriptor, ok := s.nodeService.Descriptor(kind)
+	if !ok {
+		errs[n.ID] = "unknown node type"
+		return in, errs
+	}
+
+	for _, prop := range descriptor.Metadata {
+		value, ok := n.Data.Metadata[prop.Name]
+		if !ok || value == nil {
+			if prop.Required {
+				errs[n.ID+"."+prop.Name] = "is required"
+			}
+			continue
+		}
+
+		if got := types.TypeOf(value); !got.AssignableTo(prop.Type) {
+			errs[n.ID+"."+prop.Name] = fmt.Sprintf("must be %s, got %s", prop.Type, got)
+		}
+	}
+
+	// Node metadata is merged into the execution variables before the node runs
+	out := maps.Clone(in)
+	for key, value := range n.Data.Metadata {
+		out[key] = types.TypeOf(value)
+	}
+
+	for _, prop := range s.declaredInputs(n) {
+		got, ok := out[prop.Name]
+		if !ok {
+			if prop.Required {
+				errs[n.ID+"."+prop.Name] = "is not set by any upstream node"
+			}
+			continue
+		}
+
+		if !got.AssignableTo(prop.Type) {
+			errs[n.ID+"."+prop.Name] = fmt.Sprintf("expects %s, got %s", prop.Type, got)
+		}
+	}
+
+	outputTypes, err := s.outputTypes(s.nodeService.LoadNode(kind), descriptor, n.Data.Metadata)
+	if err != nil {
+		errs[n.ID] = err.Error()
+	}
+	maps.Copy(out, outputTypes)
+
+	return out, errs
+}
+
+// declaredInputs returns the inputs of the node: those of its descriptor followed by its input variables.
+func (s *ServiceImpl) declaredInputs(n node.Node) []types.Property {
+	descriptor, _ := s.nodeService.Descriptor(s.executorKind(n))
+
+	inputs := append([]types.Property{}, descriptor.Inputs...)
+	for _, name := range s.extractInputFields(n.Data.Metadata) {
+		inputs = append(inputs, types.Property{Name: name, Type: types.ValueTypeAny, Required: true})
+	}
+
+	return inputs
+}
+
+// outputTypes returns the type of each output variable configured on the node.
+func (s *ServiceImpl) outputTypes(executor types.NodeExecutor, descriptor types.Descriptor, metadata map[string]any) (scope, error) {
+	outputFields := s.extractOutputFields(metadata)
+	result := make(scope, len(outputFields))
+
+	resolver, ok := executor.(types.OutputResolver)
+	if !ok {
+		for i, field := range outputFields {
+			result[field] = descriptor.OutputType(i)
+		}
+		return result, nil
+	}
+
+	outputs, err := resolver.ResolveOutputs(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := make(scope, len(outputs))
+	for _, prop := range outputs {
+		resolved[prop.Name] = prop.Type
+	}
+
+	for _, field := range outputFields {
+		if t, ok := resolved[field]; ok {
+			result[field] = t
+		} else {
+			result[field] = types.ValueTypeAny
+		}
+	}
+
+	return result, nil
+}
+
+// intersectScopes returns the variables set by every already visited predecessor.
+// A variable holding different types on different paths is typed as any.
+func intersectScopes(predecessors []string, outScopes map[string]scope) scope {
+	var result scope
+
+	for _, id := range predecessors {
+		out, ok := outScopes[id]
+		if !ok {
+			continue
+		}
+
+		if result == nil {
+			result = maps.Clone(out)
+			continue
+		}
+
+		for name, t := range result {
+			other, ok := out[name]
+			switch {
+			case !ok:
+				delete(result, name)
+			case other != t:
+				result[name] = types.ValueTypeAny
+			}
+		}
+	}
+
+	if result == nil {
+		return scope{}
+	}
+
+	return result
+}
+
+// validateMocks checks that mocks are only given to dry runs and that every mocked node is a node of the workflow.
+func (s *ServiceImpl) validateMocks(ctx context.Context, wf *Workflow, mocks map[string]map[string]any) error {
+	if len(mocks) == 0 {
+		return nil
+	}
+
+	if !isDryRun(ctx) {
+		return &ValidationError{Fields: map[string]string{"mocks": "only allowed in dry runs"}}
+	}
+
+	fieldErrors := map[string]string{}
+	for nodeID := range mocks {
+		if !slices.ContainsFunc(wf.Nodes, func(n node.Node) bool { return n.ID == nodeID }) {
+			fieldErrors["mocks."+nodeID] = "is not a node of the workflow"
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return &ValidationError{Fields: fieldErrors}
+	}
+
+	return nil
+}
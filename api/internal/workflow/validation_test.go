@@ -0,0 +1,230 @@
+package workflow_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"workflow-code-test/api/internal/edge"
+	"workflow-code-test/api/internal/node"
+	"workflow-code-test/api/internal/workflow"
+	"workflow-code-test/api/pkg/nodes/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+// typedKinds returns kinds declaring the types of the variables they read and set.
+func typedKinds() map[string]*testKind {
+	output := func(t types.ValueType) *testKind {
+		return &testKind{descriptor: types.Descriptor{Outputs: []types.Property{{Name: "value", Type: t}}}}
+	}
+
+	return map[string]*testKind{
+		// set, count and check set their output variables to a string, a number and a boolean
+		"set":   output(types.ValueTypeString),
+		"count": output(types.ValueTypeNumber),
+		"check": output(types.ValueTypeBoolean),
+		// use reads the string x
+		"use": {descriptor: types.Descriptor{Inputs: []types.Property{{Name: "x", Type: types.ValueTypeString, Required: true}}}},
+		// limit is configured with a numeric threshold
+		"limit": {descriptor: types.Descriptor{Metadata: []types.Property{{Name: "threshold", Type: types.ValueTypeNumber, Required: true}}}},
+		"noop":  {},
+	}
+}
+
+// sets returns a node of the kind setting the variable x.
+func sets(id, kind string) node.Node {
+	return newNode(id, kind, map[string]any{"outputVariables": []any{"x"}})
+}
+
+func TestValidateWorkflow(t *testing.T) {
+	tests := []struct {
+		name           string
+		nodes          []node.Node
+		edges          [][2]string
+		formData       map[string]any
+		expectedFields map[string]string
+	}{
+		{
+			name:  "variable set upstream",
+			nodes: []node.Node{sets("a", "set"), newNode("b", "use", nil)},
+			edges: [][2]string{{"start", "a"}, {"a", "b"}, {"b", "end"}},
+		},
+		{
+			name:           "variable never set",
+			nodes:          []node.Node{newNode("a", "noop", nil), newNode("b", "use", nil)},
+			edges:          [][2]string{{"start", "a"}, {"a", "b"}, {"b", "end"}},
+			expectedFields: map[string]string{"b.x": "is not set by any upstream node"},
+		},
+		{
+			name:           "variable set downstream",
+			nodes:          []node.Node{newNode("a", "use", nil), sets("b", "set")},
+			edges:          [][2]string{{"start", "a"}, {"a", "b"}, {"b", "end"}},
+			expectedFields: map[string]string{"a.x": "is not set by any upstream node"},
+		},
+		{
+			name:     "variable set by the form data",
+			nodes:    []node.Node{newNode("a", "use", nil)},
+			edges:    [][2]string{{"start", "a"}, {"a", "end"}},
+			formData: map[string]any{"x": "Sydney"},
+		},
+		{
+			name:           "form data of another type",
+			nodes:          []node.Node{newNode("a", "use", nil)},
+			edges:          [][2]string{{"start", "a"}, {"a", "end"}},
+			formData:       map[string]any{"x": 25.0},
+			expectedFields: map[string]string{"a.x": "expects string, got number"},
+		},
+		{
+			name:           "output of another type",
+			nodes:          []node.Node{sets("a", "count"), newNode("b", "use", nil)},
+			edges:          [][2]string{{"start", "a"}, {"a", "b"}, {"b", "end"}},
+			expectedFields: map[string]string{"b.x": "expects string, got number"},
+		},
+		{
+			name:  "variable set by the node's own metadata",
+			nodes: []node.Node{newNode("a", "use", map[string]any{"x": "Perth"})},
+			edges: [][2]string{{"start", "a"}, {"a", "end"}},
+		},
+		{
+			name:  "input variable set upstream",
+			nodes: []node.Node{sets("a", "count"), newNode("b", "noop", map[string]any{"inputVariables": []any{"x"}})},
+			edges: [][2]string{{"start", "a"}, {"a", "b"}, {"b", "end"}},
+		},
+		{
+			name: "join of branches all setting the variable",
+			nodes: []node.Node{
+				newNode("branch", "check", map[string]any{"outputVariables": []any{"ok"}}),
+				sets("yes", "set"), sets("no", "set"),
+				newNode("join", "use", nil),
+			},
+			edges: [][2]string{{"start", "branch"}, {"branch", "yes"}, {"branch", "no"}, {"yes", "join"}, {"no", "join"}, {"join", "end"}},
+		},
+		{
+			name: "join of a branch not setting the variable",
+			nodes: []node.Node{
+				newNode("branch", "check", map[string]any{"outputVariables": []any{"ok"}}),
+				sets("yes", "set"), newNode("no", "noop", nil),
+				newNode("join", "use", nil),
+			},
+			edges:          [][2]string{{"start", "branch"}, {"branch", "yes"}, {"branch", "no"}, {"yes", "join"}, {"no", "join"}, {"join", "end"}},
+			expectedFields: map[string]string{"join.x": "is not set by any upstream node"},
+		},
+		{
+			name: "join of a branch skipping straight to it",
+			nodes: []node.Node{
+				newNode("branch", "check", map[string]any{"outputVariables": []any{"ok"}}),
+				sets("yes", "set"),
+				newNode("join", "use", nil),
+			},
+			edges:          [][2]string{{"start", "branch"}, {"branch", "yes"}, {"branch", "join"}, {"yes", "join"}, {"join", "end"}},
+			expectedFields: map[string]string{"join.x": "is not set by any upstream node"},
+		},
+		{
+			// Branches setting the variable to different types make it any, which every input accepts
+			name: "join of branches setting the variable to different types",
+			nodes: []node.Node{
+				newNode("branch", "check", map[string]any{"outputVariables": []any{"ok"}}),
+				sets("yes", "set"), sets("no", "count"),
+				newNode("join", "use", nil),
+			},
+			edges: [][2]string{{"start", "branch"}, {"branch", "yes"}, {"branch", "no"}, {"yes", "join"}, {"no", "join"}, {"join", "end"}},
+		},
+		{
+			name: "loop reading a variable set before it",
+			nodes: []node.Node{
+				sets("a", "set"),
+				newNode("loop", "use", nil),
+				newNode("again", "check", map[string]any{"outputVariables": []any{"ok"}}),
+			},
+			edges: [][2]string{{"start", "a"}, {"a", "loop"}, {"loop", "again"}, {"again", "loop"}, {"again", "end"}},
+		},
+		{
+			// The variable is only set from the second iteration on
+			name: "loop reading a variable set within it",
+			nodes: []node.Node{
+				newNode("loop", "use", nil),
+				sets("a", "set"),
+				newNode("again", "check", map[string]any{"outputVariables": []any{"ok"}}),
+			},
+			edges:          [][2]string{{"start", "loop"}, {"loop", "a"}, {"a", "again"}, {"again", "loop"}, {"again", "end"}},
+			expectedFields: map[string]string{"loop.x": "is not set by any upstream node"},
+		},
+		{
+			name: "variable read after the loop setting it",
+			nodes: []node.Node{
+				sets("loop", "set"),
+				newNode("again", "check", map[string]any{"outputVariables": []any{"ok"}}),
+				newNode("after", "use", nil),
+			},
+			edges: [][2]string{{"start", "loop"}, {"loop", "again"}, {"again", "loop"}, {"again", "after"}, {"after", "end"}},
+		},
+		{
+			name:           "missing metadata",
+			nodes:          []node.Node{newNode("a", "limit", nil)},
+			edges:          [][2]string{{"start", "a"}, {"a", "end"}},
+			expectedFields: map[string]string{"a.threshold": "is required"},
+		},
+		{
+			name:           "null metadata",
+			nodes:          []node.Node{newNode("a", "limit", map[string]any{"threshold": nil})},
+			edges:          [][2]string{{"start", "a"}, {"a", "end"}},
+			expectedFields: map[string]string{"a.threshold": "is required"},
+		},
+		{
+			name:           "metadata of another type",
+			nodes:          []node.Node{newNode("a", "limit", map[string]any{"threshold": "high"})},
+			edges:          [][2]string{{"start", "a"}, {"a", "end"}},
+			expectedFields: map[string]string{"a.threshold": "must be number, got string"},
+		},
+		{
+			name:           "unknown kind",
+			nodes:          []node.Node{newNode("a", "mystery", nil)},
+			edges:          [][2]string{{"start", "a"}, {"a", "end"}},
+			expectedFields: map[string]string{"a": "unknown node type"},
+		},
+		{
+			name:  "errors of several nodes",
+			nodes: []node.Node{newNode("a", "use", nil), newNode("b", "limit", map[string]any{"threshold": true}), newNode("c", "use", nil)},
+			edges: [][2]string{{"start", "a"}, {"a", "b"}, {"b", "c"}, {"c", "end"}},
+			expectedFields: map[string]string{
+				"a.x":         "is not set by any upstream node",
+				"b.threshold": "must be number, got boolean",
+				"c.x":         "is not set by any upstream node",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := workflow.NewMemoryRepository()
+			wf := &workflow.Workflow{
+				ID:    "typed",
+				Name:  "typed",
+				Nodes: append(append([]node.Node{newNode("start", "start", nil)}, tt.nodes...), newNode("end", "end", nil)),
+			}
+			for _, e := range tt.edges {
+				wf.Edges = append(wf.Edges, edge.Edge{ID: e[0] + "-" + e[1], Source: e[0], Target: e[1]})
+			}
+			require.NoError(t, repo.AddWorkflow(wf))
+
+			kinds := typedKinds()
+			svc := newService(t, repo, kinds)
+
+			_, err := svc.Execute(context.Background(), "typed", &workflow.ExecutionInput{FormData: tt.formData})
+
+			var validationErr *workflow.ValidationError
+			if tt.expectedFields == nil {
+				require.False(t, errors.As(err, &validationErr), "unexpected validation error: %v", err)
+				return
+			}
+
+			require.ErrorAs(t, err, &validationErr)
+			require.Equal(t, tt.expectedFields, validationErr.Fields)
+
+			// Invalid workflows are rejected before any node runs
+			for _, kind := range kinds {
+				require.Empty(t, kind.Runs())
+			}
+		})
+	}
+}
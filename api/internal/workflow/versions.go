@@ -0,0 +1,285 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"workflow-code-test/api/internal/edge"
+	"workflow-code-test/api/internal/node"
+)
+
+// edgeKindSmoothStep is the only edge kind stored by the edge_kind type, and the default of new edges.
+const edgeKindSmoothStep = "smoothstep"
+
+// Versions implements Service.
+func (s *ServiceImpl) Versions(ctx context.Context, workflowID string) ([]Version, error) {
+	return s.repo.Versions(ctx, workflowID)
+}
+
+// Version implements Service.
+func (s *ServiceImpl) Version(ctx context.Context, workflowID string, version int) (*Version, error) {
+	return s.repo.Version(ctx, workflowID, version)
+}
+
+// SaveDraft implements Service.
+func (s *ServiceImpl) SaveDraft(ctx context.Context, workflowID string, input *DraftInput) (*Version, error) {
+	if err := s.validateDefinition(input); err != nil {
+		return nil, err
+	}
+
+	return s.repo.SaveDraft(ctx, workflowID, input)
+}
+
+// Publish implements Service.
+func (s *ServiceImpl) Publish(ctx context.Context, workflowID string, input *PublishInput) (*Version, error) {
+	return s.repo.PublishDraft(ctx, workflowID, input)
+}
+
+// Rollback implements Service.
+func (s *ServiceImpl) Rollback(ctx context.Context, workflowID string, version int, input *PublishInput) (*Version, error) {
+	if version == DraftVersion {
+		return nil, ErrVersionNotFound
+	}
+
+	target, err := s.repo.Version(ctx, workflowID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.ChangeNote == "" {
+		input.ChangeNote = fmt.Sprintf("Rollback to version %d", version)
+	}
+
+	return s.repo.PublishVersion(ctx, workflowID, target.Definition, input)
+}
+
+// Diff implements Service.
+func (s *ServiceImpl) Diff(ctx context.Context, workflowID string, from int, to int) (*VersionDiff, error) {
+	var err error
+	if from, err = s.resolveVersion(ctx, workflowID, from); err != nil {
+		return nil, err
+	}
+	if to, err = s.resolveVersion(ctx, workflowID, to); err != nil {
+		return nil, err
+	}
+
+	fromVersion, err := s.repo.Version(ctx, workflowID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	toVersion, err := s.repo.Version(ctx, workflowID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VersionDiff{
+		From:  from,
+		To:    to,
+		Nodes: diffNodes(fromVersion.Definition.Nodes, toVersion.Definition.Nodes),
+		Edges: diffEdges(fromVersion.Definition.Edges, toVersion.Definition.Edges),
+	}, nil
+}
+
+// resolveVersion returns the number of the latest published version of the workflow for LatestVersion, and the
+// version otherwise. Returns ErrVersionNotFound if no version was published.
+func (s *ServiceImpl) resolveVersion(ctx context.Context, workflowID string, version int) (int, error) {
+	if version != LatestVersion {
+		return version, nil
+	}
+
+	wf, err := s.repo.WorkflowWithNodesAndEdges(ctx, workflowID)
+	if err != nil {
+		return 0, err
+	}
+	if wf.Version == 0 {
+		return 0, ErrVersionNotFound
+	}
+
+	return wf.Version, nil
+}
+
+// versionWorkflow returns the workflow running the graph of a published version.
+func versionWorkflow(version *Version) *Workflow {
+	return &Workflow{
+		ID:        version.WorkflowID,
+		Version:   version.Version,
+		Nodes:     version.Definition.Nodes,
+		Edges:     version.Definition.Edges,
+		CreatedAt: version.CreatedAt,
+		UpdatedAt: version.UpdatedAt,
+	}
+}
+
+// validateDefinition checks that the graph of a draft can be stored and run: node IDs are unique, the start node
+// exists, every node kind is available and edges link existing nodes. Edges without a kind are given the default one.
+// Errors are keyed by "nodes.<nodeID>" and "edges.<edgeID>".
+func (s *ServiceImpl) validateDefinition(input *DraftInput) error {
+	fieldErrors := map[string]string{}
+
+	nodesByID := make(map[string]node.Node, len(input.Nodes))
+	for i, n := range input.Nodes {
+		if n.ID == "" {
+			fieldErrors[fmt.Sprintf("nodes[%d].id", i)] = "is required"
+			continue
+		}
+		if _, exists := nodesByID[n.ID]; exists {
+			fieldErrors["nodes."+n.ID] = "is duplicated"
+			continue
+		}
+		nodesByID[n.ID] = n
+
+		if n.ID != startNode && n.ID != endNode && !s.nodeService.Has(s.executorKind(n)) {
+			fieldErrors["nodes."+n.ID] = "unknown node type"
+		}
+	}
+
+	if _, ok := nodesByID[startNode]; !ok {
+		fieldErrors["nodes."+startNode] = "is required"
+	}
+
+	edgeIDs := map[string]bool{}
+	for i := range input.Edges {
+		e := &input.Edges[i]
+		e.ID = fmt.Sprintf("%s-%s", e.Source, e.Target)
+
+		key := "edges." + e.ID
+		if edgeIDs[e.ID] {
+			fieldErrors[key] = "is duplicated"
+			continue
+		}
+		edgeIDs[e.ID] = true
+
+		if e.Kind == "" {
+			e.Kind = edgeKindSmoothStep
+		}
+
+		_, sourceOK := nodesByID[e.Source]
+		_, targetOK := nodesByID[e.Target]
+
+		switch {
+		case !sourceOK:
+			fieldErrors[key] = fmt.Sprintf("source %q is not a node", e.Source)
+		case !targetOK:
+			fieldErrors[key] = fmt.Sprintf("target %q is not a node", e.Target)
+		case e.Kind != edgeKindSmoothStep:
+			fieldErrors[key] = fmt.Sprintf("type must be %s", edgeKindSmoothStep)
+		case e.SourceHandle != nil && !isBool(*e.SourceHandle):
+			fieldErrors[key] = "sourceHandle must be true or false"
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return &ValidationError{Fields: fieldErrors}
+	}
+
+	return nil
+}
+
+func isBool(s string) bool {
+	_, err := strconv.ParseBool(s)
+	return err == nil
+}
+
+// diffNodes lists the nodes added, removed or changed from one version to another.
+func diffNodes(from, to []node.Node) []Change {
+	return diff(from, to, func(n node.Node) string { return n.ID }, func(a, b node.Node) []string {
+		var fields []string
+		if a.Kind != b.Kind {
+			fields = append(fields, "type")
+		}
+		if a.Position != b.Position {
+			fields = append(fields, "position")
+		}
+		if a.Data.Label != b.Data.Label {
+			fields = append(fields, "data.label")
+		}
+		if a.Data.Description != b.Data.Description {
+			fields = append(fields, "data.description")
+		}
+
+		keys := maps.Clone(a.Data.Metadata)
+		if keys == nil {
+			keys = map[string]any{}
+		}
+		maps.Copy(keys, b.Data.Metadata)
+
+		for _, key := range slices.Sorted(maps.Keys(keys)) {
+			if !reflect.DeepEqual(a.Data.Metadata[key], b.Data.Metadata[key]) {
+				fields = append(fields, "data.metadata."+key)
+			}
+		}
+
+		return fields
+	})
+}
+
+// diffEdges lists the edges added, removed or changed from one version to another.
+func diffEdges(from, to []edge.Edge) []Change {
+	return diff(from, to, func(e edge.Edge) string { return e.ID }, func(a, b edge.Edge) []string {
+		var fields []string
+		if a.Kind != b.Kind {
+			fields = append(fields, "type")
+		}
+		if a.Animated != b.Animated {
+			fields = append(fields, "animated")
+		}
+		if !reflect.DeepEqual(a.SourceHandle, b.SourceHandle) {
+			fields = append(fields, "sourceHandle")
+		}
+		if a.Label != b.Label {
+			fields = append(fields, "label")
+		}
+		if !reflect.DeepEqual(a.Style, b.Style) {
+			fields = append(fields, "style")
+		}
+		if !reflect.DeepEqual(a.LabelStyle, b.LabelStyle) {
+			fields = append(fields, "labelStyle")
+		}
+
+		return fields
+	})
+}
+
+// diff matches the elements of two versions by ID and returns their changes sorted by ID.
+// changed returns the fields that differ between two elements with the same ID.
+func diff[T any](from, to []T, id func(T) string, changed func(a, b T) []string) []Change {
+	fromByID := make(map[string]T, len(from))
+	for _, v := range from {
+		fromByID[id(v)] = v
+	}
+
+	toByID := make(map[string]T, len(to))
+	for _, v := range to {
+		toByID[id(v)] = v
+	}
+
+	changes := []Change{}
+	for _, key := range slices.Sorted(maps.Keys(fromByID)) {
+		if _, ok := toByID[key]; !ok {
+			changes = append(changes, Change{ID: key, Type: ChangeTypeRemoved})
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(toByID)) {
+		a, ok := fromByID[key]
+		if !ok {
+			changes = append(changes, Change{ID: key, Type: ChangeTypeAdded})
+			continue
+		}
+
+		if fields := changed(a, toByID[key]); len(fields) > 0 {
+			changes = append(changes, Change{ID: key, Type: ChangeTypeChanged, Fields: fields})
+		}
+	}
+
+	slices.SortStableFunc(changes, func(a, b Change) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return changes
+}
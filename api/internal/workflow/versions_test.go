@@ -0,0 +1,244 @@
+package workflow_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"workflow-code-test/api/internal/edge"
+	"workflow-code-test/api/internal/node"
+	"workflow-code-test/api/internal/workflow"
+
+	"github.com/stretchr/testify/require"
+)
+
+// baseDraft returns the graph start -> a -> b -> end.
+func baseDraft() *workflow.DraftInput {
+	return &workflow.DraftInput{
+		Nodes: []node.Node{
+			newNode("start", "start", nil),
+			newNode("a", "work", map[string]any{"city": "Sydney"}),
+			newNode("b", "work", nil),
+			newNode("end", "end", nil),
+		},
+		Edges: []edge.Edge{
+			{Source: "start", Target: "a"},
+			{Source: "a", Target: "b"},
+			{Source: "b", Target: "end"},
+		},
+	}
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name          string
+		edit          func(draft *workflow.DraftInput)
+		expectedNodes []workflow.Change
+		expectedEdges []workflow.Change
+	}{
+		{
+			name:          "unchanged",
+			edit:          func(draft *workflow.DraftInput) {},
+			expectedNodes: []workflow.Change{},
+			expectedEdges: []workflow.Change{},
+		},
+		{
+			name: "added node and edge",
+			edit: func(draft *workflow.DraftInput) {
+				draft.Nodes = append(draft.Nodes, newNode("c", "work", nil))
+				draft.Edges = append(draft.Edges, edge.Edge{Source: "b", Target: "c"})
+			},
+			expectedNodes: []workflow.Change{{ID: "c", Type: workflow.ChangeTypeAdded}},
+			expectedEdges: []workflow.Change{{ID: "b-c", Type: workflow.ChangeTypeAdded}},
+		},
+		{
+			name: "removed node and its edges",
+			edit: func(draft *workflow.DraftInput) {
+				draft.Nodes = slices.DeleteFunc(draft.Nodes, func(n node.Node) bool { return n.ID == "b" })
+				draft.Edges = []edge.Edge{{Source: "start", Target: "a"}, {Source: "a", Target: "end"}}
+			},
+			expectedNodes: []workflow.Change{{ID: "b", Type: workflow.ChangeTypeRemoved}},
+			expectedEdges: []workflow.Change{
+				{ID: "a-b", Type: workflow.ChangeTypeRemoved},
+				{ID: "a-end", Type: workflow.ChangeTypeAdded},
+				{ID: "b-end", Type: workflow.ChangeTypeRemoved},
+			},
+		},
+		{
+			name: "changed node",
+			edit: func(draft *workflow.DraftInput) {
+				a := &draft.Nodes[1]
+				a.Position = node.Position{X: 100, Y: 50}
+				a.Data.Label = "A"
+				a.Data.Metadata = map[string]any{"city": "Perth", "units": "metric"}
+			},
+			expectedNodes: []workflow.Change{{
+				ID:     "a",
+				Type:   workflow.ChangeTypeChanged,
+				Fields: []string{"position", "data.label", "data.metadata.city", "data.metadata.units"},
+			}},
+			expectedEdges: []workflow.Change{},
+		},
+		{
+			name: "changed edge",
+			edit: func(draft *workflow.DraftInput) {
+				handle := "true"
+				draft.Edges[1].SourceHandle = &handle
+				draft.Edges[1].Label = "Next"
+				draft.Edges[1].Animated = true
+			},
+			expectedNodes: []workflow.Change{},
+			expectedEdges: []workflow.Change{{
+				ID:     "a-b",
+				Type:   workflow.ChangeTypeChanged,
+				Fields: []string{"animated", "sourceHandle", "label"},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := workflow.NewMemoryRepository()
+			addChain(t, repo, "versioned")
+			svc := newService(t, repo, map[string]*testKind{"work": {}})
+			ctx := context.Background()
+
+			_, err := svc.SaveDraft(ctx, "versioned", baseDraft())
+			require.NoError(t, err)
+			published, err := svc.Publish(ctx, "versioned", &workflow.PublishInput{})
+			require.NoError(t, err)
+
+			draft := baseDraft()
+			tt.edit(draft)
+			_, err = svc.SaveDraft(ctx, "versioned", draft)
+			require.NoError(t, err)
+
+			diff, err := svc.Diff(ctx, "versioned", published.Version, workflow.DraftVersion)
+			require.NoError(t, err)
+			require.Equal(t, published.Version, diff.From)
+			require.Equal(t, workflow.DraftVersion, diff.To)
+			require.Equal(t, tt.expectedNodes, diff.Nodes)
+			require.Equal(t, tt.expectedEdges, diff.Edges)
+
+			// Once published, the draft compares the same
+			next, err := svc.Publish(ctx, "versioned", &workflow.PublishInput{})
+			require.NoError(t, err)
+
+			diff, err = svc.Diff(ctx, "versioned", published.Version, next.Version)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedNodes, diff.Nodes)
+			require.Equal(t, tt.expectedEdges, diff.Edges)
+		})
+	}
+
+	t.Run("latest published version", func(t *testing.T) {
+		repo := workflow.NewMemoryRepository()
+		addChain(t, repo, "versioned")
+		svc := newService(t, repo, map[string]*testKind{"work": {}})
+		ctx := context.Background()
+
+		_, err := svc.SaveDraft(ctx, "versioned", baseDraft())
+		require.NoError(t, err)
+		published, err := svc.Publish(ctx, "versioned", &workflow.PublishInput{})
+		require.NoError(t, err)
+
+		draft := baseDraft()
+		draft.Nodes = append(draft.Nodes, newNode("c", "work", nil))
+		_, err = svc.SaveDraft(ctx, "versioned", draft)
+		require.NoError(t, err)
+
+		diff, err := svc.Diff(ctx, "versioned", workflow.LatestVersion, workflow.DraftVersion)
+		require.NoError(t, err)
+		require.Equal(t, published.Version, diff.From)
+		require.Equal(t, []workflow.Change{{ID: "c", Type: workflow.ChangeTypeAdded}}, diff.Nodes)
+	})
+
+	t.Run("missing version", func(t *testing.T) {
+		repo := workflow.NewMemoryRepository()
+		addChain(t, repo, "versioned")
+		svc := newService(t, repo, nil)
+
+		_, err := svc.Diff(context.Background(), "versioned", 1, 2)
+		require.ErrorIs(t, err, workflow.ErrVersionNotFound)
+	})
+}
+
+func TestPublishAndRollback(t *testing.T) {
+	repo := workflow.NewMemoryRepository()
+	addChain(t, repo, "versioned", newNode("work", "work", map[string]any{"city": "Sydney"}))
+	kinds := map[string]*testKind{"work": {}}
+	svc := newService(t, repo, kinds)
+	ctx := context.Background()
+
+	original, err := svc.Version(ctx, "versioned", 1)
+	require.NoError(t, err)
+
+	t.Run("publishing without a draft", func(t *testing.T) {
+		_, err := svc.Publish(ctx, "versioned", &workflow.PublishInput{})
+		require.ErrorIs(t, err, workflow.ErrVersionNotFound)
+	})
+
+	t.Run("invalid draft", func(t *testing.T) {
+		draft := baseDraft()
+		draft.Nodes = append(draft.Nodes, newNode("unknown", "unknown", nil))
+
+		_, err := svc.SaveDraft(ctx, "versioned", draft)
+		var validationErr *workflow.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		require.Equal(t, map[string]string{"nodes.unknown": "unknown node type"}, validationErr.Fields)
+	})
+
+	draft := baseDraft()
+	draft.Author = "Jane"
+	draft.ChangeNote = "Split the work"
+	_, err = svc.SaveDraft(ctx, "versioned", draft)
+	require.NoError(t, err)
+
+	published, err := svc.Publish(ctx, "versioned", &workflow.PublishInput{Author: "John"})
+	require.NoError(t, err)
+	require.Equal(t, 2, published.Version)
+	require.Equal(t, workflow.VersionStatusPublished, published.Status)
+	require.Equal(t, "John", published.Author)
+	require.Equal(t, "Split the work", published.ChangeNote)
+	require.NotNil(t, published.PublishedAt)
+
+	_, err = svc.Version(ctx, "versioned", workflow.DraftVersion)
+	require.ErrorIs(t, err, workflow.ErrVersionNotFound)
+
+	// Executions run the published version
+	result, err := svc.Execute(ctx, "versioned", &workflow.ExecutionInput{})
+	require.NoError(t, err)
+	require.Equal(t, 2, *result.WorkflowVersion)
+	require.Equal(t, []string{"start", "a", "b", "end"}, stepIDs(result.Steps))
+
+	t.Run("rollback to a draft or a missing version", func(t *testing.T) {
+		_, err := svc.Rollback(ctx, "versioned", workflow.DraftVersion, &workflow.PublishInput{})
+		require.ErrorIs(t, err, workflow.ErrVersionNotFound)
+
+		_, err = svc.Rollback(ctx, "versioned", 9, &workflow.PublishInput{})
+		require.ErrorIs(t, err, workflow.ErrVersionNotFound)
+	})
+
+	rolledBack, err := svc.Rollback(ctx, "versioned", 1, &workflow.PublishInput{Author: "Jane"})
+	require.NoError(t, err)
+	require.Equal(t, 3, rolledBack.Version)
+	require.Equal(t, "Rollback to version 1", rolledBack.ChangeNote)
+	require.Equal(t, original.Definition, rolledBack.Definition)
+
+	diff, err := svc.Diff(ctx, "versioned", 1, 3)
+	require.NoError(t, err)
+	require.Empty(t, diff.Nodes)
+	require.Empty(t, diff.Edges)
+
+	result, err = svc.Execute(ctx, "versioned", &workflow.ExecutionInput{})
+	require.NoError(t, err)
+	require.Equal(t, 3, *result.WorkflowVersion)
+	require.Equal(t, []string{"start", "work", "end"}, stepIDs(result.Steps))
+
+	versions, err := svc.Versions(ctx, "versioned")
+	require.NoError(t, err)
+	numbers := make([]int, 0, len(versions))
+	for _, version := range versions {
+		numbers = append(numbers, version.Version)
+	}
+	require.Equal(t, []int{3, 2, 1}, numbers)
+}
@@ -3,8 +3,15 @@ package config
 import "github.com/caarlos0/env"
 
 type Config struct {
-	Database Database
-	CORS     Cors
+	Database   Database
+	CORS       Cors
+	Plugins    Plugins
+	Executions Executions
+	Scheduler  Scheduler
+	Webhooks   Webhooks
+	Tracing    Tracing
+	Logging    Logging
+	Health     Health
 }
 
 func LoadConfig() (*Config, error) {
@@ -22,5 +29,47 @@ func LoadConfig() (*Config, error) {
 	}
 	cfg.CORS = cors
 
+	var plugins Plugins
+	if err := env.Parse(&plugins); err != nil {
+		return nil, err
+	}
+	cfg.Plugins = plugins
+
+	var executions Executions
+	if err := env.Parse(&executions); err != nil {
+		return nil, err
+	}
+	cfg.Executions = executions
+
+	var scheduler Scheduler
+	if err := env.Parse(&scheduler); err != nil {
+		return nil, err
+	}
+	cfg.Scheduler = scheduler
+
+	var webhooks Webhooks
+	if err := env.Parse(&webhooks); err != nil {
+		return nil, err
+	}
+	cfg.Webhooks = webhooks
+
+	var tracing Tracing
+	if err := env.Parse(&tracing); err != nil {
+		return nil, err
+	}
+	cfg.Tracing = tracing
+
+	var logging Logging
+	if err := env.Parse(&logging); err != nil {
+		return nil, err
+	}
+	cfg.Logging = logging
+
+	var health Health
+	if err := env.Parse(&health); err != nil {
+		return nil, err
+	}
+	cfg.Health = health
+
 	return &cfg, nil
 }
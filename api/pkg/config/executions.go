@@ -0,0 +1,8 @@
+package config
+
+import "time"
+
+type Executions struct {
+	// TimerInterval is how often waiting executions are checked for an elapsed delay.
+	TimerInterval time.Duration `env:"EXECUTION_TIMER_INTERVAL" envDefault:"5s"`
+}
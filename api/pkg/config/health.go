@@ -0,0 +1,16 @@
+package config
+
+import "time"
+
+type Health struct {
+	// Timeout bounds the checks of the readiness probe.
+	Timeout time.Duration `env:"READINESS_TIMEOUT" envDefault:"2s"`
+	// Upstreams are the URLs of external services the readiness probe checks are reachable, such as
+	// https://api.open-meteo.com. None are checked by default.
+	Upstreams []string `env:"READINESS_UPSTREAMS"`
+	// UpstreamCacheTTL is how long the reachability of upstreams is cached, so probes do not call them each time.
+	UpstreamCacheTTL time.Duration `env:"READINESS_UPSTREAM_CACHE_TTL" envDefault:"30s"`
+	// DrainDelay is how long the server keeps serving requests once it reports not ready on shutdown, so that it
+	// is taken out of load balancing before it stops accepting connections.
+	DrainDelay time.Duration `env:"SHUTDOWN_DRAIN_DELAY" envDefault:"5s"`
+}
@@ -0,0 +1,11 @@
+package config
+
+type Logging struct {
+	// Level is the minimum level logged: debug, info, warn or error.
+	Level string `env:"LOG_LEVEL" envDefault:"info"`
+	// Format is text or json.
+	Format string `env:"LOG_FORMAT" envDefault:"text"`
+	// RedactKeys are the attribute keys whose values are redacted from the logs, e.g. "email" also redacting
+	// "userEmail".
+	RedactKeys []string `env:"LOG_REDACT_KEYS" envDefault:"password,secret,token,authorization,apikey,signature,email"`
+}
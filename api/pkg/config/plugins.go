@@ -0,0 +1,7 @@
+package config
+
+type Plugins struct {
+	// Addresses lists the node plugins to load, separated by ";".
+	// Each is either tcp://host:port for a running plugin or a command line launching one.
+	Addresses []string `env:"NODE_PLUGINS" envSeparator:";"`
+}
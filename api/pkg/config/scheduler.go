@@ -0,0 +1,11 @@
+package config
+
+import "time"
+
+type Scheduler struct {
+	// Enabled runs the scheduler within the api command.
+	// It can be disabled when the scheduler command runs as a separate process instead.
+	Enabled bool `env:"SCHEDULER_ENABLED" envDefault:"true"`
+	// Interval is how often due schedules are checked.
+	Interval time.Duration `env:"SCHEDULER_INTERVAL" envDefault:"10s"`
+}
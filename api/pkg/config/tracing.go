@@ -0,0 +1,9 @@
+package config
+
+type Tracing struct {
+	// Exporter sends the spans of requests, executions, nodes, queries and outbound calls: none, stdout or otlp.
+	// The otlp exporter is configured by the standard OTEL_EXPORTER_OTLP_* variables.
+	Exporter string `env:"OTEL_TRACES_EXPORTER" envDefault:"none"`
+	// ServiceName identifies the API in the exported spans.
+	ServiceName string `env:"OTEL_SERVICE_NAME" envDefault:"workflow-api"`
+}
@@ -0,0 +1,9 @@
+package config
+
+import "time"
+
+type Webhooks struct {
+	// Tolerance is how far the timestamp of a webhook delivery may be from the current time.
+	// It bounds the window within which delivery nonces are remembered to reject replays.
+	Tolerance time.Duration `env:"WEBHOOK_TOLERANCE" envDefault:"5m"`
+}
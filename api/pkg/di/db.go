@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"workflow-code-test/api/pkg/config"
+	"workflow-code-test/api/pkg/metrics"
 	"workflow-code-test/api/pkg/postgres"
 )
 
@@ -21,5 +22,10 @@ func (s *serviceImpl) dbService(ctx context.Context, cfg *config.Config) *postgr
 		os.Exit(1)
 	}
 
+	if err := metrics.Registry.Register(postgres.NewPoolCollector(pool.Pool())); err != nil {
+		s.container.Logger.Error("Failed to register postgres pool metrics", "error", err)
+		os.Exit(1)
+	}
+
 	return pool
 }
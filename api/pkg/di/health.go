@@ -0,0 +1,29 @@
+package di
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"workflow-code-test/api/pkg/config"
+	"workflow-code-test/api/pkg/health"
+	"workflow-code-test/api/pkg/postgres"
+)
+
+func (s *serviceImpl) healthChecker(cfg *config.Config, dbService *postgres.Service) *health.Checker {
+	checker := health.NewChecker(cfg.Health.Timeout, s.container.Logger)
+	checker.Add("database", dbService.Ping)
+
+	// Upstreams are checked with a client of their own, so probes are not traced and measured as node calls
+	client := &http.Client{}
+	for _, upstream := range cfg.Health.Upstreams {
+		u, err := url.Parse(upstream)
+		if err != nil || u.Host == "" {
+			s.container.Logger.Error("Invalid readiness upstream", "url", upstream, "error", err)
+			os.Exit(1)
+		}
+
+		checker.Add(u.Host, health.Cached(health.HTTPCheck(client, upstream), cfg.Health.UpstreamCacheTTL))
+	}
+
+	return checker
+}
@@ -4,7 +4,11 @@ import (
 	"context"
 	"log/slog"
 
+	"workflow-code-test/api/internal/schedule"
+	"workflow-code-test/api/internal/webhook"
+	"workflow-code-test/api/internal/workflow"
 	"workflow-code-test/api/pkg/config"
+	"workflow-code-test/api/pkg/health"
 	"workflow-code-test/api/pkg/nodes"
 	"workflow-code-test/api/pkg/postgres"
 )
@@ -27,6 +31,16 @@ type Container struct {
 	Logger *slog.Logger
 	// DbService provides access to the Postgres database.
 	DbService *postgres.Service
+	// Health runs the readiness checks of the API and reports it draining on shutdown.
+	Health *health.Checker
 	// NodeService provides workflow node management functionality.
 	NodeService *nodes.Service
+	// WorkflowService executes workflows and resumes waiting executions.
+	WorkflowService workflow.Service
+	// ScheduleService manages the cron schedules of workflows.
+	ScheduleService schedule.Service
+	// Scheduler triggers the executions of due schedules.
+	Scheduler *schedule.Scheduler
+	// WebhookService manages webhooks and executes workflows from their deliveries.
+	WebhookService webhook.Service
 }
@@ -0,0 +1,25 @@
+package di
+
+import (
+	"log/slog"
+	"workflow-code-test/api/internal/workflow"
+	"workflow-code-test/api/pkg/nodes"
+)
+
+// LocalContainer builds a container running workflows from repo, without Postgres, for the run command.
+// Nodes call external services through integrations. Only the logger, node and workflow services are set:
+// schedules, webhooks and plugins need the API.
+func LocalContainer(repo workflow.Repository, integrations *Integrations, logger *slog.Logger) *Container {
+	registry := nodes.DefaultRegistry().Clone()
+	registerIntegrationNodes(registry, integrations)
+
+	nodeService := nodes.NewService(registry)
+	svc := workflow.NewService(repo, nodeService, logger)
+	registerWorkflowNodes(registry, svc)
+
+	return &Container{
+		Logger:          logger,
+		NodeService:     nodeService,
+		WorkflowService: svc,
+	}
+}
@@ -3,12 +3,23 @@ package di
 import (
 	"log/slog"
 	"os"
+	"workflow-code-test/api/pkg/config"
+	"workflow-code-test/api/pkg/logging"
 )
 
-func (s *serviceImpl) logger() *slog.Logger {
-	logHandler := slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
-		Level: slog.LevelDebug,
+func (s *serviceImpl) logger(cfg *config.Config) *slog.Logger {
+	logger, err := logging.New(&logging.Options{
+		Level:      cfg.Logging.Level,
+		Format:     cfg.Logging.Format,
+		RedactKeys: cfg.Logging.RedactKeys,
 	})
+	if err != nil {
+		slog.Error("Failed to create logger", "error", err)
+		os.Exit(1)
+	}
 
-	return slog.New(logHandler)
+	// Packages logging through slog directly, such as the noop mailer, share its configuration
+	slog.SetDefault(logger)
+
+	return logger
 }
@@ -1,17 +1,82 @@
 package di
 
 import (
+	"context"
+	"os"
+	"workflow-code-test/api/pkg/config"
 	"workflow-code-test/api/pkg/mailer"
 	"workflow-code-test/api/pkg/nodes"
+	"workflow-code-test/api/pkg/nodes/email"
+	"workflow-code-test/api/pkg/nodes/plugin"
+	"workflow-code-test/api/pkg/nodes/types"
+	"workflow-code-test/api/pkg/nodes/weatherapi"
 	"workflow-code-test/api/pkg/openstreetmap"
 	"workflow-code-test/api/pkg/openweather"
+
+	// Node kinds without dependencies register themselves from init
+	_ "workflow-code-test/api/pkg/nodes/approval"
+	_ "workflow-code-test/api/pkg/nodes/condition"
+	_ "workflow-code-test/api/pkg/nodes/delay"
+	_ "workflow-code-test/api/pkg/nodes/form"
+	_ "workflow-code-test/api/pkg/nodes/script"
 )
 
-// nodeService initializes and returns a new nodes.Service.
+// Integrations are the clients of the external services called by nodes.
+type Integrations struct {
+	GeoClient     openstreetmap.Client
+	WeatherClient openweather.Client
+	MailClient    mailer.Client
+}
+
+// DefaultIntegrations returns the clients of the services used by the API.
 // For simplicity, OpenStreetMap, OpenWeather, and Mailer clients are
 // initialized together here. In future iterations, these dependencies
 // should be initialized individually to allow for more granular control
 // and easier testing.
-func (s *serviceImpl) nodeService() *nodes.Service {
-	return nodes.NewService(openstreetmap.NewClient(), openweather.NewClient(), mailer.NewNoopClient())
+func DefaultIntegrations() *Integrations {
+	return &Integrations{
+		GeoClient:     openstreetmap.NewClient(),
+		WeatherClient: openweather.NewClient(),
+		MailClient:    mailer.NewNoopClient(),
+	}
+}
+
+// nodeService initializes and returns a new nodes.Service.
+// Node kinds without dependencies register themselves into the default registry, which is cloned
+// so that containers do not share kinds, while kinds requiring clients are registered here with
+// their dependencies, followed by the kinds served by the configured plugins.
+func (s *serviceImpl) nodeService(ctx context.Context, cfg *config.Config) *nodes.Service {
+	registry := nodes.DefaultRegistry().Clone()
+	s.registry = registry
+
+	registerIntegrationNodes(registry, DefaultIntegrations())
+
+	for _, address := range cfg.Plugins.Addresses {
+		client, err := plugin.Load(ctx, registry, address)
+		if err != nil {
+			s.container.Logger.Error("Failed to load node plugin", "address", address, "error", err)
+			os.Exit(1)
+		}
+		s.plugins = append(s.plugins, client)
+	}
+
+	return nodes.NewService(registry)
+}
+
+// registerIntegrationNodes registers the node kinds calling external services through integrations.
+func registerIntegrationNodes(registry *nodes.Registry, integrations *Integrations) {
+	weatherOpts := &weatherapi.Options{
+		GeoClient:     integrations.GeoClient,
+		WeatherClient: integrations.WeatherClient,
+	}
+	registry.Register(weatherapi.Descriptor.Kind, func() types.NodeExecutor {
+		return &weatherapi.Executor{Opts: weatherOpts}
+	}, weatherapi.Descriptor)
+
+	emailOpts := &email.Options{
+		MailClient: integrations.MailClient,
+	}
+	registry.Register(email.Descriptor.Kind, func() types.NodeExecutor {
+		return &email.Executor{Opts: emailOpts}
+	}, email.Descriptor)
 }
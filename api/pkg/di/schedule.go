@@ -0,0 +1,17 @@
+package di
+
+import (
+	"workflow-code-test/api/internal/schedule"
+	"workflow-code-test/api/pkg/config"
+)
+
+// scheduleServices initializes the schedule management service and the scheduler
+// triggering executions of due schedules through the workflow service.
+func (s *serviceImpl) scheduleServices(cfg *config.Config) (schedule.Service, *schedule.Scheduler) {
+	repo := schedule.NewRepository(s.container.DbService.Pool())
+
+	svc := schedule.NewService(repo)
+	scheduler := schedule.NewScheduler(repo, s.container.WorkflowService, cfg.Scheduler.Interval, s.container.Logger)
+
+	return svc, scheduler
+}
@@ -2,13 +2,21 @@ package di
 
 import (
 	"context"
+	"log/slog"
 	"os"
 	"workflow-code-test/api/pkg/config"
+	"workflow-code-test/api/pkg/nodes"
+	"workflow-code-test/api/pkg/nodes/plugin"
 )
 
 type serviceImpl struct {
 	container *Container
 	config    *config.Config
+	plugins   []*plugin.Client
+	// registry holds the node kinds of the container, cloned from the kinds registered from init.
+	registry *nodes.Registry
+	// stopTracing flushes the spans left and stops the tracer provider.
+	stopTracing func(context.Context) error
 }
 
 // Config implements Service.
@@ -20,29 +28,42 @@ func (s *serviceImpl) Config() *config.Config {
 func (s *serviceImpl) Container(ctx context.Context) *Container {
 	s.container = &Container{}
 
-	logger := s.logger()
-	s.container.Logger = logger
-
 	cfg, err := config.LoadConfig()
 	if err != nil {
-		logger.Error("unable to parse configs")
+		slog.Error("unable to parse configs", "error", err)
 		os.Exit(1)
 	}
 	s.config = cfg
 
+	s.container.Logger = s.logger(cfg)
+
+	s.stopTracing = s.tracing(ctx, cfg)
+
 	dbService := s.dbService(ctx, cfg)
 	s.container.DbService = dbService
 
-	nodeService := s.nodeService()
+	s.container.Health = s.healthChecker(cfg, dbService)
+
+	nodeService := s.nodeService(ctx, cfg)
 	s.container.NodeService = nodeService
 
+	s.container.WorkflowService = s.workflowService()
+
+	s.container.ScheduleService, s.container.Scheduler = s.scheduleServices(cfg)
+
+	s.container.WebhookService = s.webhookService(cfg)
+
 	return s.container
 }
 
 // Shutdown implements Service.
 func (s *serviceImpl) Shutdown(ctx context.Context) error {
+	for _, p := range s.plugins {
+		p.Close()
+	}
+
 	s.container.DbService.Disconnect(ctx)
-	return nil
+	return s.stopTracing(ctx)
 }
 
 func NewService() Service {
@@ -0,0 +1,21 @@
+package di
+
+import (
+	"context"
+	"os"
+	"workflow-code-test/api/pkg/config"
+	"workflow-code-test/api/pkg/telemetry"
+)
+
+func (s *serviceImpl) tracing(ctx context.Context, cfg *config.Config) func(context.Context) error {
+	stop, err := telemetry.Setup(ctx, &telemetry.Options{
+		Exporter:    cfg.Tracing.Exporter,
+		ServiceName: cfg.Tracing.ServiceName,
+	})
+	if err != nil {
+		s.container.Logger.Error("Failed to set up tracing", "error", err)
+		os.Exit(1)
+	}
+
+	return stop
+}
@@ -0,0 +1,12 @@
+package di
+
+import (
+	"workflow-code-test/api/internal/webhook"
+	"workflow-code-test/api/pkg/config"
+)
+
+// webhookService initializes the service managing webhooks and executing workflows from their deliveries.
+func (s *serviceImpl) webhookService(cfg *config.Config) webhook.Service {
+	repo := webhook.NewRepository(s.container.DbService.Pool())
+	return webhook.NewService(repo, s.container.WorkflowService, cfg.Webhooks.Tolerance)
+}
@@ -0,0 +1,38 @@
+package di
+
+import (
+	"workflow-code-test/api/internal/workflow"
+	"workflow-code-test/api/pkg/nodes"
+	"workflow-code-test/api/pkg/nodes/foreach"
+	"workflow-code-test/api/pkg/nodes/subworkflow"
+	"workflow-code-test/api/pkg/nodes/types"
+)
+
+// workflowService initializes the workflow engine on top of the database and node services.
+// It is shared by the HTTP handlers and the timers resuming delayed executions.
+// The subworkflow and foreach node kinds run workflows through the engine, so they are registered once the engine exists.
+func (s *serviceImpl) workflowService() workflow.Service {
+	repo := workflow.NewRepository(s.container.DbService.Pool())
+	svc := workflow.NewService(repo, s.container.NodeService, s.container.Logger)
+
+	registerWorkflowNodes(s.registry, svc)
+
+	return svc
+}
+
+// registerWorkflowNodes registers the node kinds running workflows through the engine.
+func registerWorkflowNodes(registry *nodes.Registry, runner types.WorkflowRunner) {
+	subworkflowOpts := &subworkflow.Options{
+		Workflows: runner,
+	}
+	registry.Register(subworkflow.Descriptor.Kind, func() types.NodeExecutor {
+		return &subworkflow.Executor{Opts: subworkflowOpts}
+	}, subworkflow.Descriptor)
+
+	foreachOpts := &foreach.Options{
+		Workflows: runner,
+	}
+	registry.Register(foreach.Descriptor.Kind, func() types.NodeExecutor {
+		return &foreach.Executor{Opts: foreachOpts}
+	}, foreach.Descriptor)
+}
@@ -0,0 +1,128 @@
+// Package document defines the portable form of a workflow, used to move workflows between environments.
+//
+// A document holds the workflow's name along with its nodes and edges, and is encoded as JSON or YAML.
+// Both encodings share the JSON field names, so a YAML document reads like its JSON counterpart.
+package document
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// CurrentVersion is the version of the document format written by Encode.
+// Decode rejects documents of other versions.
+const CurrentVersion = 1
+
+// Document is a workflow exported from an environment.
+type Document struct {
+	Version  int      `json:"version"`
+	Workflow Workflow `json:"workflow"`
+	Nodes    []Node   `json:"nodes"`
+	Edges    []Edge   `json:"edges"`
+}
+
+// Workflow describes the exported workflow.
+// ID and Version identify the workflow and the published version it was exported from.
+type Workflow struct {
+	ID         string     `json:"id,omitempty"`
+	Name       string     `json:"name"`
+	Version    int        `json:"version,omitempty"`
+	ExportedAt *time.Time `json:"exportedAt,omitempty"`
+}
+
+type Node struct {
+	ID       string   `json:"id"`
+	Type     string   `json:"type"`
+	Position Position `json:"position"`
+	Data     Data     `json:"data"`
+}
+
+type Position struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type Data struct {
+	Label       string         `json:"label"`
+	Description string         `json:"description"`
+	Metadata    map[string]any `json:"metadata,omitempty"`
+}
+
+type Edge struct {
+	Source       string         `json:"source"`
+	Target       string         `json:"target"`
+	Type         string         `json:"type,omitempty"`
+	Animated     bool           `json:"animated,omitempty"`
+	SourceHandle *string        `json:"sourceHandle,omitempty"`
+	Label        string         `json:"label,omitempty"`
+	Style        map[string]any `json:"style,omitempty"`
+	LabelStyle   map[string]any `json:"labelStyle,omitempty"`
+}
+
+// ValidationError reports a document that does not match the schema.
+// Fields maps each offending field, e.g. "nodes[2].id", to a human readable message.
+type ValidationError struct {
+	Fields map[string]string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid document: %d field(s) failed validation", len(e.Fields))
+}
+
+// Validate checks the document against the schema: a supported version, a named workflow,
+// unique node IDs and edges linking existing nodes through true or false handles.
+func (d *Document) Validate() error {
+	fieldErrors := map[string]string{}
+
+	if d.Version != CurrentVersion {
+		fieldErrors["version"] = fmt.Sprintf("must be %d, got %d", CurrentVersion, d.Version)
+	}
+
+	if d.Workflow.Name == "" {
+		fieldErrors["workflow.name"] = "is required"
+	}
+
+	if len(d.Nodes) == 0 {
+		fieldErrors["nodes"] = "is required"
+	}
+
+	nodeIDs := make(map[string]bool, len(d.Nodes))
+	for i, n := range d.Nodes {
+		field := fmt.Sprintf("nodes[%d]", i)
+
+		switch {
+		case n.ID == "":
+			fieldErrors[field+".id"] = "is required"
+		case nodeIDs[n.ID]:
+			fieldErrors[field+".id"] = fmt.Sprintf("%s is duplicated", n.ID)
+		}
+		nodeIDs[n.ID] = true
+
+		if n.Type == "" {
+			fieldErrors[field+".type"] = "is required"
+		}
+	}
+
+	for i, e := range d.Edges {
+		field := fmt.Sprintf("edges[%d]", i)
+
+		if !nodeIDs[e.Source] {
+			fieldErrors[field+".source"] = fmt.Sprintf("%q is not a node", e.Source)
+		}
+		if !nodeIDs[e.Target] {
+			fieldErrors[field+".target"] = fmt.Sprintf("%q is not a node", e.Target)
+		}
+		if e.SourceHandle != nil {
+			if _, err := strconv.ParseBool(*e.SourceHandle); err != nil {
+				fieldErrors[field+".sourceHandle"] = "must be true or false"
+			}
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return &ValidationError{Fields: fieldErrors}
+	}
+
+	return nil
+}
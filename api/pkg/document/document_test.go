@@ -0,0 +1,167 @@
+package document_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"workflow-code-test/api/pkg/document"
+
+	"github.com/stretchr/testify/require"
+)
+
+func weatherAlert() *document.Document {
+	trueHandle := "true"
+
+	return &document.Document{
+		Version: document.CurrentVersion,
+		Workflow: document.Workflow{
+			ID:      "550e8400-e29b-41d4-a716-446655440000",
+			Name:    "Weather alert",
+			Version: 3,
+		},
+		Nodes: []document.Node{
+			{ID: "start", Type: "start", Data: document.Data{Label: "Start"}},
+			{
+				ID:       "condition",
+				Type:     "condition",
+				Position: document.Position{X: 100, Y: 200},
+				Data: document.Data{
+					Label: "Check temperature",
+					Metadata: map[string]any{
+						"conditionExpression": "{{temperature}} > {{threshold}}",
+						"threshold":           25.5,
+						"outputVariables":     []any{"conditionMet"},
+						"enabled":             "yes",
+					},
+				},
+			},
+			{ID: "end", Type: "end", Data: document.Data{Label: "End"}},
+		},
+		Edges: []document.Edge{
+			{Source: "start", Target: "condition", Type: "smoothstep"},
+			{Source: "condition", Target: "end", Type: "smoothstep", SourceHandle: &trueHandle, Style: map[string]any{"stroke": "#10b981"}},
+		},
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, format := range []document.Format{document.FormatJSON, document.FormatYAML} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, document.Encode(&buf, weatherAlert(), format))
+
+			doc, err := document.Decode(&buf, format)
+			require.NoError(t, err)
+			require.Equal(t, weatherAlert(), doc)
+		})
+	}
+}
+
+func TestEncodeYAML(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, document.Encode(&buf, weatherAlert(), document.FormatYAML))
+
+	yaml := buf.String()
+	require.True(t, strings.HasPrefix(yaml, "version: 1\nworkflow:\n"), "fields keep the JSON order:\n%s", yaml)
+	require.Contains(t, yaml, "sourceHandle: \"true\"")
+	require.Contains(t, yaml, "enabled: \"yes\"")
+	require.NotContains(t, yaml, "{\"")
+}
+
+func TestDecodeYAMLNumbers(t *testing.T) {
+	doc, err := document.Decode(strings.NewReader(`
+version: 1
+workflow:
+  name: Retry
+nodes:
+  - id: start
+    type: start
+    data:
+      metadata:
+        attempts: 3
+`), document.FormatYAML)
+	require.NoError(t, err)
+
+	// Numbers are float64 whatever the format, as when decoding JSON
+	require.Equal(t, 3.0, doc.Nodes[0].Data.Metadata["attempts"])
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	tests := []struct {
+		name           string
+		document       string
+		expectedFields map[string]string
+		expectedErr    string
+	}{
+		{
+			name:     "unsupported version and missing name",
+			document: `{"version": 2, "workflow": {}, "nodes": [{"id": "start", "type": "start"}]}`,
+			expectedFields: map[string]string{
+				"version":       "must be 1, got 2",
+				"workflow.name": "is required",
+			},
+		},
+		{
+			name:     "no nodes",
+			document: `{"version": 1, "workflow": {"name": "Empty"}, "nodes": []}`,
+			expectedFields: map[string]string{
+				"nodes": "is required",
+			},
+		},
+		{
+			name: "invalid nodes and edges",
+			document: `{"version": 1, "workflow": {"name": "Broken"},
+				"nodes": [{"id": "start", "type": "start"}, {"id": "start", "type": ""}, {"id": "", "type": "end"}],
+				"edges": [{"source": "start", "target": "missing", "sourceHandle": "maybe"}]}`,
+			expectedFields: map[string]string{
+				"nodes[1].id":           "start is duplicated",
+				"nodes[1].type":         "is required",
+				"nodes[2].id":           "is required",
+				"edges[0].target":       `"missing" is not a node`,
+				"edges[0].sourceHandle": "must be true or false",
+			},
+		},
+		{
+			name:        "unknown field",
+			document:    `{"version": 1, "workflow": {"name": "Typo"}, "nodes": [{"id": "start", "type": "start", "metdata": {}}]}`,
+			expectedErr: `unknown field "metdata"`,
+		},
+		{
+			name:        "malformed",
+			document:    `{"version": 1,`,
+			expectedErr: "failed to decode document",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := document.Decode(strings.NewReader(tt.document), document.FormatJSON)
+			require.Error(t, err)
+
+			if tt.expectedErr != "" {
+				require.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+
+			var validationErr *document.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			require.Equal(t, tt.expectedFields, validationErr.Fields)
+		})
+	}
+}
+
+func TestFormats(t *testing.T) {
+	format, err := document.ParseFormat("YAML")
+	require.NoError(t, err)
+	require.Equal(t, document.FormatYAML, format)
+
+	format, err = document.ParseFormat("")
+	require.NoError(t, err)
+	require.Equal(t, document.FormatJSON, format)
+
+	_, err = document.ParseFormat("xml")
+	require.ErrorContains(t, err, `unsupported format "xml"`)
+
+	require.Equal(t, document.FormatYAML, document.FormatOf("exports/weather.yml"))
+	require.Equal(t, document.FormatJSON, document.FormatOf("weather.json"))
+}
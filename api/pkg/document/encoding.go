@@ -0,0 +1,121 @@
+package document
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the encoding of a document.
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+)
+
+// ParseFormat parses a format name. An empty name is JSON.
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case "", string(FormatJSON):
+		return FormatJSON, nil
+	case string(FormatYAML), "yml":
+		return FormatYAML, nil
+	default:
+		return "", fmt.Errorf("unsupported format %q, expected json or yaml", name)
+	}
+}
+
+// FormatOf returns the format of a file from its extension: YAML for .yaml and .yml files, JSON otherwise.
+func FormatOf(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML
+	default:
+		return FormatJSON
+	}
+}
+
+// Encode writes the document to w in the format.
+func Encode(w io.Writer, doc *Document, format Format) error {
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode document: %w", err)
+	}
+
+	if format == FormatYAML {
+		// JSON is valid YAML, so it is re-encoded in block style to keep the JSON field order
+		var node yaml.Node
+		if err := yaml.Unmarshal(b, &node); err != nil {
+			return fmt.Errorf("failed to encode document: %w", err)
+		}
+		blockStyle(&node)
+
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(&node); err != nil {
+			return fmt.Errorf("failed to encode document: %w", err)
+		}
+		return enc.Close()
+	}
+
+	b = append(b, '\n')
+	_, err = w.Write(b)
+	return err
+}
+
+// Decode reads a document in the format from r and validates it.
+// Unknown fields are rejected, and a *ValidationError is returned if the document does not match the schema.
+func Decode(r io.Reader, format Format) (*Document, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read document: %w", err)
+	}
+
+	if format == FormatYAML {
+		// YAML is converted to JSON, so numbers are decoded as float64 whatever the format
+		var value any
+		if err := yaml.Unmarshal(b, &value); err != nil {
+			return nil, fmt.Errorf("failed to decode document: %w", err)
+		}
+
+		if b, err = json.Marshal(value); err != nil {
+			return nil, fmt.Errorf("failed to decode document: %w", err)
+		}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+
+	var doc Document
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode document: %w", err)
+	}
+
+	if err := doc.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &doc, nil
+}
+
+// blockStyle clears the flow and quoting styles of the node tree.
+// Strings are only quoted where the encoder quotes them when marshalling a string, such as "true" or "yes",
+// so YAML 1.1 parsers do not read them as another type.
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!str" {
+		if plain, err := yaml.Marshal(node.Value); err == nil && bytes.ContainsAny(plain[:1], `"'`) {
+			node.Style = yaml.DoubleQuotedStyle
+		}
+	}
+
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
@@ -0,0 +1,58 @@
+// Package eventbus publishes events to the subscribers of a topic within the process.
+package eventbus
+
+import "sync"
+
+// Bus delivers the events published to a topic to its subscribers. Publishing never blocks: events are dropped for
+// subscribers whose buffer is full, so a slow subscriber cannot hold up the publisher. It is safe for concurrent use.
+type Bus[T any] struct {
+	mu          sync.Mutex
+	subscribers map[string]map[chan T]struct{}
+}
+
+// Publish delivers the event to the current subscribers of the topic.
+func (b *Bus[T]) Publish(topic string, event T) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers[topic] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Subscribe returns a channel receiving the events published to the topic from now on, buffering up to buffer events.
+// The returned function unsubscribes and closes the channel.
+func (b *Bus[T]) Subscribe(topic string, buffer int) (<-chan T, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan T, buffer)
+	if b.subscribers[topic] == nil {
+		b.subscribers[topic] = map[chan T]struct{}{}
+	}
+	b.subscribers[topic][ch] = struct{}{}
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+
+			delete(b.subscribers[topic], ch)
+			if len(b.subscribers[topic]) == 0 {
+				delete(b.subscribers, topic)
+			}
+			close(ch)
+		})
+	}
+}
+
+// New creates a bus without subscribers.
+func New[T any]() *Bus[T] {
+	return &Bus[T]{
+		subscribers: map[string]map[chan T]struct{}{},
+	}
+}
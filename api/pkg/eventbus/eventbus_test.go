@@ -0,0 +1,54 @@
+package eventbus_test
+
+import (
+	"testing"
+	"workflow-code-test/api/pkg/eventbus"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublish(t *testing.T) {
+	bus := eventbus.New[string]()
+
+	a, unsubscribeA := bus.Subscribe("a", 10)
+	defer unsubscribeA()
+	b, unsubscribeB := bus.Subscribe("b", 10)
+	defer unsubscribeB()
+
+	bus.Publish("a", "first")
+	bus.Publish("b", "second")
+	bus.Publish("c", "nobody listens")
+
+	require.Equal(t, "first", <-a)
+	require.Equal(t, "second", <-b)
+	require.Empty(t, a)
+	require.Empty(t, b)
+}
+
+func TestPublishDropsWhenFull(t *testing.T) {
+	bus := eventbus.New[int]()
+
+	events, unsubscribe := bus.Subscribe("topic", 2)
+	defer unsubscribe()
+
+	for i := range 5 {
+		bus.Publish("topic", i)
+	}
+
+	require.Equal(t, 0, <-events)
+	require.Equal(t, 1, <-events)
+	require.Empty(t, events)
+}
+
+func TestUnsubscribe(t *testing.T) {
+	bus := eventbus.New[int]()
+
+	events, unsubscribe := bus.Subscribe("topic", 1)
+	unsubscribe()
+	unsubscribe()
+
+	bus.Publish("topic", 1)
+
+	_, open := <-events
+	require.False(t, open)
+}
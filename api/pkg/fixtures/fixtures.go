@@ -0,0 +1,151 @@
+// Package fixtures replays recorded responses of the external services called by nodes,
+// so workflows run offline and give the same results on every run.
+package fixtures
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"workflow-code-test/api/pkg/openstreetmap"
+	"workflow-code-test/api/pkg/openweather"
+)
+
+// ErrNoFixture is returned for a request without a recorded response when no service is called instead.
+var ErrNoFixture = errors.New("no fixture recorded")
+
+type Coordinates struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// Fixtures are recorded responses keyed by their request. It is safe for concurrent use.
+type Fixtures struct {
+	mu sync.Mutex
+
+	// Cities maps city names to their coordinates, as located by openstreetmap.Client.
+	Cities map[string]Coordinates `json:"cities"`
+	// Temperatures maps coordinates, formatted as "<lat>,<lng>", to their temperature in Celsius,
+	// as returned by openweather.Client.
+	Temperatures map[string]float64 `json:"temperatures"`
+}
+
+// GeoClient returns a client locating cities from the fixtures.
+// Cities without a fixture are located by next and recorded, or fail with ErrNoFixture if next is nil.
+func (f *Fixtures) GeoClient(next openstreetmap.Client) openstreetmap.Client {
+	return &geoClient{fixtures: f, next: next}
+}
+
+// WeatherClient returns a client reading temperatures from the fixtures.
+// Coordinates without a fixture are read by next and recorded, or fail with ErrNoFixture if next is nil.
+func (f *Fixtures) WeatherClient(next openweather.Client) openweather.Client {
+	return &weatherClient{fixtures: f, next: next}
+}
+
+// Save writes the fixtures to a JSON file.
+func (f *Fixtures) Save(path string) error {
+	f.mu.Lock()
+	b, err := json.MarshalIndent(f, "", "  ")
+	f.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode fixtures: %w", err)
+	}
+
+	return os.WriteFile(path, append(b, '\n'), 0o644)
+}
+
+type geoClient struct {
+	fixtures *Fixtures
+	next     openstreetmap.Client
+}
+
+// LatLngByCity implements openstreetmap.Client.
+func (c *geoClient) LatLngByCity(ctx context.Context, city string) (float64, float64, error) {
+	c.fixtures.mu.Lock()
+	coordinates, ok := c.fixtures.Cities[city]
+	c.fixtures.mu.Unlock()
+	if ok {
+		return coordinates.Lat, coordinates.Lng, nil
+	}
+
+	if c.next == nil {
+		return 0, 0, fmt.Errorf("%w for city %s", ErrNoFixture, city)
+	}
+
+	lat, lng, err := c.next.LatLngByCity(ctx, city)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	c.fixtures.mu.Lock()
+	c.fixtures.Cities[city] = Coordinates{Lat: lat, Lng: lng}
+	c.fixtures.mu.Unlock()
+
+	return lat, lng, nil
+}
+
+type weatherClient struct {
+	fixtures *Fixtures
+	next     openweather.Client
+}
+
+// TemperatureInCelsiusByLatLng implements openweather.Client.
+func (c *weatherClient) TemperatureInCelsiusByLatLng(ctx context.Context, lat, lng float64) (float64, error) {
+	key := strconv.FormatFloat(lat, 'f', -1, 64) + "," + strconv.FormatFloat(lng, 'f', -1, 64)
+
+	c.fixtures.mu.Lock()
+	temperature, ok := c.fixtures.Temperatures[key]
+	c.fixtures.mu.Unlock()
+	if ok {
+		return temperature, nil
+	}
+
+	if c.next == nil {
+		return 0, fmt.Errorf("%w for coordinates %s", ErrNoFixture, key)
+	}
+
+	temperature, err := c.next.TemperatureInCelsiusByLatLng(ctx, lat, lng)
+	if err != nil {
+		return 0, err
+	}
+
+	c.fixtures.mu.Lock()
+	c.fixtures.Temperatures[key] = temperature
+	c.fixtures.mu.Unlock()
+
+	return temperature, nil
+}
+
+// Load reads fixtures from a JSON file.
+func Load(path string) (*Fixtures, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	f := New()
+	if err := json.Unmarshal(b, f); err != nil {
+		return nil, fmt.Errorf("failed to decode fixtures %s: %w", path, err)
+	}
+
+	// Files may leave out services they have no fixtures for
+	if f.Cities == nil {
+		f.Cities = map[string]Coordinates{}
+	}
+	if f.Temperatures == nil {
+		f.Temperatures = map[string]float64{}
+	}
+
+	return f, nil
+}
+
+// New creates empty fixtures.
+func New() *Fixtures {
+	return &Fixtures{
+		Cities:       map[string]Coordinates{},
+		Temperatures: map[string]float64{},
+	}
+}
@@ -0,0 +1,74 @@
+package fixtures_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"workflow-code-test/api/pkg/fixtures"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockGeoClient struct {
+	calls int
+}
+
+func (m *mockGeoClient) LatLngByCity(ctx context.Context, city string) (float64, float64, error) {
+	m.calls++
+	return -33.8688, 151.2093, nil
+}
+
+type mockWeatherClient struct {
+	calls int
+}
+
+func (m *mockWeatherClient) TemperatureInCelsiusByLatLng(ctx context.Context, lat, lng float64) (float64, error) {
+	m.calls++
+	return 28.5, nil
+}
+
+func TestReplay(t *testing.T) {
+	f := fixtures.New()
+	f.Cities["Sydney"] = fixtures.Coordinates{Lat: -33.8688, Lng: 151.2093}
+	f.Temperatures["-33.8688,151.2093"] = 21.3
+
+	lat, lng, err := f.GeoClient(nil).LatLngByCity(context.Background(), "Sydney")
+	require.NoError(t, err)
+
+	temperature, err := f.WeatherClient(nil).TemperatureInCelsiusByLatLng(context.Background(), lat, lng)
+	require.NoError(t, err)
+	require.Equal(t, 21.3, temperature)
+
+	_, _, err = f.GeoClient(nil).LatLngByCity(context.Background(), "Perth")
+	require.ErrorIs(t, err, fixtures.ErrNoFixture)
+	require.ErrorContains(t, err, "no fixture recorded for city Perth")
+
+	_, err = f.WeatherClient(nil).TemperatureInCelsiusByLatLng(context.Background(), -31.95, 115.86)
+	require.ErrorContains(t, err, "no fixture recorded for coordinates -31.95,115.86")
+}
+
+func TestRecord(t *testing.T) {
+	geo, weather := &mockGeoClient{}, &mockWeatherClient{}
+
+	f := fixtures.New()
+	for range 2 {
+		lat, lng, err := f.GeoClient(geo).LatLngByCity(context.Background(), "Sydney")
+		require.NoError(t, err)
+
+		temperature, err := f.WeatherClient(weather).TemperatureInCelsiusByLatLng(context.Background(), lat, lng)
+		require.NoError(t, err)
+		require.Equal(t, 28.5, temperature)
+	}
+
+	// The second run replays the responses recorded by the first
+	require.Equal(t, 1, geo.calls)
+	require.Equal(t, 1, weather.calls)
+
+	path := filepath.Join(t.TempDir(), "fixtures.json")
+	require.NoError(t, f.Save(path))
+
+	loaded, err := fixtures.Load(path)
+	require.NoError(t, err)
+	require.Equal(t, f.Cities, loaded.Cities)
+	require.Equal(t, f.Temperatures, loaded.Temperatures)
+}
@@ -0,0 +1,54 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Cached returns a check reusing the result of check for ttl, for checks too costly to run on every probe,
+// such as calls to external services. Concurrent probes wait for the check rather than running it again.
+func Cached(check Check, ttl time.Duration) Check {
+	var (
+		mu        sync.Mutex
+		err       error
+		checkedAt time.Time
+	)
+
+	return func(ctx context.Context) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if checkedAt.IsZero() || time.Since(checkedAt) >= ttl {
+			err = check(ctx)
+			checkedAt = time.Now()
+		}
+
+		return err
+	}
+}
+
+// HTTPCheck returns a check that the server at url is reachable, failing if the request fails or is answered
+// with a server error. Other statuses, e.g. 404 Not Found, show that the server is up.
+func HTTPCheck(client *http.Client, url string) Check {
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to reach %s: %w", url, err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return fmt.Errorf("failed to reach %s: %s", url, resp.Status)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,118 @@
+// Package health reports whether the API is alive and ready to serve requests, for orchestrators to restart it
+// or route requests to it accordingly.
+package health
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+	"workflow-code-test/api/pkg/render"
+)
+
+// Statuses reported by the probes and their checks.
+const (
+	StatusOK          = "ok"
+	StatusReady       = "ready"
+	StatusNotReady    = "not ready"
+	StatusDraining    = "draining"
+	StatusUnavailable = "unavailable"
+)
+
+// Check returns an error if a dependency of the API cannot be used.
+type Check func(ctx context.Context) error
+
+// Report is the body of the probes. Checks holds the status of each check of the readiness probe.
+type Report struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// Checker runs the checks of the readiness probe, until the API is drained.
+type Checker struct {
+	timeout  time.Duration
+	log      *slog.Logger
+	checks   map[string]Check
+	draining atomic.Bool
+}
+
+// NewChecker returns a checker giving each check up to timeout. Failed checks are logged with log rather than
+// reported, as their errors may reveal the infrastructure of the API.
+func NewChecker(timeout time.Duration, log *slog.Logger) *Checker {
+	return &Checker{
+		timeout: timeout,
+		log:     log,
+		checks:  make(map[string]Check),
+	}
+}
+
+// Add adds a check to the readiness probe, reported under name. Checks must be added before serving probes.
+func (c *Checker) Add(name string, check Check) {
+	c.checks[name] = check
+}
+
+// Drain makes the readiness probe fail from now on, so that the API stops receiving requests before it shuts down.
+func (c *Checker) Drain() {
+	c.draining.Store(true)
+}
+
+// Ready runs the checks concurrently and reports whether they all passed.
+func (c *Checker) Ready(ctx context.Context) (Report, bool) {
+	if c.draining.Load() {
+		return Report{Status: StatusDraining}, false
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		report = Report{Status: StatusReady, Checks: make(map[string]string, len(c.checks))}
+	)
+	for name, check := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			status := StatusOK
+			if err := check(ctx); err != nil {
+				c.log.WarnContext(ctx, "readiness check failed", slog.String("check", name), slog.Any("ERROR", err))
+				status = StatusUnavailable
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = status
+			if status != StatusOK {
+				report.Status = StatusNotReady
+			}
+		}()
+	}
+	wg.Wait()
+
+	return report, report.Status == StatusReady
+}
+
+// ReadyHandler serves the readiness probe, responding with 503 Service Unavailable unless the API is ready.
+func (c *Checker) ReadyHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report, ready := c.Ready(r.Context())
+		if !ready {
+			render.JSON(w, r, http.StatusServiceUnavailable, report)
+			return
+		}
+
+		render.JSON(w, r, http.StatusOK, report)
+	})
+}
+
+// LiveHandler serves the liveness probe, which succeeds as long as the API serves requests, even while draining
+// or when its dependencies fail, so that it is not restarted for a problem a restart would not solve.
+func LiveHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		render.JSON(w, r, http.StatusOK, Report{Status: StatusOK})
+	})
+}
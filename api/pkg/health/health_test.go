@@ -0,0 +1,129 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+	"workflow-code-test/api/pkg/health"
+
+	"github.com/stretchr/testify/require"
+)
+
+var logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// probe serves a request to the handler and decodes its report.
+func probe(t *testing.T, handler http.Handler) (int, health.Report) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	var report health.Report
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+
+	return rec.Code, report
+}
+
+func TestLiveHandler(t *testing.T) {
+	code, report := probe(t, health.LiveHandler())
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, health.Report{Status: health.StatusOK}, report)
+}
+
+func TestReadyHandler(t *testing.T) {
+	var dbErr error
+	checker := health.NewChecker(time.Second, logger)
+	checker.Add("database", func(context.Context) error { return dbErr })
+	checker.Add("upstream", func(context.Context) error { return nil })
+
+	code, report := probe(t, checker.ReadyHandler())
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, health.Report{
+		Status: health.StatusReady,
+		Checks: map[string]string{"database": health.StatusOK, "upstream": health.StatusOK},
+	}, report)
+
+	dbErr = errors.New("dial tcp 10.0.0.5:5432: connection refused")
+	code, report = probe(t, checker.ReadyHandler())
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, health.Report{
+		Status: health.StatusNotReady,
+		Checks: map[string]string{"database": health.StatusUnavailable, "upstream": health.StatusOK},
+	}, report)
+}
+
+func TestReadyTimeout(t *testing.T) {
+	checker := health.NewChecker(10*time.Millisecond, logger)
+	checker.Add("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	report, ready := checker.Ready(context.Background())
+	require.False(t, ready)
+	require.Equal(t, health.StatusUnavailable, report.Checks["slow"])
+}
+
+func TestDrain(t *testing.T) {
+	var checked atomic.Int32
+	checker := health.NewChecker(time.Second, logger)
+	checker.Add("database", func(context.Context) error {
+		checked.Add(1)
+		return nil
+	})
+
+	checker.Drain()
+
+	code, report := probe(t, checker.ReadyHandler())
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, health.Report{Status: health.StatusDraining}, report)
+	require.Zero(t, checked.Load())
+
+	code, _ = probe(t, health.LiveHandler())
+	require.Equal(t, http.StatusOK, code)
+}
+
+func TestCached(t *testing.T) {
+	var calls atomic.Int32
+	check := health.Cached(func(context.Context) error {
+		if calls.Add(1) == 1 {
+			return errors.New("unreachable")
+		}
+		return nil
+	}, 50*time.Millisecond)
+
+	require.Error(t, check(context.Background()))
+	require.Error(t, check(context.Background()))
+	require.Equal(t, int32(1), calls.Load())
+
+	time.Sleep(60 * time.Millisecond)
+	require.NoError(t, check(context.Background()))
+	require.NoError(t, check(context.Background()))
+	require.Equal(t, int32(2), calls.Load())
+}
+
+func TestHTTPCheck(t *testing.T) {
+	var method string
+	status := http.StatusNotFound
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+		w.WriteHeader(status)
+	}))
+
+	check := health.HTTPCheck(server.Client(), server.URL)
+	require.NoError(t, check(context.Background()))
+	require.Equal(t, http.MethodHead, method)
+
+	status = http.StatusBadGateway
+	require.ErrorContains(t, check(context.Background()), "502 Bad Gateway")
+
+	server.Close()
+	require.ErrorContains(t, check(context.Background()), "failed to reach")
+}
@@ -0,0 +1,114 @@
+// Package logging builds the structured logger of the API. Records logged with a context are annotated with the
+// request and execution they belong to, and the values of sensitive attributes are redacted.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"workflow-code-test/api/pkg/nodes/types"
+)
+
+// Formats records are written in.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Keys of the attributes added from the context of a record.
+const (
+	RequestIDKey   = "request_id"
+	ExecutionIDKey = "execution_id"
+)
+
+// Redacted replaces the values of sensitive attributes.
+const Redacted = "[REDACTED]"
+
+// Options configure the logger built by New.
+type Options struct {
+	// Level is the minimum level logged, such as debug, info, warn or error. Defaults to info.
+	Level string
+	// Format is FormatText or FormatJSON. Defaults to FormatText.
+	Format string
+	// Writer receives the records, os.Stdout if nil.
+	Writer io.Writer
+	// RedactKeys are the attribute keys whose values are redacted, at any depth. Keys match regardless of
+	// case, underscores and dashes, and as a suffix, so "email" also redacts "userEmail".
+	RedactKeys []string
+}
+
+// New returns a logger writing records as configured.
+func New(opts *Options) (*slog.Logger, error) {
+	var level slog.Level
+	if opts.Level != "" {
+		if err := level.UnmarshalText([]byte(opts.Level)); err != nil {
+			return nil, fmt.Errorf("unknown log level %q: %w", opts.Level, err)
+		}
+	}
+
+	writer := opts.Writer
+	if writer == nil {
+		writer = os.Stdout
+	}
+
+	handlerOpts := &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: newRedactor(opts.RedactKeys).replaceAttr,
+	}
+
+	var handler slog.Handler
+	switch opts.Format {
+	case FormatText, "":
+		handler = slog.NewTextHandler(writer, handlerOpts)
+	case FormatJSON:
+		handler = slog.NewJSONHandler(writer, handlerOpts)
+	default:
+		return nil, fmt.Errorf("unknown log format %q, expected %s or %s", opts.Format, FormatText, FormatJSON)
+	}
+
+	return slog.New(&contextHandler{Handler: handler}), nil
+}
+
+type contextKey int
+
+const requestIDKey contextKey = iota
+
+// WithRequestID returns a context carrying the ID correlating the logs of a request.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestID returns the ID correlating the logs of the request, or "" if there is none.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// contextHandler adds the IDs of the request and the execution found in the context of records.
+type contextHandler struct {
+	slog.Handler
+}
+
+// Handle implements slog.Handler.
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		r.AddAttrs(slog.String(RequestIDKey, id))
+	}
+	if id := types.ExecutionID(ctx); id != "" {
+		r.AddAttrs(slog.String(ExecutionIDKey, id))
+	}
+
+	return h.Handler.Handle(ctx, r)
+}
+
+// WithAttrs implements slog.Handler.
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup implements slog.Handler.
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
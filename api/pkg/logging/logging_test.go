@@ -0,0 +1,131 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"workflow-code-test/api/pkg/logging"
+	"workflow-code-test/api/pkg/nodes/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+// records decodes the JSON records written to out.
+func records(t *testing.T, out *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if line == "" {
+			continue
+		}
+
+		var record map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func TestNewLevel(t *testing.T) {
+	var out bytes.Buffer
+	logger, err := logging.New(&logging.Options{Level: "warn", Format: logging.FormatJSON, Writer: &out})
+	require.NoError(t, err)
+
+	logger.Info("ignored")
+	logger.Warn("kept")
+
+	logged := records(t, &out)
+	require.Len(t, logged, 1)
+	require.Equal(t, "kept", logged[0]["msg"])
+	require.Equal(t, "WARN", logged[0]["level"])
+}
+
+func TestNewText(t *testing.T) {
+	var out bytes.Buffer
+	logger, err := logging.New(&logging.Options{Writer: &out})
+	require.NoError(t, err)
+
+	logger.Debug("ignored")
+	logger.Info("kept", "key", "value")
+
+	require.Contains(t, out.String(), "level=INFO msg=kept key=value")
+	require.NotContains(t, out.String(), "ignored")
+}
+
+func TestNewInvalid(t *testing.T) {
+	_, err := logging.New(&logging.Options{Level: "loud"})
+	require.ErrorContains(t, err, `unknown log level "loud"`)
+
+	_, err = logging.New(&logging.Options{Format: "xml"})
+	require.ErrorContains(t, err, `unknown log format "xml"`)
+}
+
+func TestContextIDs(t *testing.T) {
+	var out bytes.Buffer
+	logger, err := logging.New(&logging.Options{Format: logging.FormatJSON, Writer: &out})
+	require.NoError(t, err)
+
+	ctx := logging.WithRequestID(context.Background(), "req-1")
+	logger.InfoContext(ctx, "request")
+
+	ctx = types.WithExecutionID(ctx, "exec-1")
+	logger.With("component", "engine").InfoContext(ctx, "execution")
+
+	logger.Info("no context")
+
+	logged := records(t, &out)
+	require.Len(t, logged, 3)
+	require.Equal(t, "req-1", logged[0][logging.RequestIDKey])
+	require.NotContains(t, logged[0], logging.ExecutionIDKey)
+
+	require.Equal(t, "engine", logged[1]["component"])
+	require.Equal(t, "req-1", logged[1][logging.RequestIDKey])
+	require.Equal(t, "exec-1", logged[1][logging.ExecutionIDKey])
+
+	require.NotContains(t, logged[2], logging.RequestIDKey)
+	require.Equal(t, "req-1", logging.RequestID(ctx))
+}
+
+func TestRedact(t *testing.T) {
+	var out bytes.Buffer
+	logger, err := logging.New(&logging.Options{
+		Format:     logging.FormatJSON,
+		Writer:     &out,
+		RedactKeys: []string{"password", "api_key", "email"},
+	})
+	require.NoError(t, err)
+
+	input := map[string]any{
+		"name":      "Alice",
+		"userEmail": "alice@example.com",
+		"nested": map[string]any{
+			"API-KEY": "abc",
+			"list":    []any{map[string]any{"email": "bob@example.com", "city": "Sydney"}},
+		},
+		"headers": map[string]string{"Password": "hunter2", "Accept": "*/*"},
+	}
+	logger.Info("node input", slog.Any("input", input), slog.String("password", "hunter2"),
+		slog.Group("user", slog.String("email", "alice@example.com"), slog.Bool("emailSent", true)))
+
+	logged := records(t, &out)
+	require.Len(t, logged, 1)
+	require.Equal(t, logging.Redacted, logged[0]["password"])
+	require.Equal(t, map[string]any{"email": logging.Redacted, "emailSent": true}, logged[0]["user"])
+	require.Equal(t, map[string]any{
+		"name":      "Alice",
+		"userEmail": logging.Redacted,
+		"nested": map[string]any{
+			"API-KEY": logging.Redacted,
+			"list":    []any{map[string]any{"email": logging.Redacted, "city": "Sydney"}},
+		},
+		"headers": map[string]any{"Password": logging.Redacted, "Accept": "*/*"},
+	}, logged[0]["input"])
+
+	// The logged value is redacted in a copy
+	require.Equal(t, "alice@example.com", input["userEmail"])
+}
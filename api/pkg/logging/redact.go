@@ -0,0 +1,89 @@
+package logging
+
+import (
+	"log/slog"
+	"strings"
+)
+
+// redactor redacts the values of the attributes whose keys are sensitive.
+type redactor struct {
+	keys []string
+}
+
+func newRedactor(keys []string) *redactor {
+	r := &redactor{}
+	for _, key := range keys {
+		if key = normalizeKey(key); key != "" {
+			r.keys = append(r.keys, key)
+		}
+	}
+
+	return r
+}
+
+// replaceAttr is the slog.HandlerOptions.ReplaceAttr of the logger. Maps and slices logged as a single
+// attribute, such as the inputs of a node, are redacted in depth.
+func (r *redactor) replaceAttr(_ []string, a slog.Attr) slog.Attr {
+	if len(r.keys) == 0 {
+		return a
+	}
+
+	if r.sensitive(a.Key) {
+		return slog.String(a.Key, Redacted)
+	}
+	if a.Value.Kind() == slog.KindAny {
+		a.Value = slog.AnyValue(r.redact(a.Value.Any()))
+	}
+
+	return a
+}
+
+// redact returns a copy of the value with the values of sensitive keys redacted, or the value itself if it
+// holds no map.
+func (r *redactor) redact(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		redacted := make(map[string]any, len(v))
+		for key, value := range v {
+			if r.sensitive(key) {
+				redacted[key] = Redacted
+				continue
+			}
+			redacted[key] = r.redact(value)
+		}
+		return redacted
+	case map[string]string:
+		redacted := make(map[string]string, len(v))
+		for key, value := range v {
+			if r.sensitive(key) {
+				value = Redacted
+			}
+			redacted[key] = value
+		}
+		return redacted
+	case []any:
+		redacted := make([]any, len(v))
+		for i, value := range v {
+			redacted[i] = r.redact(value)
+		}
+		return redacted
+	default:
+		return value
+	}
+}
+
+func (r *redactor) sensitive(key string) bool {
+	key = normalizeKey(key)
+	for _, sensitive := range r.keys {
+		if strings.HasSuffix(key, sensitive) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalizeKey lowercases the key and removes its underscores and dashes, so "API_KEY" and "apiKey" match.
+func normalizeKey(key string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+}
@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/felixge/httpsnoop"
+	"github.com/gorilla/mux"
+)
+
+// Middleware counts and times the requests served by a router, by route template rather than path so that
+// the number of series stays bounded, e.g. "/api/v1/workflows/{id}".
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// httpsnoop keeps the interfaces of w, such as http.Flusher for event streams
+		m := httpsnoop.CaptureMetrics(next, w, r)
+
+		route := routeTemplate(r)
+		httpRequests.WithLabelValues(r.Method, route, strconv.Itoa(m.Code)).Inc()
+		httpRequestDuration.WithLabelValues(r.Method, route).Observe(m.Duration.Seconds())
+	})
+}
+
+// InstrumentTransport times the requests sent through next to the external service named client, and counts
+// those failing or answered with a status of 400 or more as errors.
+func InstrumentTransport(client string, next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		start := time.Now()
+		resp, err := next.RoundTrip(r)
+		clientRequestDuration.WithLabelValues(client).Observe(time.Since(start).Seconds())
+
+		if err != nil || resp.StatusCode >= http.StatusBadRequest {
+			clientErrors.WithLabelValues(client).Inc()
+		}
+
+		return resp, err
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func routeTemplate(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			return template
+		}
+	}
+
+	return "unmatched"
+}
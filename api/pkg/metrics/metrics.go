@@ -0,0 +1,74 @@
+// Package metrics exposes the metrics of the API to Prometheus: HTTP requests, executions, nodes and calls to
+// external services, along with the metrics of the Go runtime and of the components registered on Registry.
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Registry holds the metrics served by Handler. Components with metrics of their own, such as the database pool,
+// register their collectors on it.
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests served, by method, route and status code.",
+	}, []string{"method", "route", "code"})
+
+	httpRequestDuration = promauto.With(Registry).NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Time taken to serve HTTP requests, by method and route.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	executions = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Name: "workflow_executions_total",
+		Help: "Executions finished, by workflow and status. Dry runs are not counted.",
+	}, []string{"workflow_id", "status"})
+
+	nodeDuration = promauto.With(Registry).NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "workflow_node_duration_seconds",
+		Help:    "Time taken to run nodes, by node type and step status. Dry runs are not observed.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"kind", "status"})
+
+	clientRequestDuration = promauto.With(Registry).NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_client_request_duration_seconds",
+		Help:    "Time taken by the requests sent to external services, by client.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"client"})
+
+	clientErrors = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Name: "http_client_errors_total",
+		Help: "Requests sent to external services that failed or were answered with an error status, by client.",
+	}, []string{"client"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Handler serves the metrics of Registry in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// ExecutionFinished counts an execution of the workflow that finished with the status.
+func ExecutionFinished(workflowID, status string) {
+	executions.WithLabelValues(workflowID, status).Inc()
+}
+
+// NodeExecuted observes the time taken to run a node of the kind, which ended with the step status.
+func NodeExecuted(kind, status string, duration time.Duration) {
+	nodeDuration.WithLabelValues(kind, status).Observe(duration.Seconds())
+}
@@ -0,0 +1,119 @@
+package metrics_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"workflow-code-test/api/pkg/metrics"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// scrape returns the metrics served by the handler in the text exposition format.
+func scrape(t *testing.T) string {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	return rec.Body.String()
+}
+
+func TestMiddleware(t *testing.T) {
+	router := mux.NewRouter()
+	router.Use(metrics.Middleware)
+	router.HandleFunc("/middleware/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}).Methods(http.MethodPost)
+
+	for _, id := range []string{"a", "b"} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/middleware/"+id, nil))
+		require.Equal(t, http.StatusAccepted, rec.Code)
+	}
+
+	out := scrape(t)
+	require.Contains(t, out, `http_requests_total{code="202",method="POST",route="/middleware/{id}"} 2`)
+	require.Contains(t, out, `http_request_duration_seconds_count{method="POST",route="/middleware/{id}"} 2`)
+}
+
+func TestMiddlewareFlushes(t *testing.T) {
+	router := mux.NewRouter()
+	router.Use(metrics.Middleware)
+	router.HandleFunc("/stream", func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		require.True(t, ok)
+		flusher.Flush()
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stream", nil))
+	require.True(t, rec.Flushed)
+}
+
+func TestExecutionFinished(t *testing.T) {
+	metrics.ExecutionFinished("wf-finished", "completed")
+	metrics.ExecutionFinished("wf-finished", "completed")
+	metrics.ExecutionFinished("wf-finished", "failed")
+
+	out := scrape(t)
+	require.Contains(t, out, `workflow_executions_total{status="completed",workflow_id="wf-finished"} 2`)
+	require.Contains(t, out, `workflow_executions_total{status="failed",workflow_id="wf-finished"} 1`)
+}
+
+func TestNodeExecuted(t *testing.T) {
+	metrics.NodeExecuted("node-executed", "completed", 200*time.Millisecond)
+	metrics.NodeExecuted("node-executed", "completed", 2*time.Second)
+
+	out := scrape(t)
+	require.Contains(t, out, `workflow_node_duration_seconds_bucket{kind="node-executed",status="completed",le="0.25"} 1`)
+	require.Contains(t, out, `workflow_node_duration_seconds_bucket{kind="node-executed",status="completed",le="2.5"} 2`)
+	require.Contains(t, out, `workflow_node_duration_seconds_sum{kind="node-executed",status="completed"} 2.2`)
+}
+
+func TestInstrumentTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: metrics.InstrumentTransport("transport-test", http.DefaultTransport)}
+	for _, path := range []string{"/", "/missing"} {
+		resp, err := client.Get(server.URL + path)
+		require.NoError(t, err)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		require.NoError(t, resp.Body.Close())
+	}
+
+	failing := &http.Client{Transport: metrics.InstrumentTransport("transport-test", roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	}))}
+	_, err := failing.Get(server.URL)
+	require.ErrorContains(t, err, "connection refused")
+
+	out := scrape(t)
+	require.Contains(t, out, `http_client_request_duration_seconds_count{client="transport-test"} 3`)
+	require.Contains(t, out, `http_client_errors_total{client="transport-test"} 2`)
+}
+
+func TestRegistry(t *testing.T) {
+	problems, err := testutil.GatherAndLint(metrics.Registry)
+	require.NoError(t, err)
+	require.Empty(t, problems)
+
+	require.Contains(t, scrape(t), "go_goroutines")
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
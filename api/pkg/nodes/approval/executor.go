@@ -0,0 +1,100 @@
+package approval
+
+import (
+	"context"
+	"fmt"
+	"workflow-code-test/api/pkg/nodes"
+	"workflow-code-test/api/pkg/nodes/email"
+	"workflow-code-test/api/pkg/nodes/form"
+	"workflow-code-test/api/pkg/nodes/types"
+)
+
+const (
+	MessageKey string = "message"
+	FieldsKey  string = form.FieldsKey
+
+	// DecisionOutput is the output variable set to whether the execution was approved.
+	DecisionOutput string = "approved"
+)
+
+// Descriptor is the contract of the approval node kind.
+var Descriptor = types.Descriptor{
+	Kind:        "approval",
+	Description: "Pauses the execution until a person approves or rejects it, optionally with extra form data.",
+	Outputs: []types.Property{
+		{Name: DecisionOutput, Type: types.ValueTypeBoolean, Description: "Whether the execution was approved. Routes along the true or false handle."},
+	},
+	Metadata: []types.Property{
+		{Name: MessageKey, Type: types.ValueTypeString, Description: "Message shown to the approver, with {{key}} placeholders."},
+		{Name: FieldsKey, Type: types.ValueTypeArray, Description: "Fields of the form data requested on resume, validated like the form node's fields."},
+	},
+}
+
+func init() {
+	nodes.Register(Descriptor.Kind, func() types.NodeExecutor { return &Executor{} }, Descriptor)
+}
+
+// Executor suspends the execution until it is approved or rejected through the resume API.
+// The decision and the submitted form data become the node outputs when the execution resumes.
+type Executor struct {
+	args         map[string]any
+	outputFields []string
+}
+
+func (e *Executor) SetArgs(args map[string]any) {
+	e.args = args
+}
+
+func (e *Executor) SetOutputFields(fields []string) {
+	e.outputFields = fields
+}
+
+// ValidateAndParse implements NodeExecutor.
+func (e *Executor) ValidateAndParse(argsCheck []string) error {
+	if err := Descriptor.Validate(e.args, argsCheck); err != nil {
+		return err
+	}
+
+	if _, err := form.ParseFields(e.args[FieldsKey]); err != nil {
+		return fmt.Errorf("%s: validation failed to parse fields: %w", e.ID(), err)
+	}
+
+	return nil
+}
+
+// ID implements NodeExecutor.
+func (e *Executor) ID() string {
+	return "approval"
+}
+
+// ResolveOutputs implements types.OutputResolver.
+// The approval outputs its decision along with the fields requested on resume.
+func (e *Executor) ResolveOutputs(metadata map[string]any) ([]types.Property, error) {
+	fields, err := form.ParseFields(metadata[FieldsKey])
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := append([]types.Property{}, Descriptor.Outputs...)
+	for _, field := range fields {
+		outputs = append(outputs, types.Property{
+			Name:     field.Name,
+			Type:     types.ValueType(field.Type),
+			Required: field.Required,
+		})
+	}
+
+	return outputs, nil
+}
+
+func (e *Executor) Execute(ctx context.Context) (any, error) {
+	message, _ := e.args[MessageKey].(string)
+
+	return &types.Suspension{
+		WaitingFor: &types.Wait{
+			Message:  email.TmplReplacePlaceholderByMap(message, e.args),
+			Fields:   e.args[FieldsKey],
+			Decision: DecisionOutput,
+		},
+	}, nil
+}
@@ -0,0 +1,94 @@
+package approval_test
+
+import (
+	"context"
+	"testing"
+	"workflow-code-test/api/pkg/nodes/approval"
+	"workflow-code-test/api/pkg/nodes/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		name             string
+		args             map[string]any
+		expectedWait     *types.Wait
+		expectedErrorMsg string
+	}{
+		{
+			name: "message with placeholders",
+			args: map[string]any{
+				approval.MessageKey: "Send the alert to {{name}}?",
+				"name":              "Alice",
+			},
+			expectedWait: &types.Wait{
+				Message:  "Send the alert to Alice?",
+				Decision: approval.DecisionOutput,
+			},
+		},
+		{
+			name: "requested fields",
+			args: map[string]any{
+				approval.FieldsKey: []any{"reason"},
+			},
+			expectedWait: &types.Wait{
+				Fields:   []any{"reason"},
+				Decision: approval.DecisionOutput,
+			},
+		},
+		{
+			name: "invalid fields",
+			args: map[string]any{
+				approval.FieldsKey: []any{1},
+			},
+			expectedErrorMsg: "validation failed to parse fields",
+		},
+		{
+			name: "message of the wrong type",
+			args: map[string]any{
+				approval.MessageKey: 1,
+			},
+			expectedErrorMsg: "validation failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := &approval.Executor{}
+			executor.SetArgs(tt.args)
+			executor.SetOutputFields([]string{approval.DecisionOutput})
+
+			err := executor.ValidateAndParse(nil)
+			if tt.expectedErrorMsg != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.expectedErrorMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			outputs, err := executor.Execute(context.Background())
+			require.NoError(t, err)
+
+			suspension, ok := outputs.(*types.Suspension)
+			require.True(t, ok)
+			require.True(t, suspension.ResumeAt.IsZero())
+			require.Equal(t, tt.expectedWait, suspension.WaitingFor)
+		})
+	}
+}
+
+func TestResolveOutputs(t *testing.T) {
+	executor := &approval.Executor{}
+
+	outputs, err := executor.ResolveOutputs(map[string]any{
+		approval.FieldsKey: []any{
+			map[string]any{"name": "note", "type": "string"},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []types.Property{
+		approval.Descriptor.Outputs[0],
+		{Name: "note", Type: types.ValueTypeString},
+	}, outputs)
+}
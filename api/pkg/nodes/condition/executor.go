@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 
+	"workflow-code-test/api/pkg/nodes"
+	"workflow-code-test/api/pkg/nodes/types"
+
 	"github.com/expr-lang/expr"
 )
 
@@ -12,9 +15,29 @@ const (
 	OperatorKey   string = "operator"
 )
 
+// Descriptor is the contract of the condition node kind.
+var Descriptor = types.Descriptor{
+	Kind:        "condition",
+	Description: "Evaluates an expression with {{key}} placeholders and routes on the boolean result.",
+	Inputs: []types.Property{
+		{Name: OperatorKey, Type: types.ValueTypeString, Required: true, Description: "Comparison operator substituted for {{operator}}."},
+	},
+	Outputs: []types.Property{
+		{Name: "result", Type: types.ValueTypeBoolean, Description: "Result of the expression."},
+	},
+	Metadata: []types.Property{
+		{Name: ExpressionKey, Type: types.ValueTypeString, Required: true, Description: "Expression to evaluate."},
+	},
+}
+
+func init() {
+	nodes.Register(Descriptor.Kind, func() types.NodeExecutor { return &Executor{} }, Descriptor)
+}
+
 type Executor struct {
 	args         map[string]any
 	outputFields []string
+	expression   string
 }
 
 func (e *Executor) SetArgs(args map[string]any) {
@@ -26,25 +49,21 @@ func (e *Executor) SetOutputFields(fields []string) {
 }
 
 func (e *Executor) ValidateAndParse(argsCheck []string) error {
-	_, ok := e.args[ExpressionKey].(string)
-	if !ok {
-		return fmt.Errorf("%s: validation failed to get expression where it should string", e.ID())
+	if err := Descriptor.Validate(e.args, argsCheck); err != nil {
+		return err
 	}
 
 	operator, ok := e.args[OperatorKey].(string)
 	if !ok {
-		return fmt.Errorf("%s: validation failed to get operator where it should &Operator", e.ID())
+		return fmt.Errorf("%s: validation failed, %s must be string, got %s", e.ID(), OperatorKey, types.TypeOf(e.args[OperatorKey]))
 	}
-
 	if err := Operator(operator).Validate(); err != nil {
 		return fmt.Errorf("%s: validation failed to validate operator: %w", e.ID(), err)
 	}
 
-	for _, key := range argsCheck {
-		_, ok := e.args[key].(string)
-		if !ok {
-			return fmt.Errorf("%s: validation key failed, key: %v", e.ID(), key)
-		}
+	e.expression, ok = e.args[ExpressionKey].(string)
+	if !ok {
+		return fmt.Errorf("%s: validation failed, %s must be string, got %s", e.ID(), ExpressionKey, types.TypeOf(e.args[ExpressionKey]))
 	}
 
 	return nil
@@ -56,16 +75,14 @@ func (e *Executor) ID() string {
 }
 
 func (e *Executor) Execute(ctx context.Context) (any, error) {
-	rawExprString := e.args[ExpressionKey].(string)
-
-	exprString := ExprReplacePlaceholderByMap(rawExprString, e.args)
+	exprString := ExprReplacePlaceholderByMap(e.expression, e.args)
 
-	program, err := expr.Compile(exprString)
+	program, err := expr.Compile(exprString, expr.Env(e.args))
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to compile expression: %w", e.ID(), err)
 	}
 
-	output, err := expr.Run(program, nil)
+	output, err := expr.Run(program, e.args)
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to run expression: %w", e.ID(), err)
 	}
@@ -111,3 +111,44 @@ func TestConditionExecute(t *testing.T) {
 		})
 	}
 }
+
+func TestConditionValidateAndParse(t *testing.T) {
+	tests := []struct {
+		name             string
+		args             map[string]any
+		expectedErrorMsg string
+	}{
+		{
+			name:             "null operator",
+			args:             map[string]any{"conditionExpression": "{{temperature}} {{operator}} 25", "operator": nil},
+			expectedErrorMsg: "operator is required",
+		},
+		{
+			name:             "null expression",
+			args:             map[string]any{"conditionExpression": nil, "operator": string(condition.GreaterThanOperator)},
+			expectedErrorMsg: "conditionExpression is required",
+		},
+		{
+			name:             "operator of the wrong type",
+			args:             map[string]any{"conditionExpression": "{{temperature}} {{operator}} 25", "operator": 1.0},
+			expectedErrorMsg: "operator must be string, got number",
+		},
+		{
+			name:             "unknown operator",
+			args:             map[string]any{"conditionExpression": "{{temperature}} {{operator}} 25", "operator": "between"},
+			expectedErrorMsg: "failed to validate operator",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := condition.Executor{}
+			e.SetArgs(tt.args)
+			e.SetOutputFields([]string{"conditionMet"})
+
+			err := e.ValidateAndParse([]string{})
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.expectedErrorMsg)
+		})
+	}
+}
@@ -9,7 +9,9 @@ import (
 // conditionExpression is the template string containing placeholders to be replaced.
 // args is a map containing key-value pairs where keys match placeholder names.
 // Special handling is applied for OperatorKey values which are converted using ToExpr().
-// Returns the expression with all matching placeholders replaced by their corresponding values.
+// Other placeholders are replaced by a reference to the variable in the expression environment,
+// so values keep their type and numbers are not compared as text.
+// Returns the expression with all matching placeholders replaced.
 func ExprReplacePlaceholderByMap(conditionExpression string, args map[string]any) string {
 	result := conditionExpression
 
@@ -21,7 +23,7 @@ func ExprReplacePlaceholderByMap(conditionExpression string, args map[string]any
 			op := Operator(value.(string))
 			replacement = fmt.Sprintf("%v", op.ToExpr())
 		} else {
-			replacement = fmt.Sprintf("%v", value)
+			replacement = fmt.Sprintf("$env[%q]", key)
 		}
 		result = strings.ReplaceAll(result, placeholder, replacement)
 	}
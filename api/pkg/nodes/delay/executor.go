@@ -0,0 +1,132 @@
+package delay
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"workflow-code-test/api/pkg/nodes"
+	"workflow-code-test/api/pkg/nodes/types"
+
+	"github.com/expr-lang/expr"
+)
+
+const (
+	DurationKey string = "duration"
+	UntilKey    string = "until"
+)
+
+// Descriptor is the contract of the delay node kind.
+var Descriptor = types.Descriptor{
+	Kind:        "delay",
+	Description: "Pauses the execution for a fixed duration or until a point in time, then resumes it.",
+	Outputs: []types.Property{
+		{Name: "resumeAt", Type: types.ValueTypeString, Description: "RFC 3339 time the execution resumes at."},
+	},
+	Metadata: []types.Property{
+		{Name: DurationKey, Type: types.ValueTypeString, Description: "Fixed delay, e.g. 2h or 30m."},
+		{Name: UntilKey, Type: types.ValueTypeString, Description: "Expression evaluating to the time to resume at, e.g. now() + duration(\"2h\")."},
+	},
+}
+
+func init() {
+	nodes.Register(Descriptor.Kind, func() types.NodeExecutor { return &Executor{} }, Descriptor)
+}
+
+// Executor suspends the execution until a fixed duration elapsed or a point in time is reached.
+// The engine persists the suspended execution, so the delay survives API restarts.
+type Executor struct {
+	args         map[string]any
+	outputFields []string
+	duration     time.Duration
+	until        string
+}
+
+func (e *Executor) SetArgs(args map[string]any) {
+	e.args = args
+}
+
+func (e *Executor) SetOutputFields(fields []string) {
+	e.outputFields = fields
+}
+
+// ValidateAndParse implements NodeExecutor.
+func (e *Executor) ValidateAndParse(argsCheck []string) error {
+	if err := Descriptor.Validate(e.args, argsCheck); err != nil {
+		return err
+	}
+
+	rawDuration, hasDuration := e.args[DurationKey].(string)
+	until, hasUntil := e.args[UntilKey].(string)
+
+	switch {
+	case hasDuration && hasUntil:
+		return fmt.Errorf("%s: validation failed, only one of %s and %s can be set", e.ID(), DurationKey, UntilKey)
+
+	case hasDuration:
+		duration, err := time.ParseDuration(rawDuration)
+		if err != nil {
+			return fmt.Errorf("%s: validation failed to parse duration: %w", e.ID(), err)
+		}
+		if duration < 0 {
+			return fmt.Errorf("%s: validation failed, duration must not be negative", e.ID())
+		}
+		e.duration = duration
+
+	case hasUntil:
+		e.until = until
+
+	default:
+		return fmt.Errorf("%s: validation failed, one of %s and %s is required", e.ID(), DurationKey, UntilKey)
+	}
+
+	return nil
+}
+
+// ID implements NodeExecutor.
+func (e *Executor) ID() string {
+	return "delay"
+}
+
+func (e *Executor) Execute(ctx context.Context) (any, error) {
+	resumeAt := time.Now().Add(e.duration)
+	if e.until != "" {
+		var err error
+		resumeAt, err = e.evalUntil()
+		if err != nil {
+			return nil, err
+		}
+	}
+	resumeAt = resumeAt.UTC().Truncate(time.Second)
+
+	result := map[string]any{}
+	for _, field := range e.outputFields {
+		result[field] = resumeAt.Format(time.RFC3339)
+	}
+
+	return &types.Suspension{
+		ResumeAt: resumeAt,
+		Output:   result,
+	}, nil
+}
+
+// evalUntil evaluates the until expression against the execution variables.
+// The expression evaluates either to a time or to an RFC 3339 string.
+func (e *Executor) evalUntil() (time.Time, error) {
+	output, err := expr.Eval(e.until, e.args)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: failed to evaluate until: %w", e.ID(), err)
+	}
+
+	switch v := output.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%s: failed to parse until: %w", e.ID(), err)
+		}
+		return t, nil
+	default:
+		return time.Time{}, fmt.Errorf("%s: until must evaluate to a time, got %T", e.ID(), output)
+	}
+}
@@ -0,0 +1,92 @@
+package delay_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"workflow-code-test/api/pkg/nodes/delay"
+	"workflow-code-test/api/pkg/nodes/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		name             string
+		args             map[string]any
+		expectedDelay    time.Duration
+		expectedResumeAt time.Time
+		expectedErrorMsg string
+	}{
+		{
+			name:          "fixed duration",
+			args:          map[string]any{delay.DurationKey: "2h"},
+			expectedDelay: 2 * time.Hour,
+		},
+		{
+			name:          "until relative expression",
+			args:          map[string]any{delay.UntilKey: `now() + duration("30m")`},
+			expectedDelay: 30 * time.Minute,
+		},
+		{
+			name: "until timestamp variable",
+			args: map[string]any{
+				delay.UntilKey: "deadline",
+				"deadline":     "2030-01-02T15:04:05Z",
+			},
+			expectedResumeAt: time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC),
+		},
+		{
+			name:             "missing configuration",
+			args:             map[string]any{},
+			expectedErrorMsg: "one of duration and until is required",
+		},
+		{
+			name:             "both duration and until",
+			args:             map[string]any{delay.DurationKey: "1h", delay.UntilKey: "now()"},
+			expectedErrorMsg: "only one of duration and until can be set",
+		},
+		{
+			name:             "invalid duration",
+			args:             map[string]any{delay.DurationKey: "two hours"},
+			expectedErrorMsg: "failed to parse duration",
+		},
+		{
+			name:             "until of the wrong type",
+			args:             map[string]any{delay.UntilKey: "1 + 1"},
+			expectedErrorMsg: "until must evaluate to a time",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := &delay.Executor{}
+			executor.SetArgs(tt.args)
+			executor.SetOutputFields([]string{"resumeAt"})
+
+			before := time.Now()
+			err := executor.ValidateAndParse(nil)
+			if err == nil {
+				var outputs any
+				outputs, err = executor.Execute(context.Background())
+				if tt.expectedErrorMsg == "" {
+					require.NoError(t, err)
+
+					suspension, ok := outputs.(*types.Suspension)
+					require.True(t, ok)
+
+					expected := tt.expectedResumeAt
+					if expected.IsZero() {
+						expected = before.Add(tt.expectedDelay)
+					}
+					require.WithinDuration(t, expected, suspension.ResumeAt, 2*time.Second)
+					require.Equal(t, suspension.ResumeAt.Format(time.RFC3339), suspension.Output["resumeAt"])
+					return
+				}
+			}
+
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.expectedErrorMsg)
+		})
+	}
+}
@@ -4,12 +4,28 @@ import (
 	"context"
 	"fmt"
 	"workflow-code-test/api/pkg/mailer"
+	"workflow-code-test/api/pkg/nodes/types"
 )
 
 const (
 	TemplateKey string = "emailTemplate"
 )
 
+// Descriptor is the contract of the email node kind.
+var Descriptor = types.Descriptor{
+	Kind:        "email",
+	Description: "Sends an email rendered from a template with {{key}} placeholders.",
+	Inputs: []types.Property{
+		{Name: "email", Type: types.ValueTypeString, Required: true, Description: "Recipient email address."},
+	},
+	Outputs: []types.Property{
+		{Name: "emailSent", Type: types.ValueTypeBoolean, Description: "Whether the email was sent."},
+	},
+	Metadata: []types.Property{
+		{Name: TemplateKey, Type: types.ValueTypeObject, Required: true, Description: "Template with subject and body."},
+	},
+}
+
 type Options struct {
 	MailClient mailer.Client
 }
@@ -32,11 +48,8 @@ func (e *Executor) SetOutputFields(fields []string) {
 
 // ValidateAndParse implements nodes.NodeExecutor.
 func (e *Executor) ValidateAndParse(argsCheck []string) error {
-	for _, key := range argsCheck {
-		_, ok := e.args[key].(string)
-		if !ok {
-			return fmt.Errorf("%s: validation key failed, key: %v", e.ID(), key)
-		}
+	if err := Descriptor.Validate(e.args, argsCheck); err != nil {
+		return err
 	}
 
 	tmpl, ok := e.args[TemplateKey].(map[string]any)
@@ -64,20 +77,47 @@ func (e *Executor) ID() string {
 }
 
 func (e *Executor) Execute(ctx context.Context) (any, error) {
-	err := e.Opts.MailClient.Send(
-		e.args["email"].(string),
-		e.tmpl["subject"].(string),
-		TmplReplacePlaceholderByMap(
-			e.tmpl["body"].(string),
-			e.args,
-		),
-	)
+	to, subject, body := e.render()
+
+	err := e.Opts.MailClient.Send(to, subject, body)
 	if err != nil {
 		return map[string]any{
 			"emailSent": false,
 		}, fmt.Errorf("%s: failed to send email: %w", e.ID(), err)
 	}
 
+	return e.output()
+}
+
+// Simulate implements types.Simulator.
+// The email is rendered and reported rather than sent, and is assumed to be sent.
+func (e *Executor) Simulate(ctx context.Context) (*types.Simulation, error) {
+	to, subject, body := e.render()
+
+	output, err := e.output()
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.Simulation{
+		Output: output,
+		Effect: map[string]any{
+			"email": map[string]any{
+				"to":      to,
+				"subject": subject,
+				"body":    body,
+			},
+		},
+	}, nil
+}
+
+// render returns the recipient, subject and body of the email.
+func (e *Executor) render() (string, string, string) {
+	return e.args["email"].(string), e.tmpl["subject"].(string), TmplReplacePlaceholderByMap(e.tmpl["body"].(string), e.args)
+}
+
+// output returns the output of a sent email.
+func (e *Executor) output() (map[string]any, error) {
 	// Hardcoded for now to explicitly there should be one output from the mail execution
 	if len(e.outputFields) != 1 {
 		return nil, fmt.Errorf("%s: output should only contain one variable, outputs: %+v", e.ID(), e.outputFields)
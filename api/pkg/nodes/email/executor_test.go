@@ -35,3 +35,36 @@ func TestExecute(t *testing.T) {
 	result := outputs.(map[string]any)
 	require.Equal(t, true, result["emailSent"])
 }
+
+func TestSimulate(t *testing.T) {
+	executor := &email.Executor{
+		Opts: &email.Options{
+			MailClient: nil, // Simulate must not send the email
+		},
+	}
+	executor.SetArgs(map[string]any{
+		"name":        "John Doe",
+		"email":       "johndoe@example.com",
+		"city":        "New York",
+		"temperature": "25.5",
+		"emailTemplate": map[string]any{
+			"body":    "Weather alert for {{city}}! Temperature is {{temperature}}°C!",
+			"subject": "Weather Alert",
+		},
+	})
+	executor.SetOutputFields([]string{"emailSent"})
+	err := executor.ValidateAndParse([]string{"name", "email", "city", "temperature"})
+	require.NoError(t, err)
+
+	simulation, err := executor.Simulate(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]any{"emailSent": true}, simulation.Output)
+	require.Equal(t, map[string]any{
+		"email": map[string]any{
+			"to":      "johndoe@example.com",
+			"subject": "Weather Alert",
+			"body":    "Weather alert for New York! Temperature is 25.5°C!",
+		},
+	}, simulation.Effect)
+}
@@ -0,0 +1,229 @@
+package foreach
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"workflow-code-test/api/pkg/nodes/subworkflow"
+	"workflow-code-test/api/pkg/nodes/types"
+
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	ItemsKey         string = "items"
+	WorkflowIDKey    string = subworkflow.WorkflowIDKey
+	ItemVariableKey  string = "itemVariable"
+	IndexVariableKey string = "indexVariable"
+	ConcurrencyKey   string = "concurrency"
+	CollectKey       string = "collect"
+
+	DefaultItemVariable  = "item"
+	DefaultIndexVariable = "index"
+
+	// MaxConcurrency caps how many items are run at once, so a single node cannot flood the API.
+	MaxConcurrency = 10
+)
+
+// Descriptor is the contract of the foreach node kind.
+var Descriptor = types.Descriptor{
+	Kind:        "foreach",
+	Description: "Runs a stored workflow for every item of an array variable and collects the outputs of each run.",
+	Outputs: []types.Property{
+		{Name: "results", Type: types.ValueTypeArray, Description: "Outputs of the workflow for each item, in the order of the items."},
+	},
+	Metadata: []types.Property{
+		{Name: ItemsKey, Type: types.ValueTypeString, Required: true, Description: "Name of the array variable to iterate over."},
+		{Name: WorkflowIDKey, Type: types.ValueTypeString, Required: true, Description: "ID of the workflow run for each item."},
+		{Name: ItemVariableKey, Type: types.ValueTypeString, Description: "Input the item is passed as. Defaults to item."},
+		{Name: IndexVariableKey, Type: types.ValueTypeString, Description: "Input the index of the item is passed as. Defaults to index."},
+		{Name: ConcurrencyKey, Type: types.ValueTypeNumber, Description: "How many items are run at once, from 1 to 10. Defaults to 1."},
+		{Name: CollectKey, Type: types.ValueTypeArray, Description: "Workflow variables collected for each item. Defaults to the outputs of every node of the workflow."},
+	},
+}
+
+type Options struct {
+	Workflows types.WorkflowRunner
+}
+
+// Executor runs a workflow once per item of an array variable, as children of the running execution.
+// The inputVariables are passed to every run along with the item and its index.
+type Executor struct {
+	Opts *Options
+
+	args          map[string]any
+	argsCheck     []string
+	outputFields  []string
+	items         string
+	workflowID    string
+	itemVariable  string
+	indexVariable string
+	concurrency   int
+	collect       []string
+}
+
+func (e *Executor) SetArgs(args map[string]any) {
+	e.args = args
+}
+
+func (e *Executor) SetOutputFields(fields []string) {
+	e.outputFields = fields
+}
+
+// ValidateAndParse implements NodeExecutor.
+func (e *Executor) ValidateAndParse(argsCheck []string) error {
+	if err := Descriptor.Validate(e.args, argsCheck); err != nil {
+		return err
+	}
+
+	e.argsCheck = argsCheck
+
+	var ok bool
+	e.items, ok = e.args[ItemsKey].(string)
+	if !ok {
+		return fmt.Errorf("%s: validation failed, %s must be string, got %s", e.ID(), ItemsKey, types.TypeOf(e.args[ItemsKey]))
+	}
+
+	e.workflowID, ok = e.args[WorkflowIDKey].(string)
+	if !ok {
+		return fmt.Errorf("%s: validation failed, %s must be string, got %s", e.ID(), WorkflowIDKey, types.TypeOf(e.args[WorkflowIDKey]))
+	}
+
+	e.itemVariable = DefaultItemVariable
+	if name, ok := e.args[ItemVariableKey].(string); ok && name != "" {
+		e.itemVariable = name
+	}
+
+	e.indexVariable = DefaultIndexVariable
+	if name, ok := e.args[IndexVariableKey].(string); ok && name != "" {
+		e.indexVariable = name
+	}
+
+	if e.itemVariable == e.indexVariable {
+		return fmt.Errorf("%s: validation failed, item and index are both passed as %s", e.ID(), e.itemVariable)
+	}
+
+	e.concurrency = 1
+	if raw, ok := e.args[ConcurrencyKey]; ok {
+		concurrency, ok := raw.(float64)
+		if !ok || concurrency != float64(int(concurrency)) || concurrency < 1 || concurrency > MaxConcurrency {
+			return fmt.Errorf("%s: validation failed, %s must be an integer from 1 to %d, got %v", e.ID(), ConcurrencyKey, MaxConcurrency, raw)
+		}
+		e.concurrency = int(concurrency)
+	}
+
+	e.collect = nil
+	rawCollect, _ := e.args[CollectKey].([]any)
+	for _, raw := range rawCollect {
+		name, ok := raw.(string)
+		if !ok {
+			return fmt.Errorf("%s: validation failed, %s must list variable names, got %T", e.ID(), CollectKey, raw)
+		}
+		e.collect = append(e.collect, name)
+	}
+
+	return nil
+}
+
+// ID implements NodeExecutor.
+func (e *Executor) ID() string {
+	return "foreach"
+}
+
+func (e *Executor) Execute(ctx context.Context) (any, error) {
+	rawItems, ok := e.args[e.items]
+	if !ok {
+		return nil, fmt.Errorf("%s: variable %s is not set", e.ID(), e.items)
+	}
+
+	items, ok := toItems(rawItems)
+	if !ok {
+		return nil, fmt.Errorf("%s: variable %s must be array, got %s", e.ID(), e.items, types.TypeOf(rawItems))
+	}
+
+	input := make(map[string]any, len(e.argsCheck)+2)
+	for _, name := range e.argsCheck {
+		input[name] = e.args[name]
+	}
+
+	// Every run writes its own slot, so the results keep the order of the items
+	results := make([]any, len(items))
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(e.concurrency)
+
+	for i, item := range items {
+		itemInput := maps.Clone(input)
+		itemInput[e.itemVariable] = item
+		// Numbers are float64 as if decoded from JSON, so the form of the workflow can declare the index a number field
+		itemInput[e.indexVariable] = float64(i)
+
+		g.Go(func() error {
+			result, err := subworkflow.Run(gctx, e.Opts.Workflows, e.workflowID, itemInput)
+			if err != nil {
+				return fmt.Errorf("%s: item %d: %w", e.ID(), i, err)
+			}
+
+			collected, err := e.collectOutputs(itemInput, result.Outputs)
+			if err != nil {
+				return fmt.Errorf("%s: item %d: %w", e.ID(), i, err)
+			}
+
+			results[i] = collected
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	output := map[string]any{}
+	for _, field := range e.outputFields {
+		output[field] = results
+	}
+
+	return output, nil
+}
+
+// collectOutputs returns the collected variables of a run,
+// or the outputs of every node of the workflow when no variables are listed.
+func (e *Executor) collectOutputs(input map[string]any, outputs []map[string]any) (map[string]any, error) {
+	if len(e.collect) == 0 {
+		return subworkflow.Variables(nil, outputs), nil
+	}
+
+	variables := subworkflow.Variables(input, outputs)
+
+	collected := make(map[string]any, len(e.collect))
+	for _, name := range e.collect {
+		value, ok := variables[name]
+		if !ok {
+			return nil, fmt.Errorf("workflow %s did not set %s", e.workflowID, name)
+		}
+		collected[name] = value
+	}
+
+	return collected, nil
+}
+
+// toItems returns the items of a slice.
+// Lists decoded from JSON are []any, while lists built by nodes, such as a script splitting a string, can be any slice.
+func toItems(raw any) ([]any, bool) {
+	if items, ok := raw.([]any); ok {
+		return items, true
+	}
+
+	value := reflect.ValueOf(raw)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil, false
+	}
+
+	items := make([]any, value.Len())
+	for i := range items {
+		items[i] = value.Index(i).Interface()
+	}
+
+	return items, true
+}
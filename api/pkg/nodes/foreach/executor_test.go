@@ -0,0 +1,270 @@
+package foreach_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+	"workflow-code-test/api/pkg/nodes/foreach"
+	"workflow-code-test/api/pkg/nodes/form"
+	"workflow-code-test/api/pkg/nodes/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+// mockWorkflows greets the city passed as item, failing for the city "fail".
+type mockWorkflows struct {
+	mu      sync.Mutex
+	running int
+	peak    int
+}
+
+func (m *mockWorkflows) RunWorkflow(ctx context.Context, workflowID string, input *types.WorkflowInput) (*types.WorkflowResult, error) {
+	m.mu.Lock()
+	m.running++
+	m.peak = max(m.peak, m.running)
+	m.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	m.mu.Lock()
+	m.running--
+	m.mu.Unlock()
+
+	city, _ := input.Variables["city"].(string)
+	if city == "fail" {
+		return nil, fmt.Errorf("no weather for %s", city)
+	}
+
+	return &types.WorkflowResult{
+		ExecutionID: "child",
+		Status:      types.WorkflowStatusCompleted,
+		Outputs: []map[string]any{
+			{"greeting": "Hello " + city},
+		},
+	}, nil
+}
+
+func (m *mockWorkflows) CancelWorkflow(ctx context.Context, executionID string) error {
+	return nil
+}
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		name             string
+		args             map[string]any
+		inputVariables   []string
+		expectedResults  []any
+		expectedPeak     int
+		expectedErrorMsg string
+	}{
+		{
+			name: "runs items in order",
+			args: map[string]any{
+				foreach.ItemsKey:        "cities",
+				foreach.WorkflowIDKey:   "child-workflow",
+				foreach.ItemVariableKey: "city",
+				"cities":                []any{"Sydney", "Perth", "Hobart"},
+			},
+			expectedResults: []any{
+				map[string]any{"greeting": "Hello Sydney"},
+				map[string]any{"greeting": "Hello Perth"},
+				map[string]any{"greeting": "Hello Hobart"},
+			},
+			expectedPeak: 1,
+		},
+		{
+			name: "runs items in parallel and collects variables",
+			args: map[string]any{
+				foreach.ItemsKey:        "cities",
+				foreach.WorkflowIDKey:   "child-workflow",
+				foreach.ItemVariableKey: "city",
+				foreach.ConcurrencyKey:  2.0,
+				foreach.CollectKey:      []any{"city", "index", "greeting"},
+				"cities":                []any{"Sydney", "Perth", "Hobart", "Darwin"},
+			},
+			expectedResults: []any{
+				map[string]any{"city": "Sydney", "index": 0.0, "greeting": "Hello Sydney"},
+				map[string]any{"city": "Perth", "index": 1.0, "greeting": "Hello Perth"},
+				map[string]any{"city": "Hobart", "index": 2.0, "greeting": "Hello Hobart"},
+				map[string]any{"city": "Darwin", "index": 3.0, "greeting": "Hello Darwin"},
+			},
+			expectedPeak: 2,
+		},
+		{
+			name: "passes input variables to every item",
+			args: map[string]any{
+				foreach.ItemsKey:      "cities",
+				foreach.WorkflowIDKey: "child-workflow",
+				foreach.CollectKey:    []any{"threshold"},
+				"cities":              []any{"Sydney", "Perth"},
+				"threshold":           25.0,
+			},
+			inputVariables: []string{"threshold"},
+			expectedResults: []any{
+				map[string]any{"threshold": 25.0},
+				map[string]any{"threshold": 25.0},
+			},
+			expectedPeak: 1,
+		},
+		{
+			name: "list built by a node",
+			args: map[string]any{
+				foreach.ItemsKey:        "cities",
+				foreach.WorkflowIDKey:   "child-workflow",
+				foreach.ItemVariableKey: "city",
+				"cities":                []string{"Sydney", "Perth"},
+			},
+			expectedResults: []any{
+				map[string]any{"greeting": "Hello Sydney"},
+				map[string]any{"greeting": "Hello Perth"},
+			},
+			expectedPeak: 1,
+		},
+		{
+			name: "empty list",
+			args: map[string]any{
+				foreach.ItemsKey:      "cities",
+				foreach.WorkflowIDKey: "child-workflow",
+				"cities":              []any{},
+			},
+			expectedResults: []any{},
+		},
+		{
+			name: "failed item",
+			args: map[string]any{
+				foreach.ItemsKey:        "cities",
+				foreach.WorkflowIDKey:   "child-workflow",
+				foreach.ItemVariableKey: "city",
+				"cities":                []any{"Sydney", "fail"},
+			},
+			expectedErrorMsg: "foreach: item 1: workflow child-workflow failed: no weather for fail",
+		},
+		{
+			name: "collected variable not set",
+			args: map[string]any{
+				foreach.ItemsKey:      "cities",
+				foreach.WorkflowIDKey: "child-workflow",
+				foreach.CollectKey:    []any{"temperature"},
+				"cities":              []any{"Sydney"},
+			},
+			expectedErrorMsg: "did not set temperature",
+		},
+		{
+			name: "items variable is not an array",
+			args: map[string]any{
+				foreach.ItemsKey:      "cities",
+				foreach.WorkflowIDKey: "child-workflow",
+				"cities":              "Sydney",
+			},
+			expectedErrorMsg: "variable cities must be array, got string",
+		},
+		{
+			name: "items variable not set",
+			args: map[string]any{
+				foreach.ItemsKey:      "cities",
+				foreach.WorkflowIDKey: "child-workflow",
+			},
+			expectedErrorMsg: "variable cities is not set",
+		},
+		{
+			name: "null workflow",
+			args: map[string]any{
+				foreach.ItemsKey:      "cities",
+				foreach.WorkflowIDKey: nil,
+			},
+			expectedErrorMsg: "workflowId is required",
+		},
+		{
+			name: "concurrency out of range",
+			args: map[string]any{
+				foreach.ItemsKey:       "cities",
+				foreach.WorkflowIDKey:  "child-workflow",
+				foreach.ConcurrencyKey: 50.0,
+			},
+			expectedErrorMsg: "concurrency must be an integer from 1 to 10",
+		},
+		{
+			name: "item and index passed as the same input",
+			args: map[string]any{
+				foreach.ItemsKey:         "cities",
+				foreach.WorkflowIDKey:    "child-workflow",
+				foreach.IndexVariableKey: "item",
+			},
+			expectedErrorMsg: "item and index are both passed as item",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workflows := &mockWorkflows{}
+			executor := &foreach.Executor{Opts: &foreach.Options{Workflows: workflows}}
+			executor.SetArgs(tt.args)
+			executor.SetOutputFields([]string{"results"})
+
+			err := executor.ValidateAndParse(tt.inputVariables)
+			if err == nil {
+				var output any
+				output, err = executor.Execute(context.Background())
+				if tt.expectedErrorMsg == "" {
+					require.NoError(t, err)
+					require.Equal(t, map[string]any{"results": tt.expectedResults}, output)
+					require.Equal(t, tt.expectedPeak, workflows.peak)
+					return
+				}
+			}
+
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.expectedErrorMsg)
+		})
+	}
+}
+
+// formWorkflows checks the input of every run against the fields of a form, as the engine does for the form node
+// of the workflow, and returns the input as the output of the form.
+type formWorkflows struct {
+	fields []form.Field
+}
+
+func (f *formWorkflows) RunWorkflow(ctx context.Context, workflowID string, input *types.WorkflowInput) (*types.WorkflowResult, error) {
+	if errs := form.Validate(f.fields, input.Variables); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid input: %v", errs)
+	}
+
+	return &types.WorkflowResult{
+		ExecutionID: "child",
+		Status:      types.WorkflowStatusCompleted,
+		Outputs:     []map[string]any{input.Variables},
+	}, nil
+}
+
+func (f *formWorkflows) CancelWorkflow(ctx context.Context, executionID string) error {
+	return nil
+}
+
+func TestExecuteFormWithNumberIndex(t *testing.T) {
+	fields, err := form.ParseFields([]any{
+		"city",
+		map[string]any{"name": "position", "type": "number", "required": true, "min": 0.0},
+	})
+	require.NoError(t, err)
+
+	executor := &foreach.Executor{Opts: &foreach.Options{Workflows: &formWorkflows{fields: fields}}}
+	executor.SetArgs(map[string]any{
+		foreach.ItemsKey:         "cities",
+		foreach.WorkflowIDKey:    "child-workflow",
+		foreach.ItemVariableKey:  "city",
+		foreach.IndexVariableKey: "position",
+		"cities":                 []any{"Sydney", "Perth"},
+	})
+	executor.SetOutputFields([]string{"results"})
+	require.NoError(t, executor.ValidateAndParse(nil))
+
+	output, err := executor.Execute(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"results": []any{
+		map[string]any{"city": "Sydney", "position": 0.0},
+		map[string]any{"city": "Perth", "position": 1.0},
+	}}, output)
+}
@@ -3,8 +3,26 @@ package form
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"workflow-code-test/api/pkg/nodes"
+	"workflow-code-test/api/pkg/nodes/types"
 )
 
+// Descriptor is the contract of the form node kind.
+var Descriptor = types.Descriptor{
+	Kind:        "form",
+	Description: "Entry point that validates the submitted form data and passes it through.",
+	Metadata: []types.Property{
+		{Name: FieldsKey, Type: types.ValueTypeArray, Description: "Declared form fields and their validation rules."},
+	},
+}
+
+func init() {
+	nodes.Register(Descriptor.Kind, func() types.NodeExecutor { return &Executor{} }, Descriptor)
+}
+
 type Executor struct {
 	args         map[string]any
 	outputFields []string
@@ -20,11 +38,21 @@ func (e *Executor) SetOutputFields(fields []string) {
 
 // Validate implements nodes.NodeExecutor.
 func (e *Executor) ValidateAndParse(argsCheck []string) error {
-	for _, key := range argsCheck {
-		_, ok := e.args[key].(string)
-		if !ok {
-			return fmt.Errorf("%s: validation key failed, key: %v", e.ID(), key)
+	if err := Descriptor.Validate(e.args, argsCheck); err != nil {
+		return err
+	}
+
+	fields, err := ParseFields(e.args[FieldsKey])
+	if err != nil {
+		return fmt.Errorf("%s: validation failed to parse fields: %w", e.ID(), err)
+	}
+
+	if errs := Validate(fields, e.args); len(errs) > 0 {
+		messages := make([]string, 0, len(errs))
+		for _, name := range slices.Sorted(maps.Keys(errs)) {
+			messages = append(messages, fmt.Sprintf("%s %s", name, errs[name]))
 		}
+		return fmt.Errorf("%s: validation failed: %s", e.ID(), strings.Join(messages, ", "))
 	}
 
 	return nil
@@ -35,6 +63,26 @@ func (e *Executor) ID() string {
 	return "form"
 }
 
+// ResolveOutputs implements types.OutputResolver.
+// The form outputs its declared fields with their declared types.
+func (e *Executor) ResolveOutputs(metadata map[string]any) ([]types.Property, error) {
+	fields, err := ParseFields(metadata[FieldsKey])
+	if err != nil {
+		return nil, err
+	}
+
+	outputs := make([]types.Property, 0, len(fields))
+	for _, field := range fields {
+		outputs = append(outputs, types.Property{
+			Name:     field.Name,
+			Type:     types.ValueType(field.Type),
+			Required: field.Required,
+		})
+	}
+
+	return outputs, nil
+}
+
 func (e *Executor) Execute(ctx context.Context) (any, error) {
 	result := map[string]any{}
 	for _, field := range e.outputFields {
@@ -0,0 +1,195 @@
+package form
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"slices"
+)
+
+const (
+	// FieldsKey is the metadata key holding the form's declared fields.
+	FieldsKey string = "inputFields"
+
+	// FormatEmail requires a string field to be a bare email address.
+	FormatEmail string = "email"
+)
+
+// FieldType is the type of value a form field accepts.
+type FieldType string
+
+const (
+	FieldTypeString  FieldType = "string"
+	FieldTypeNumber  FieldType = "number"
+	FieldTypeBoolean FieldType = "boolean"
+)
+
+// Field describes a single form input and the rules its value must satisfy.
+// Min and Max bound the value of number fields and the length of string fields.
+type Field struct {
+	Name     string    `json:"name"`
+	Type     FieldType `json:"type"`
+	Required bool      `json:"required"`
+	Pattern  string    `json:"pattern,omitempty"`
+	Min      *float64  `json:"min,omitempty"`
+	Max      *float64  `json:"max,omitempty"`
+	Enum     []any     `json:"enum,omitempty"`
+	Format   string    `json:"format,omitempty"`
+
+	pattern *regexp.Regexp
+}
+
+// ParseFields parses the inputFields metadata of a form node.
+// Each entry is either a field name, which declares a required string field,
+// or an object describing the field schema.
+// Returns nil when no fields are declared.
+func ParseFields(raw any) ([]Field, error) {
+	if raw == nil {
+		return nil, nil
+	}
+
+	entries, ok := raw.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a list, got %T", FieldsKey, raw)
+	}
+
+	fields := make([]Field, 0, len(entries))
+	for i, entry := range entries {
+		var field Field
+
+		switch v := entry.(type) {
+		case string:
+			field = Field{Name: v, Type: FieldTypeString, Required: true}
+		case map[string]any:
+			b, err := json.Marshal(v)
+			if err != nil {
+				return nil, fmt.Errorf("%s[%d]: %w", FieldsKey, i, err)
+			}
+			if err := json.Unmarshal(b, &field); err != nil {
+				return nil, fmt.Errorf("%s[%d]: %w", FieldsKey, i, err)
+			}
+		default:
+			return nil, fmt.Errorf("%s[%d] must be a string or an object, got %T", FieldsKey, i, entry)
+		}
+
+		if err := field.compile(); err != nil {
+			return nil, fmt.Errorf("%s[%d]: %w", FieldsKey, i, err)
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// Validate checks data against fields and returns a message per invalid field.
+// Fields that are present in data but not declared are left untouched.
+func Validate(fields []Field, data map[string]any) map[string]string {
+	errs := map[string]string{}
+
+	for _, field := range fields {
+		value, ok := data[field.Name]
+		if !ok || value == nil || value == "" {
+			if field.Required {
+				errs[field.Name] = "is required"
+			}
+			continue
+		}
+
+		if err := field.validate(value); err != nil {
+			errs[field.Name] = err.Error()
+		}
+	}
+
+	return errs
+}
+
+func (f *Field) compile() error {
+	if f.Name == "" {
+		return fmt.Errorf("field name is required")
+	}
+
+	if f.Type == "" {
+		f.Type = FieldTypeString
+	}
+
+	switch f.Type {
+	case FieldTypeString, FieldTypeNumber, FieldTypeBoolean:
+	default:
+		return fmt.Errorf("field %s: unsupported type: %s", f.Name, f.Type)
+	}
+
+	if f.Format != "" && f.Format != FormatEmail {
+		return fmt.Errorf("field %s: unsupported format: %s", f.Name, f.Format)
+	}
+
+	if f.Pattern != "" {
+		pattern, err := regexp.Compile(f.Pattern)
+		if err != nil {
+			return fmt.Errorf("field %s: invalid pattern: %w", f.Name, err)
+		}
+		f.pattern = pattern
+	}
+
+	return nil
+}
+
+func (f *Field) validate(value any) error {
+	switch f.Type {
+	case FieldTypeString:
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("must be a string")
+		}
+		if err := f.validateString(s); err != nil {
+			return err
+		}
+
+	case FieldTypeNumber:
+		n, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("must be a number")
+		}
+		if f.Min != nil && n < *f.Min {
+			return fmt.Errorf("must be at least %v", *f.Min)
+		}
+		if f.Max != nil && n > *f.Max {
+			return fmt.Errorf("must be at most %v", *f.Max)
+		}
+
+	case FieldTypeBoolean:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("must be a boolean")
+		}
+	}
+
+	if len(f.Enum) > 0 && !slices.Contains(f.Enum, value) {
+		return fmt.Errorf("must be one of %v", f.Enum)
+	}
+
+	return nil
+}
+
+func (f *Field) validateString(s string) error {
+	length := float64(len([]rune(s)))
+	if f.Min != nil && length < *f.Min {
+		return fmt.Errorf("must be at least %v characters", *f.Min)
+	}
+	if f.Max != nil && length > *f.Max {
+		return fmt.Errorf("must be at most %v characters", *f.Max)
+	}
+
+	if f.pattern != nil && !f.pattern.MatchString(s) {
+		return fmt.Errorf("must match pattern %s", f.Pattern)
+	}
+
+	if f.Format == FormatEmail {
+		addr, err := mail.ParseAddress(s)
+		if err != nil || addr.Address != s {
+			return fmt.Errorf("must be a valid email address")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package form_test
+
+import (
+	"testing"
+	"workflow-code-test/api/pkg/nodes/form"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	rawFields := []any{
+		"name",
+		map[string]any{"name": "email", "type": "string", "required": true, "format": "email"},
+		map[string]any{"name": "city", "type": "string", "required": true, "min": 1, "max": 10},
+		map[string]any{"name": "code", "type": "string", "pattern": "^[A-Z]{3}$"},
+		map[string]any{"name": "operator", "type": "string", "required": true, "enum": []any{"greater_than", "less_than"}},
+		map[string]any{"name": "threshold", "type": "number", "required": true, "min": -100, "max": 100},
+		map[string]any{"name": "subscribe", "type": "boolean"},
+	}
+
+	validData := func() map[string]any {
+		return map[string]any{
+			"name":      "John Doe",
+			"email":     "johndoe@example.com",
+			"city":      "Sydney",
+			"code":      "SYD",
+			"operator":  "greater_than",
+			"threshold": 25.0,
+			"subscribe": true,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(data map[string]any)
+		expected map[string]string
+	}{
+		{
+			name:     "valid input",
+			modify:   func(data map[string]any) {},
+			expected: map[string]string{},
+		},
+		{
+			name:     "undeclared fields are ignored",
+			modify:   func(data map[string]any) { data["extra"] = 1 },
+			expected: map[string]string{},
+		},
+		{
+			name:     "optional field may be missing",
+			modify:   func(data map[string]any) { delete(data, "code") },
+			expected: map[string]string{},
+		},
+		{
+			name: "missing required fields",
+			modify: func(data map[string]any) {
+				delete(data, "name")
+				data["city"] = ""
+			},
+			expected: map[string]string{"name": "is required", "city": "is required"},
+		},
+		{
+			name:     "invalid email",
+			modify:   func(data map[string]any) { data["email"] = "John <johndoe@example.com>" },
+			expected: map[string]string{"email": "must be a valid email address"},
+		},
+		{
+			name:     "string too long",
+			modify:   func(data map[string]any) { data["city"] = "Kuala Lumpur" },
+			expected: map[string]string{"city": "must be at most 10 characters"},
+		},
+		{
+			name:     "pattern mismatch",
+			modify:   func(data map[string]any) { data["code"] = "syd" },
+			expected: map[string]string{"code": "must match pattern ^[A-Z]{3}$"},
+		},
+		{
+			name:     "value not in enum",
+			modify:   func(data map[string]any) { data["operator"] = "equals" },
+			expected: map[string]string{"operator": "must be one of [greater_than less_than]"},
+		},
+		{
+			name:     "number out of range",
+			modify:   func(data map[string]any) { data["threshold"] = 150.0 },
+			expected: map[string]string{"threshold": "must be at most 100"},
+		},
+		{
+			name: "wrong types",
+			modify: func(data map[string]any) {
+				data["name"] = 42.0
+				data["threshold"] = "25"
+				data["subscribe"] = "yes"
+			},
+			expected: map[string]string{
+				"name":      "must be a string",
+				"threshold": "must be a number",
+				"subscribe": "must be a boolean",
+			},
+		},
+	}
+
+	fields, err := form.ParseFields(rawFields)
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := validData()
+			tt.modify(data)
+
+			require.Equal(t, tt.expected, form.Validate(fields, data))
+		})
+	}
+}
+
+func TestParseFields(t *testing.T) {
+	tests := []struct {
+		name             string
+		raw              any
+		expectedErrorMsg string
+	}{
+		{
+			name: "no fields",
+			raw:  nil,
+		},
+		{
+			name:             "not a list",
+			raw:              "name",
+			expectedErrorMsg: "inputFields must be a list",
+		},
+		{
+			name:             "unsupported type",
+			raw:              []any{map[string]any{"name": "age", "type": "integer"}},
+			expectedErrorMsg: "unsupported type: integer",
+		},
+		{
+			name:             "unsupported format",
+			raw:              []any{map[string]any{"name": "site", "format": "url"}},
+			expectedErrorMsg: "unsupported format: url",
+		},
+		{
+			name:             "invalid pattern",
+			raw:              []any{map[string]any{"name": "code", "pattern": "("}},
+			expectedErrorMsg: "invalid pattern",
+		},
+		{
+			name:             "missing name",
+			raw:              []any{map[string]any{"type": "string"}},
+			expectedErrorMsg: "field name is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := form.ParseFields(tt.raw)
+			if tt.expectedErrorMsg != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.expectedErrorMsg)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,402 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+	"workflow-code-test/api/pkg/nodes/types"
+)
+
+const (
+	tcpScheme = "tcp://"
+
+	// stopTimeout is how long a launched plugin is given to exit after its stdin is closed.
+	stopTimeout = 5 * time.Second
+
+	// minReconnectDelay and maxReconnectDelay bound the delay between two failed attempts to reconnect
+	// to a plugin, which doubles on every failure.
+	minReconnectDelay = 100 * time.Millisecond
+	maxReconnectDelay = 30 * time.Second
+)
+
+// ErrClientClosed is returned by the calls made after the client was closed.
+var ErrClientClosed = errors.New("plugin: client closed")
+
+// Client is a connection to a plugin. Calls may be issued concurrently.
+//
+// Clients created by Connect, Dial and Start reconnect to the plugin, or launch it again, on the first call made after
+// the connection was lost, such as when the plugin process exited. Calls in flight when the connection is lost fail,
+// as the plugin may have run them. Failed attempts are retried after a delay doubling up to maxReconnectDelay.
+type Client struct {
+	// connect opens a new connection to the plugin. Nil for clients created by NewClient, which cannot reconnect.
+	connect func(ctx context.Context) (*session, error)
+
+	mu       sync.Mutex
+	session  *session
+	nextID   uint64
+	attempts int
+	retryAt  time.Time
+	closed   bool
+}
+
+// session is a single connection to a plugin, which is replaced when the client reconnects.
+type session struct {
+	conn io.ReadWriteCloser
+	cmd  *exec.Cmd
+	// exited is closed once the launched plugin exited.
+	exited chan struct{}
+
+	writeMu sync.Mutex
+	enc     *json.Encoder
+
+	mu      sync.Mutex
+	pending map[uint64]chan *response
+	err     error
+	closed  chan struct{}
+}
+
+// Connect starts or connects to the plugin at address.
+// address is either tcp://host:port for a plugin that is already running,
+// or a command line launching the plugin and speaking the protocol over its stdin/stdout.
+func Connect(ctx context.Context, address string) (*Client, error) {
+	if tcpAddr, ok := strings.CutPrefix(address, tcpScheme); ok {
+		return Dial(ctx, tcpAddr)
+	}
+
+	return Start(strings.Fields(address)...)
+}
+
+// Dial connects to a plugin listening on the TCP address.
+func Dial(ctx context.Context, address string) (*Client, error) {
+	return newReconnectingClient(ctx, func(ctx context.Context) (*session, error) {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if err != nil {
+			return nil, fmt.Errorf("plugin: failed to dial %s: %w", address, err)
+		}
+
+		return newSession(conn, nil), nil
+	})
+}
+
+// Start launches the plugin command and speaks the protocol over its stdin/stdout.
+// The plugin's stderr is forwarded to the API's stderr.
+func Start(command ...string) (*Client, error) {
+	if len(command) == 0 {
+		return nil, fmt.Errorf("plugin: command is empty")
+	}
+
+	return newReconnectingClient(context.Background(), func(ctx context.Context) (*session, error) {
+		cmd := exec.Command(command[0], command[1:]...)
+		cmd.Stderr = os.Stderr
+
+		stdin, err := cmd.StdinPipe()
+		if err != nil {
+			return nil, fmt.Errorf("plugin: failed to open stdin: %w", err)
+		}
+
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			return nil, fmt.Errorf("plugin: failed to open stdout: %w", err)
+		}
+
+		if err := cmd.Start(); err != nil {
+			return nil, fmt.Errorf("plugin: failed to start %s: %w", command[0], err)
+		}
+
+		return newSession(&stdio{ReadCloser: stdout, WriteCloser: stdin}, cmd), nil
+	})
+}
+
+// NewClient speaks the protocol over conn. The client cannot reconnect once conn is closed.
+func NewClient(conn io.ReadWriteCloser) *Client {
+	return &Client{
+		session: newSession(conn, nil),
+	}
+}
+
+func newReconnectingClient(ctx context.Context, connect func(ctx context.Context) (*session, error)) (*Client, error) {
+	s, err := connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		connect: connect,
+		session: s,
+	}, nil
+}
+
+func newSession(conn io.ReadWriteCloser, cmd *exec.Cmd) *session {
+	s := &session{
+		conn:    conn,
+		cmd:     cmd,
+		enc:     json.NewEncoder(conn),
+		pending: map[uint64]chan *response{},
+		closed:  make(chan struct{}),
+	}
+
+	go s.readLoop()
+
+	if cmd != nil {
+		s.exited = make(chan struct{})
+		go s.wait()
+	}
+
+	return s
+}
+
+// Describe returns the descriptor of the node kind implemented by the plugin.
+func (c *Client) Describe(ctx context.Context) (types.Descriptor, error) {
+	var descriptor types.Descriptor
+	if err := c.Call(ctx, MethodDescribe, nil, &descriptor); err != nil {
+		return types.Descriptor{}, err
+	}
+
+	if descriptor.Kind == "" {
+		return types.Descriptor{}, fmt.Errorf("plugin: describe returned an empty kind")
+	}
+
+	return descriptor, nil
+}
+
+// Call invokes method with params and decodes the result into result, unless result is nil.
+// Errors returned by the plugin are of type *Error.
+func (c *Client) Call(ctx context.Context, method string, params any, result any) error {
+	var rawParams json.RawMessage
+	if params != nil {
+		b, err := json.Marshal(params)
+		if err != nil {
+			return fmt.Errorf("plugin: failed to marshal %s params: %w", method, err)
+		}
+		rawParams = b
+	}
+
+	s, id, err := c.acquire(ctx)
+	if err != nil {
+		return err
+	}
+
+	ch, err := s.send(id, method, rawParams)
+	if isBrokenConnection(err) && c.connect != nil {
+		// The request was not sent, so it is safe to send it again once the plugin is back
+		s.shutdown(err)
+		if s, id, err = c.acquire(ctx); err != nil {
+			return err
+		}
+		ch, err = s.send(id, method, rawParams)
+	}
+	if err != nil {
+		return fmt.Errorf("plugin: failed to send %s request: %w", method, err)
+	}
+
+	select {
+	case resp := <-ch:
+		if resp.Error != nil {
+			return resp.Error
+		}
+		if result == nil {
+			return nil
+		}
+		if err := json.Unmarshal(resp.Result, result); err != nil {
+			return fmt.Errorf("plugin: failed to unmarshal %s result: %w", method, err)
+		}
+		return nil
+
+	case <-s.closed:
+		return fmt.Errorf("plugin: connection closed: %w", s.closeErr())
+
+	case <-ctx.Done():
+		s.forget(id)
+		return fmt.Errorf("plugin: %s cancelled: %w", method, ctx.Err())
+	}
+}
+
+// acquire returns the session to send the next request over along with the ID of the request,
+// reconnecting to the plugin if the connection was lost.
+func (c *Client) acquire(ctx context.Context) (*session, uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil, 0, ErrClientClosed
+	}
+
+	if err := c.session.closeErr(); err != nil {
+		if c.connect == nil {
+			return nil, 0, fmt.Errorf("plugin: connection closed: %w", err)
+		}
+		if wait := time.Until(c.retryAt); wait > 0 {
+			return nil, 0, fmt.Errorf("plugin: connection closed: %w, reconnecting in %s", err, wait.Round(time.Millisecond))
+		}
+
+		s, connectErr := c.connect(ctx)
+		if connectErr != nil {
+			c.retryAt = time.Now().Add(reconnectDelay(c.attempts))
+			c.attempts++
+			return nil, 0, fmt.Errorf("plugin: connection closed: %w, failed to reconnect: %w", err, connectErr)
+		}
+
+		c.session.close()
+		c.session = s
+		c.attempts = 0
+	}
+
+	c.nextID++
+
+	return c.session, c.nextID, nil
+}
+
+// reconnectDelay returns how long to wait after the attempts-th consecutive failure to reconnect.
+func reconnectDelay(attempts int) time.Duration {
+	delay := minReconnectDelay
+	for range attempts {
+		delay *= 2
+		if delay >= maxReconnectDelay {
+			return maxReconnectDelay
+		}
+	}
+
+	return delay
+}
+
+// Close closes the connection. A launched plugin is expected to exit once its stdin is closed,
+// and is killed if it does not exit in time.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closed = true
+
+	return c.session.close()
+}
+
+// send writes the request and returns the channel its response is delivered to.
+func (s *session) send(id uint64, method string, params json.RawMessage) (chan *response, error) {
+	ch := make(chan *response, 1)
+
+	s.mu.Lock()
+	s.pending[id] = ch
+	s.mu.Unlock()
+
+	s.writeMu.Lock()
+	err := s.enc.Encode(request{
+		JSONRPC: jsonRPCVersion,
+		ID:      id,
+		Method:  method,
+		Params:  params,
+	})
+	s.writeMu.Unlock()
+	if err != nil {
+		s.forget(id)
+		return nil, err
+	}
+
+	return ch, nil
+}
+
+// close closes the connection and stops the launched plugin, if any.
+func (s *session) close() error {
+	err := s.conn.Close()
+
+	if s.cmd == nil {
+		return err
+	}
+
+	select {
+	case <-s.exited:
+	case <-time.After(stopTimeout):
+		s.cmd.Process.Kill()
+		<-s.exited
+	}
+
+	return err
+}
+
+// wait reaps the launched plugin, losing the session as soon as it exits.
+func (s *session) wait() {
+	defer close(s.exited)
+
+	err := s.cmd.Wait()
+	if err == nil {
+		err = errors.New("plugin exited")
+	}
+	s.shutdown(err)
+}
+
+func (s *session) readLoop() {
+	dec := json.NewDecoder(s.conn)
+
+	for {
+		var resp response
+		if err := dec.Decode(&resp); err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			s.shutdown(err)
+			return
+		}
+
+		s.mu.Lock()
+		ch, ok := s.pending[resp.ID]
+		delete(s.pending, resp.ID)
+		s.mu.Unlock()
+
+		if ok {
+			ch <- &resp
+		}
+	}
+}
+
+// shutdown marks the session as lost, failing the calls waiting for a response.
+func (s *session) shutdown(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return
+	}
+
+	s.err = err
+	close(s.closed)
+}
+
+// closeErr returns why the session was lost, or nil if it is still open.
+func (s *session) closeErr() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.err
+}
+
+func (s *session) forget(id uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.pending, id)
+}
+
+// isBrokenConnection reports whether err was returned by a write to a connection the plugin closed.
+func isBrokenConnection(err error) bool {
+	return errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, net.ErrClosed) || errors.Is(err, os.ErrClosed) || errors.Is(err, io.ErrClosedPipe)
+}
+
+// stdio joins the stdout and stdin pipes of a launched plugin.
+type stdio struct {
+	io.ReadCloser
+	io.WriteCloser
+}
+
+func (s *stdio) Close() error {
+	return errors.Join(s.WriteCloser.Close(), s.ReadCloser.Close())
+}
@@ -0,0 +1,107 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"workflow-code-test/api/pkg/nodes"
+	"workflow-code-test/api/pkg/nodes/types"
+)
+
+// ValidateTimeout bounds the validate call, which is not given a context by the engine.
+const ValidateTimeout = 10 * time.Second
+
+// Executor runs a node kind implemented by a plugin.
+type Executor struct {
+	Client *Client
+	Kind   string
+
+	args         map[string]any
+	outputFields []string
+}
+
+func (e *Executor) SetArgs(args map[string]any) {
+	e.args = args
+}
+
+func (e *Executor) SetOutputFields(fields []string) {
+	e.outputFields = fields
+}
+
+// ValidateAndParse implements NodeExecutor.
+func (e *Executor) ValidateAndParse(argsCheck []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), ValidateTimeout)
+	defer cancel()
+
+	err := e.Client.Call(ctx, MethodValidate, ValidateParams{
+		Args:      e.args,
+		ArgsCheck: argsCheck,
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("%s: validation failed: %w", e.ID(), err)
+	}
+
+	return nil
+}
+
+// ID implements NodeExecutor.
+func (e *Executor) ID() string {
+	return e.Kind
+}
+
+func (e *Executor) Execute(ctx context.Context) (any, error) {
+	var result ExecuteResult
+	err := e.Client.Call(ctx, MethodExecute, ExecuteParams{
+		Args:         e.args,
+		OutputFields: e.outputFields,
+	}, &result)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to execute: %w", e.ID(), err)
+	}
+
+	if result.Output == nil {
+		result.Output = map[string]any{}
+	}
+
+	return result.Output, nil
+}
+
+// Simulate implements types.Simulator.
+// Plugins may have any side effect, so they are not called in dry runs: the simulation reports the call
+// that would be made, without an output.
+func (e *Executor) Simulate(ctx context.Context) (*types.Simulation, error) {
+	return &types.Simulation{
+		Effect: map[string]any{
+			"plugin": map[string]any{
+				"method":       MethodExecute,
+				"outputFields": e.outputFields,
+			},
+		},
+	}, nil
+}
+
+// Load starts or connects to the plugin at address (see Connect), asks it to describe its node kind
+// and registers the kind in registry. The returned client must be closed on shutdown.
+func Load(ctx context.Context, registry *nodes.Registry, address string) (*Client, error) {
+	client, err := Connect(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	descriptor, err := client.Describe(ctx)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("plugin: failed to describe %s: %w", address, err)
+	}
+
+	if _, exists := registry.Descriptor(descriptor.Kind); exists {
+		client.Close()
+		return nil, fmt.Errorf("plugin: %s serves kind %s which is already registered", address, descriptor.Kind)
+	}
+
+	registry.Register(descriptor.Kind, func() types.NodeExecutor {
+		return &Executor{Client: client, Kind: descriptor.Kind}
+	}, descriptor)
+
+	return client, nil
+}
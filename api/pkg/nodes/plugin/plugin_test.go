@@ -0,0 +1,281 @@
+package plugin_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+	"workflow-code-test/api/pkg/nodes"
+	"workflow-code-test/api/pkg/nodes/plugin"
+	"workflow-code-test/api/pkg/nodes/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+// buildGreetingPlugin compiles the reference plugin and returns the path of the binary.
+func buildGreetingPlugin(t *testing.T) string {
+	t.Helper()
+
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not available to build the reference plugin")
+	}
+
+	bin := filepath.Join(t.TempDir(), "greeting")
+	out, err := exec.Command(goBin, "build", "-o", bin, "../../../plugins/greeting").CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	return bin
+}
+
+func TestLoad_Process(t *testing.T) {
+	if testing.Short() {
+		t.Skip("spawns the reference plugin")
+	}
+
+	bin := buildGreetingPlugin(t)
+	ctx := context.Background()
+
+	registry := nodes.NewRegistry()
+	client, err := plugin.Load(ctx, registry, bin)
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+
+	descriptor, ok := registry.Descriptor("greeting")
+	require.True(t, ok)
+	require.Equal(t, types.ValueTypeString, descriptor.Outputs[0].Type)
+
+	tests := []struct {
+		name             string
+		args             map[string]any
+		argsCheck        []string
+		expectedOutput   map[string]any
+		expectedErrorMsg string
+	}{
+		{
+			name:           "default greeting",
+			args:           map[string]any{"name": "John Doe"},
+			argsCheck:      []string{"name"},
+			expectedOutput: map[string]any{"message": "Hello, John Doe!"},
+		},
+		{
+			name:           "greeting from metadata",
+			args:           map[string]any{"name": "John Doe", "greeting": "G'day"},
+			expectedOutput: map[string]any{"message": "G'day, John Doe!"},
+		},
+		{
+			name:             "missing name",
+			args:             map[string]any{},
+			expectedErrorMsg: "greeting: validation failed, name is required",
+		},
+		{
+			name:             "name of the wrong type",
+			args:             map[string]any{"name": 42.0},
+			expectedErrorMsg: "name must be string, got number",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := registry.New("greeting")
+			require.NotNil(t, executor)
+
+			executor.SetArgs(tt.args)
+			executor.SetOutputFields([]string{"message"})
+
+			err := executor.ValidateAndParse(tt.argsCheck)
+			if tt.expectedErrorMsg != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.expectedErrorMsg)
+				return
+			}
+			require.NoError(t, err)
+
+			outputs, err := executor.Execute(ctx)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedOutput, outputs)
+		})
+	}
+
+	t.Run("duplicate kind is rejected", func(t *testing.T) {
+		_, err := plugin.Load(ctx, registry, bin)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "already registered")
+	})
+}
+
+func TestLoad_TCP(t *testing.T) {
+	if testing.Short() {
+		t.Skip("spawns the reference plugin")
+	}
+
+	bin := buildGreetingPlugin(t)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	ln.Close()
+
+	cmd := exec.Command(bin, "-listen", addr)
+	require.NoError(t, cmd.Start())
+	t.Cleanup(func() {
+		cmd.Process.Kill()
+		cmd.Wait()
+	})
+
+	ctx := context.Background()
+	registry := nodes.NewRegistry()
+
+	var client *plugin.Client
+	require.Eventually(t, func() bool {
+		client, err = plugin.Load(ctx, registry, "tcp://"+addr)
+		return err == nil
+	}, 10*time.Second, 50*time.Millisecond)
+	t.Cleanup(func() { client.Close() })
+
+	executor := registry.New("greeting")
+	executor.SetArgs(map[string]any{"name": "Jane"})
+	executor.SetOutputFields([]string{"message"})
+	require.NoError(t, executor.ValidateAndParse(nil))
+
+	outputs, err := executor.Execute(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"message": "Hello, Jane!"}, outputs)
+}
+
+func TestClient_ClosedConnection(t *testing.T) {
+	pluginConn, apiConn := net.Pipe()
+	client := plugin.NewClient(apiConn)
+	pluginConn.Close()
+
+	_, err := client.Describe(context.Background())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "plugin")
+}
+
+// greet executes the greeting kind of registry for name.
+func greet(t *testing.T, registry *nodes.Registry, name string) (any, error) {
+	t.Helper()
+
+	executor := registry.New("greeting")
+	require.NotNil(t, executor)
+
+	executor.SetArgs(map[string]any{"name": name})
+	executor.SetOutputFields([]string{"message"})
+	if err := executor.ValidateAndParse(nil); err != nil {
+		return nil, err
+	}
+
+	return executor.Execute(context.Background())
+}
+
+func TestClient_RestartsKilledProcess(t *testing.T) {
+	if testing.Short() {
+		t.Skip("spawns the reference plugin")
+	}
+
+	bin := buildGreetingPlugin(t)
+
+	// The script records the PID of every plugin it launches, so the test can kill the running one
+	dir := t.TempDir()
+	pidFile := filepath.Join(dir, "pid")
+	script := filepath.Join(dir, "greeting.sh")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\necho $$ > "+pidFile+"\nexec "+bin+"\n"), 0o755))
+
+	registry := nodes.NewRegistry()
+	client, err := plugin.Load(context.Background(), registry, script)
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Close() })
+
+	output, err := greet(t, registry, "Jane")
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"message": "Hello, Jane!"}, output)
+
+	raw, err := os.ReadFile(pidFile)
+	require.NoError(t, err)
+	pid, err := strconv.Atoi(strings.TrimSpace(string(raw)))
+	require.NoError(t, err)
+
+	require.NoError(t, syscall.Kill(pid, syscall.SIGKILL))
+	require.Eventually(t, func() bool {
+		return errors.Is(syscall.Kill(pid, 0), syscall.ESRCH)
+	}, 10*time.Second, 10*time.Millisecond)
+
+	output, err = greet(t, registry, "John")
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"message": "Hello, John!"}, output)
+
+	raw, err = os.ReadFile(pidFile)
+	require.NoError(t, err)
+	require.NotEqual(t, strconv.Itoa(pid), strings.TrimSpace(string(raw)))
+}
+
+func TestClient_ReconnectsWithBackoff(t *testing.T) {
+	if testing.Short() {
+		t.Skip("spawns the reference plugin")
+	}
+
+	bin := buildGreetingPlugin(t)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	ln.Close()
+
+	listen := func() *exec.Cmd {
+		cmd := exec.Command(bin, "-listen", addr)
+		require.NoError(t, cmd.Start())
+		t.Cleanup(func() {
+			cmd.Process.Kill()
+			cmd.Wait()
+		})
+		return cmd
+	}
+
+	server := listen()
+	registry := nodes.NewRegistry()
+
+	var client *plugin.Client
+	require.Eventually(t, func() bool {
+		client, err = plugin.Load(context.Background(), registry, "tcp://"+addr)
+		return err == nil
+	}, 10*time.Second, 50*time.Millisecond)
+	t.Cleanup(func() { client.Close() })
+
+	_, err = greet(t, registry, "Jane")
+	require.NoError(t, err)
+
+	require.NoError(t, server.Process.Kill())
+	server.Wait()
+
+	// Once the client notices the plugin is down, attempting to reconnect fails and the next calls wait for the delay
+	require.Eventually(t, func() bool {
+		_, err := greet(t, registry, "John")
+		return err != nil && strings.Contains(err.Error(), "failed to reconnect")
+	}, 10*time.Second, 10*time.Millisecond)
+	_, err = greet(t, registry, "John")
+	require.ErrorContains(t, err, "reconnecting in")
+
+	listen()
+
+	require.Eventually(t, func() bool {
+		output, err := greet(t, registry, "John")
+		return err == nil && output.(map[string]any)["message"] == "Hello, John!"
+	}, 10*time.Second, 50*time.Millisecond)
+}
+
+func TestClient_Closed(t *testing.T) {
+	_, apiConn := net.Pipe()
+	client := plugin.NewClient(apiConn)
+	require.NoError(t, client.Close())
+
+	_, err := client.Describe(context.Background())
+	require.ErrorIs(t, err, plugin.ErrClientClosed)
+}
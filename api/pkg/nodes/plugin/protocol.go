@@ -0,0 +1,77 @@
+// Package plugin runs node kinds implemented by external processes.
+//
+// A plugin speaks JSON-RPC 2.0 with one JSON message per line, either over the stdin/stdout of a
+// process launched by the API or over a TCP connection to a process that is already running.
+// It answers three methods:
+//
+//   - describe: returns the types.Descriptor of the node kind it implements.
+//   - validate: checks the node arguments (ValidateParams) and returns an error if they are invalid.
+//   - execute: runs the node (ExecuteParams) and returns its output (ExecuteResult).
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	jsonRPCVersion = "2.0"
+
+	MethodDescribe = "describe"
+	MethodValidate = "validate"
+	MethodExecute  = "execute"
+)
+
+// JSON-RPC error codes used by the protocol.
+const (
+	CodeParseError     = -32700
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+
+	// CodeValidationFailed is returned by validate when the node arguments are invalid.
+	CodeValidationFailed = 1
+	// CodeExecutionFailed is returned by execute when the node failed to run.
+	CodeExecutionFailed = 2
+)
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      uint64          `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      uint64          `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Error is a JSON-RPC error returned by a plugin.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("plugin error %d: %s", e.Code, e.Message)
+}
+
+// ValidateParams are the parameters of the validate method.
+type ValidateParams struct {
+	Args      map[string]any `json:"args"`
+	ArgsCheck []string       `json:"argsCheck"`
+}
+
+// ExecuteParams are the parameters of the execute method.
+type ExecuteParams struct {
+	Args         map[string]any `json:"args"`
+	OutputFields []string       `json:"outputFields"`
+}
+
+// ExecuteResult is the result of the execute method.
+type ExecuteResult struct {
+	Output map[string]any `json:"output"`
+}
@@ -0,0 +1,107 @@
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"workflow-code-test/api/pkg/nodes/types"
+)
+
+// Handler implements a node kind served by a plugin written in Go.
+type Handler interface {
+	// Describe returns the descriptor of the node kind.
+	Describe() types.Descriptor
+
+	// Validate checks the node arguments, see types.NodeExecutor.ValidateAndParse.
+	Validate(args map[string]any, argsCheck []string) error
+
+	// Execute runs the node and returns the values of outputFields.
+	Execute(ctx context.Context, args map[string]any, outputFields []string) (map[string]any, error)
+}
+
+// Serve answers the requests read from r with handler and writes the responses to w,
+// until r is exhausted or ctx is done. Requests are handled one at a time.
+// Plugins launched by the API serve os.Stdin and os.Stdout, so they must log to os.Stderr.
+func Serve(ctx context.Context, r io.Reader, w io.Writer, handler Handler) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	enc := json.NewEncoder(w)
+
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		resp := handle(ctx, line, handler)
+		if err := enc.Encode(resp); err != nil {
+			return fmt.Errorf("plugin: failed to write response: %w", err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("plugin: failed to read request: %w", err)
+	}
+
+	return nil
+}
+
+func handle(ctx context.Context, line []byte, handler Handler) response {
+	var req request
+	if err := json.Unmarshal(line, &req); err != nil {
+		return errorResponse(0, CodeParseError, err)
+	}
+
+	switch req.Method {
+	case MethodDescribe:
+		return resultResponse(req.ID, handler.Describe())
+
+	case MethodValidate:
+		var params ValidateParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return errorResponse(req.ID, CodeInvalidParams, err)
+		}
+		if err := handler.Validate(params.Args, params.ArgsCheck); err != nil {
+			return errorResponse(req.ID, CodeValidationFailed, err)
+		}
+		return resultResponse(req.ID, struct{}{})
+
+	case MethodExecute:
+		var params ExecuteParams
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return errorResponse(req.ID, CodeInvalidParams, err)
+		}
+		output, err := handler.Execute(ctx, params.Args, params.OutputFields)
+		if err != nil {
+			return errorResponse(req.ID, CodeExecutionFailed, err)
+		}
+		return resultResponse(req.ID, ExecuteResult{Output: output})
+
+	default:
+		return errorResponse(req.ID, CodeMethodNotFound, fmt.Errorf("method not found: %s", req.Method))
+	}
+}
+
+func resultResponse(id uint64, result any) response {
+	b, err := json.Marshal(result)
+	if err != nil {
+		return errorResponse(id, CodeInternalError, err)
+	}
+
+	return response{JSONRPC: jsonRPCVersion, ID: id, Result: b}
+}
+
+func errorResponse(id uint64, code int, err error) response {
+	return response{
+		JSONRPC: jsonRPCVersion,
+		ID:      id,
+		Error:   &Error{Code: code, Message: err.Error()},
+	}
+}
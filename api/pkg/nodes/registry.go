@@ -0,0 +1,114 @@
+package nodes
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"workflow-code-test/api/pkg/nodes/types"
+)
+
+// Factory creates a new executor. A fresh executor is created for every node execution,
+// so executors can keep per-execution state such as their arguments.
+type Factory func() types.NodeExecutor
+
+type registration struct {
+	factory    Factory
+	descriptor types.Descriptor
+}
+
+// Registry is the source of truth for the node kinds available to workflows.
+// It is safe for concurrent use.
+type Registry struct {
+	mu    sync.RWMutex
+	kinds map[string]registration
+}
+
+var defaultRegistry = NewRegistry()
+
+func NewRegistry() *Registry {
+	return &Registry{
+		kinds: map[string]registration{},
+	}
+}
+
+// DefaultRegistry returns the registry node packages register themselves into from init.
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
+
+// Clone returns a registry with the kinds registered in r, in which further kinds can be registered without
+// affecting r. Containers clone the default registry, so that several can be built in the same process.
+func (r *Registry) Clone() *Registry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return &Registry{
+		kinds: maps.Clone(r.kinds),
+	}
+}
+
+// Register makes a node kind available in the default registry.
+// Node packages without dependencies call it from init; see Registry.Register.
+func Register(kind string, factory Factory, descriptor types.Descriptor) {
+	defaultRegistry.Register(kind, factory, descriptor)
+}
+
+// Register makes a node kind available to workflows.
+// Registration happens while the application starts, so it panics if kind is empty,
+// factory is nil or kind is already registered.
+func (r *Registry) Register(kind string, factory Factory, descriptor types.Descriptor) {
+	if kind == "" {
+		panic("nodes: Register kind is empty")
+	}
+	if factory == nil {
+		panic(fmt.Sprintf("nodes: Register factory is nil for kind %s", kind))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.kinds[kind]; exists {
+		panic(fmt.Sprintf("nodes: Register called twice for kind %s", kind))
+	}
+
+	descriptor.Kind = kind
+	r.kinds[kind] = registration{
+		factory:    factory,
+		descriptor: descriptor,
+	}
+}
+
+// New creates an executor for kind. Returns nil if the kind is not registered.
+func (r *Registry) New(kind string) types.NodeExecutor {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if reg, ok := r.kinds[kind]; ok {
+		return reg.factory()
+	}
+
+	return nil
+}
+
+// Descriptor returns the descriptor of kind and whether the kind is registered.
+func (r *Registry) Descriptor(kind string) (types.Descriptor, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reg, ok := r.kinds[kind]
+	return reg.descriptor, ok
+}
+
+// Descriptors returns the descriptor of every registered kind, sorted by kind.
+func (r *Registry) Descriptors() []types.Descriptor {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	descriptors := make([]types.Descriptor, 0, len(r.kinds))
+	for _, kind := range slices.Sorted(maps.Keys(r.kinds)) {
+		descriptors = append(descriptors, r.kinds[kind].descriptor)
+	}
+
+	return descriptors
+}
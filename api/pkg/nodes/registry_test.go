@@ -0,0 +1,81 @@
+package nodes_test
+
+import (
+	"context"
+	"testing"
+	"workflow-code-test/api/pkg/nodes"
+	"workflow-code-test/api/pkg/nodes/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+type stubExecutor struct {
+	args map[string]any
+}
+
+func (e *stubExecutor) Execute(ctx context.Context) (any, error) { return e.args, nil }
+func (e *stubExecutor) ID() string                               { return "stub" }
+func (e *stubExecutor) SetArgs(args map[string]any)              { e.args = args }
+func (e *stubExecutor) SetOutputFields(fields []string)          {}
+func (e *stubExecutor) ValidateAndParse(argsCheck []string) error {
+	return nil
+}
+
+func TestRegistry(t *testing.T) {
+	registry := nodes.NewRegistry()
+	registry.Register("stub", func() types.NodeExecutor { return &stubExecutor{} }, types.Descriptor{
+		Description: "stub node",
+	})
+	registry.Register("another", func() types.NodeExecutor { return &stubExecutor{} }, types.Descriptor{})
+
+	t.Run("creates a new executor per call", func(t *testing.T) {
+		first := registry.New("stub")
+		second := registry.New("stub")
+		require.NotNil(t, first)
+		require.NotSame(t, first, second)
+	})
+
+	t.Run("unknown kind", func(t *testing.T) {
+		require.Nil(t, registry.New("unknown"))
+
+		_, ok := registry.Descriptor("unknown")
+		require.False(t, ok)
+	})
+
+	t.Run("descriptor kind is set from registration", func(t *testing.T) {
+		descriptor, ok := registry.Descriptor("stub")
+		require.True(t, ok)
+		require.Equal(t, "stub", descriptor.Kind)
+		require.Equal(t, "stub node", descriptor.Description)
+	})
+
+	t.Run("descriptors are sorted by kind", func(t *testing.T) {
+		descriptors := registry.Descriptors()
+		require.Len(t, descriptors, 2)
+		require.Equal(t, "another", descriptors[0].Kind)
+		require.Equal(t, "stub", descriptors[1].Kind)
+	})
+
+	t.Run("duplicate registration panics", func(t *testing.T) {
+		require.Panics(t, func() {
+			registry.Register("stub", func() types.NodeExecutor { return &stubExecutor{} }, types.Descriptor{})
+		})
+	})
+
+	t.Run("clones are registered into separately", func(t *testing.T) {
+		first, second := registry.Clone(), registry.Clone()
+		first.Register("cloned", func() types.NodeExecutor { return &stubExecutor{} }, types.Descriptor{})
+		second.Register("cloned", func() types.NodeExecutor { return &stubExecutor{} }, types.Descriptor{})
+
+		require.NotNil(t, first.New("stub"))
+		require.NotNil(t, first.New("cloned"))
+		require.Nil(t, registry.New("cloned"))
+		require.Len(t, registry.Descriptors(), 2)
+	})
+
+	t.Run("nil factory panics", func(t *testing.T) {
+		require.Panics(t, func() {
+			registry.Register("nil", nil, types.Descriptor{})
+		})
+	})
+}
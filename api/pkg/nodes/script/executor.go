@@ -0,0 +1,156 @@
+package script
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"workflow-code-test/api/pkg/nodes"
+	"workflow-code-test/api/pkg/nodes/types"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+)
+
+const (
+	ExpressionsKey string = "expressions"
+	TimeoutKey     string = "timeout"
+
+	// MaxNodes bounds the size of each expression.
+	MaxNodes uint = 1000
+	// MemoryBudget bounds the allocations of each evaluation, which also bounds its CPU usage
+	// as ranges and collection builtins allocate per element.
+	MemoryBudget uint = 100_000
+
+	DefaultTimeout = 1 * time.Second
+	MaxTimeout     = 5 * time.Second
+)
+
+// Descriptor is the contract of the script node kind.
+var Descriptor = types.Descriptor{
+	Kind:        "script",
+	Description: "Computes its outputs from the execution variables with expr expressions.",
+	Metadata: []types.Property{
+		{Name: ExpressionsKey, Type: types.ValueTypeObject, Required: true, Description: "Expression computing each output variable."},
+		{Name: TimeoutKey, Type: types.ValueTypeString, Description: "Time limit of the evaluation, defaults to 1s and at most 5s."},
+	},
+}
+
+func init() {
+	nodes.Register(Descriptor.Kind, func() types.NodeExecutor { return &Executor{} }, Descriptor)
+}
+
+// Executor evaluates user-provided expressions in a sandbox: expressions only see the execution
+// variables, have no access to I/O, and are bounded in size, memory and time.
+// Only the declared output variables are computed and returned.
+type Executor struct {
+	args         map[string]any
+	outputFields []string
+	programs     map[string]*vm.Program
+	timeout      time.Duration
+}
+
+func (e *Executor) SetArgs(args map[string]any) {
+	e.args = args
+}
+
+func (e *Executor) SetOutputFields(fields []string) {
+	e.outputFields = fields
+}
+
+// ValidateAndParse implements NodeExecutor.
+func (e *Executor) ValidateAndParse(argsCheck []string) error {
+	if err := Descriptor.Validate(e.args, argsCheck); err != nil {
+		return err
+	}
+
+	expressions, ok := e.args[ExpressionsKey].(map[string]any)
+	if !ok {
+		return fmt.Errorf("%s: validation failed, %s must be object, got %s", e.ID(), ExpressionsKey, types.TypeOf(e.args[ExpressionsKey]))
+	}
+
+	e.timeout = DefaultTimeout
+	if raw, ok := e.args[TimeoutKey].(string); ok {
+		timeout, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("%s: validation failed to parse timeout: %w", e.ID(), err)
+		}
+		if timeout <= 0 || timeout > MaxTimeout {
+			return fmt.Errorf("%s: validation failed, timeout must be between 0 and %s", e.ID(), MaxTimeout)
+		}
+		e.timeout = timeout
+	}
+
+	if len(e.outputFields) == 0 {
+		return fmt.Errorf("%s: validation failed, at least one output variable must be declared", e.ID())
+	}
+
+	e.programs = make(map[string]*vm.Program, len(e.outputFields))
+	for _, field := range e.outputFields {
+		source, ok := expressions[field].(string)
+		if !ok {
+			return fmt.Errorf("%s: validation failed, output %s has no expression", e.ID(), field)
+		}
+
+		program, err := expr.Compile(source, expr.Env(e.args), expr.MaxNodes(MaxNodes))
+		if err != nil {
+			return fmt.Errorf("%s: failed to compile expression for %s: %w", e.ID(), field, err)
+		}
+		e.programs[field] = program
+	}
+
+	return nil
+}
+
+// ID implements NodeExecutor.
+func (e *Executor) ID() string {
+	return "script"
+}
+
+func (e *Executor) Execute(ctx context.Context) (any, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+
+	result := map[string]any{}
+	for _, field := range e.outputFields {
+		value, err := run(ctx, e.programs[field], e.args)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to evaluate %s: %w", e.ID(), field, err)
+		}
+		result[field] = value
+	}
+
+	return result, nil
+}
+
+// run evaluates program with a memory budget. The evaluation is abandoned when ctx is done;
+// the memory budget guarantees an abandoned evaluation finishes shortly after.
+func run(ctx context.Context, program *vm.Program, env map[string]any) (any, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	type outcome struct {
+		value any
+		err   error
+	}
+
+	done := make(chan outcome, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- outcome{err: fmt.Errorf("panic: %v", r)}
+			}
+		}()
+
+		machine := vm.VM{MemoryBudget: MemoryBudget}
+		value, err := machine.Run(program, env)
+		done <- outcome{value: value, err: err}
+	}()
+
+	select {
+	case o := <-done:
+		return o.value, o.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
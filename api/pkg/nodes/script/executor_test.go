@@ -0,0 +1,130 @@
+package script_test
+
+import (
+	"context"
+	"testing"
+	"workflow-code-test/api/pkg/nodes/script"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		name             string
+		expressions      map[string]any
+		extraArgs        map[string]any
+		outputFields     []string
+		expectedOutput   map[string]any
+		expectedErrorMsg string
+	}{
+		{
+			name:           "round the temperature",
+			expressions:    map[string]any{"rounded": "round(temperature * 10) / 10"},
+			outputFields:   []string{"rounded"},
+			expectedOutput: map[string]any{"rounded": 28.5},
+		},
+		{
+			name:           "build a greeting",
+			expressions:    map[string]any{"greeting": `"Hello " + name + ", it is " + string(temperature) + "°C in " + city`},
+			outputFields:   []string{"greeting"},
+			expectedOutput: map[string]any{"greeting": "Hello John Doe, it is 28.456°C in Sydney"},
+		},
+		{
+			name:           "pick from a list",
+			expressions:    map[string]any{"first": "recipients[0]", "count": "len(recipients)"},
+			outputFields:   []string{"first", "count"},
+			expectedOutput: map[string]any{"first": "a@example.com", "count": 2},
+		},
+		{
+			name:           "small program with variables",
+			expressions:    map[string]any{"hot": "let limit = threshold + 2; temperature > limit"},
+			outputFields:   []string{"hot"},
+			expectedOutput: map[string]any{"hot": true},
+		},
+		{
+			name:           "only declared outputs are returned",
+			expressions:    map[string]any{"rounded": "round(temperature)", "unused": "1 + 1"},
+			outputFields:   []string{"rounded"},
+			expectedOutput: map[string]any{"rounded": 28.0},
+		},
+		{
+			name:             "output without expression",
+			expressions:      map[string]any{"rounded": "round(temperature)"},
+			outputFields:     []string{"missing"},
+			expectedErrorMsg: "output missing has no expression",
+		},
+		{
+			name:             "no output declared",
+			expressions:      map[string]any{"rounded": "round(temperature)"},
+			expectedErrorMsg: "at least one output variable must be declared",
+		},
+		{
+			name:             "unknown variable",
+			expressions:      map[string]any{"value": "humidity * 2"},
+			outputFields:     []string{"value"},
+			expectedErrorMsg: "unknown name humidity",
+		},
+		{
+			name:             "memory budget exceeded",
+			expressions:      map[string]any{"values": "map(1..1000000, # * 2)"},
+			outputFields:     []string{"values"},
+			expectedErrorMsg: "memory budget exceeded",
+		},
+		{
+			name:             "invalid timeout",
+			expressions:      map[string]any{"rounded": "round(temperature)"},
+			extraArgs:        map[string]any{script.TimeoutKey: "1m"},
+			outputFields:     []string{"rounded"},
+			expectedErrorMsg: "timeout must be between 0 and 5s",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := map[string]any{
+				"name":                "John Doe",
+				"city":                "Sydney",
+				"temperature":         28.456,
+				"threshold":           25.0,
+				"recipients":          []any{"a@example.com", "b@example.com"},
+				script.ExpressionsKey: tt.expressions,
+			}
+			for k, v := range tt.extraArgs {
+				args[k] = v
+			}
+
+			executor := &script.Executor{}
+			executor.SetArgs(args)
+			executor.SetOutputFields(tt.outputFields)
+
+			err := executor.ValidateAndParse(nil)
+			if err == nil {
+				var outputs any
+				outputs, err = executor.Execute(context.Background())
+				if tt.expectedErrorMsg == "" {
+					require.NoError(t, err)
+					require.Equal(t, tt.expectedOutput, outputs)
+					return
+				}
+			}
+
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.expectedErrorMsg)
+		})
+	}
+}
+
+func TestExecute_Cancelled(t *testing.T) {
+	executor := &script.Executor{}
+	executor.SetArgs(map[string]any{
+		script.ExpressionsKey: map[string]any{"value": "1 + 1"},
+	})
+	executor.SetOutputFields([]string{"value"})
+	require.NoError(t, executor.ValidateAndParse(nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := executor.Execute(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
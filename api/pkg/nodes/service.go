@@ -1,55 +1,37 @@
 package nodes
 
 import (
-	"workflow-code-test/api/pkg/mailer"
-	"workflow-code-test/api/pkg/nodes/condition"
-	"workflow-code-test/api/pkg/nodes/email"
-	"workflow-code-test/api/pkg/nodes/form"
 	"workflow-code-test/api/pkg/nodes/types"
-	"workflow-code-test/api/pkg/nodes/weatherapi"
-	"workflow-code-test/api/pkg/openstreetmap"
-	"workflow-code-test/api/pkg/openweather"
 )
 
 type Service struct {
-	nodeFactories map[string]types.NodeExecutor
+	registry *Registry
 }
 
-func NewService(
-	geoClient openstreetmap.Client,
-	weatherClient openweather.Client,
-	mailClient mailer.Client,
-) *Service {
-	condition := types.NodeExecutor(&condition.Executor{})
-	weatherapi := types.NodeExecutor(&weatherapi.Executor{
-		Opts: &weatherapi.Options{
-			GeoClient:     geoClient,
-			WeatherClient: weatherClient,
-		},
-	})
-	form := types.NodeExecutor(&form.Executor{})
-	email := types.NodeExecutor(&email.Executor{
-		Opts: &email.Options{
-			MailClient: mailClient,
-		},
-	})
-
-	nodeFactories := map[string]types.NodeExecutor{
-		condition.ID():  condition,
-		weatherapi.ID(): weatherapi,
-		form.ID():       form,
-		email.ID():      email,
-	}
-
+// NewService creates a node service serving the kinds registered in registry.
+func NewService(registry *Registry) *Service {
 	return &Service{
-		nodeFactories: nodeFactories,
+		registry: registry,
 	}
 }
 
-func (s *Service) LoadNode(id string) types.NodeExecutor {
-	if node, ok := s.nodeFactories[id]; ok {
-		return node
-	}
+// LoadNode creates a new executor for the node kind. Returns nil if the kind is not registered.
+func (s *Service) LoadNode(kind string) types.NodeExecutor {
+	return s.registry.New(kind)
+}
+
+// Has reports whether the node kind is registered.
+func (s *Service) Has(kind string) bool {
+	_, ok := s.registry.Descriptor(kind)
+	return ok
+}
+
+// Descriptor returns the descriptor of the node kind and whether the kind is registered.
+func (s *Service) Descriptor(kind string) (types.Descriptor, bool) {
+	return s.registry.Descriptor(kind)
+}
 
-	return nil
+// Descriptors returns the descriptor of every available node kind, sorted by kind.
+func (s *Service) Descriptors() []types.Descriptor {
+	return s.registry.Descriptors()
 }
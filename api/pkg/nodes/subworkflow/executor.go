@@ -0,0 +1,181 @@
+package subworkflow
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"workflow-code-test/api/pkg/nodes/types"
+)
+
+const (
+	WorkflowIDKey    string = "workflowId"
+	InputMappingKey  string = "inputMapping"
+	OutputMappingKey string = "outputMapping"
+
+	// MaxDepth is how deeply sub-workflows may be nested, which stops workflows invoking themselves forever.
+	MaxDepth = 5
+)
+
+// Descriptor is the contract of the subworkflow node kind.
+var Descriptor = types.Descriptor{
+	Kind:        "subworkflow",
+	Description: "Runs another stored workflow with input mapped from the execution variables and returns its variables.",
+	Metadata: []types.Property{
+		{Name: WorkflowIDKey, Type: types.ValueTypeString, Required: true, Description: "ID of the workflow to run."},
+		{Name: InputMappingKey, Type: types.ValueTypeObject, Description: "Maps each input of the workflow to the variable it is taken from. The inputVariables are passed under the same names."},
+		{Name: OutputMappingKey, Type: types.ValueTypeObject, Description: "Maps each output variable to the workflow variable it is taken from. Unmapped outputs are taken from the variable of the same name."},
+	},
+}
+
+type Options struct {
+	Workflows types.WorkflowRunner
+}
+
+// Executor runs a workflow as a single node of another.
+// The child execution is recorded with the running execution as its parent.
+type Executor struct {
+	Opts *Options
+
+	args          map[string]any
+	outputFields  []string
+	workflowID    string
+	inputMapping  map[string]string
+	outputMapping map[string]string
+}
+
+func (e *Executor) SetArgs(args map[string]any) {
+	e.args = args
+}
+
+func (e *Executor) SetOutputFields(fields []string) {
+	e.outputFields = fields
+}
+
+// ValidateAndParse implements NodeExecutor.
+func (e *Executor) ValidateAndParse(argsCheck []string) error {
+	if err := Descriptor.Validate(e.args, argsCheck); err != nil {
+		return err
+	}
+
+	var ok bool
+	e.workflowID, ok = e.args[WorkflowIDKey].(string)
+	if !ok {
+		return fmt.Errorf("%s: validation failed, %s must be string, got %s", e.ID(), WorkflowIDKey, types.TypeOf(e.args[WorkflowIDKey]))
+	}
+
+	var err error
+	e.inputMapping, err = parseMapping(e.args[InputMappingKey], argsCheck)
+	if err != nil {
+		return fmt.Errorf("%s: validation failed to parse %s: %w", e.ID(), InputMappingKey, err)
+	}
+
+	e.outputMapping, err = parseMapping(e.args[OutputMappingKey], e.outputFields)
+	if err != nil {
+		return fmt.Errorf("%s: validation failed to parse %s: %w", e.ID(), OutputMappingKey, err)
+	}
+
+	for name := range e.outputMapping {
+		if !slices.Contains(e.outputFields, name) {
+			return fmt.Errorf("%s: validation failed, %s maps %s which is not an output variable", e.ID(), OutputMappingKey, name)
+		}
+	}
+
+	return nil
+}
+
+// ID implements NodeExecutor.
+func (e *Executor) ID() string {
+	return "subworkflow"
+}
+
+func (e *Executor) Execute(ctx context.Context) (any, error) {
+	input := make(map[string]any, len(e.inputMapping))
+	for name, variable := range e.inputMapping {
+		value, ok := e.args[variable]
+		if !ok {
+			return nil, fmt.Errorf("%s: variable %s mapped to input %s is not set", e.ID(), variable, name)
+		}
+		input[name] = value
+	}
+
+	result, err := Run(ctx, e.Opts.Workflows, e.workflowID, input)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", e.ID(), err)
+	}
+
+	variables := Variables(input, result.Outputs)
+
+	output := make(map[string]any, len(e.outputMapping))
+	for name, variable := range e.outputMapping {
+		value, ok := variables[variable]
+		if !ok {
+			return nil, fmt.Errorf("%s: workflow %s did not set %s mapped to output %s", e.ID(), e.workflowID, variable, name)
+		}
+		output[name] = value
+	}
+
+	return output, nil
+}
+
+// Run executes the workflow one level deeper than the execution in ctx, as the child of that execution.
+// It fails unless the workflow completes without pausing. A child execution that pauses is cancelled, so it is not
+// left waiting for a parent that no longer waits for it.
+func Run(ctx context.Context, workflows types.WorkflowRunner, workflowID string, input map[string]any) (*types.WorkflowResult, error) {
+	depth := types.Depth(ctx)
+	if depth >= MaxDepth {
+		return nil, fmt.Errorf("workflow %s nested deeper than %d levels, it is probably invoking itself", workflowID, MaxDepth)
+	}
+
+	result, err := workflows.RunWorkflow(types.WithDepth(ctx, depth+1), workflowID, &types.WorkflowInput{
+		Variables: maps.Clone(input),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("workflow %s failed: %w", workflowID, err)
+	}
+
+	switch result.Status {
+	case types.WorkflowStatusCompleted:
+		return result, nil
+	case types.WorkflowStatusWaiting:
+		if err := workflows.CancelWorkflow(context.WithoutCancel(ctx), result.ExecutionID); err != nil {
+			return nil, fmt.Errorf("workflow %s is waiting in execution %s, which failed to cancel: %w", workflowID, result.ExecutionID, err)
+		}
+		return nil, fmt.Errorf("workflow %s paused in execution %s, which was cancelled: sub-workflows must complete without pausing", workflowID, result.ExecutionID)
+	default:
+		return nil, fmt.Errorf("workflow %s ended as %s in execution %s", workflowID, result.Status, result.ExecutionID)
+	}
+}
+
+// Variables returns the variables of a completed execution: its input overlaid with every step's output.
+func Variables(input map[string]any, outputs []map[string]any) map[string]any {
+	variables := maps.Clone(input)
+	if variables == nil {
+		variables = map[string]any{}
+	}
+	for _, output := range outputs {
+		maps.Copy(variables, output)
+	}
+
+	return variables
+}
+
+// parseMapping parses a mapping of names to variables.
+// Each of the default names is mapped to the variable of the same name unless raw maps it.
+func parseMapping(raw any, defaults []string) (map[string]string, error) {
+	mapping := map[string]string{}
+	for _, name := range defaults {
+		mapping[name] = name
+	}
+
+	rawMapping, _ := raw.(map[string]any)
+	for name, value := range rawMapping {
+		variable, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s must map to a variable name, got %T", name, value)
+		}
+		mapping[name] = variable
+	}
+
+	return mapping, nil
+}
@@ -0,0 +1,195 @@
+package subworkflow_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"workflow-code-test/api/pkg/nodes/subworkflow"
+	"workflow-code-test/api/pkg/nodes/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockWorkflows struct {
+	result    *types.WorkflowResult
+	err       error
+	cancelErr error
+
+	workflowID string
+	input      map[string]any
+	depth      int
+	cancelled  []string
+}
+
+func (m *mockWorkflows) RunWorkflow(ctx context.Context, workflowID string, input *types.WorkflowInput) (*types.WorkflowResult, error) {
+	m.workflowID = workflowID
+	m.input = input.Variables
+	m.depth = types.Depth(ctx)
+	return m.result, m.err
+}
+
+func (m *mockWorkflows) CancelWorkflow(ctx context.Context, executionID string) error {
+	m.cancelled = append(m.cancelled, executionID)
+	return m.cancelErr
+}
+
+func completed(outputs ...map[string]any) *types.WorkflowResult {
+	return &types.WorkflowResult{
+		ExecutionID: "child",
+		Status:      types.WorkflowStatusCompleted,
+		Outputs:     outputs,
+	}
+}
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		name             string
+		args             map[string]any
+		inputVariables   []string
+		outputFields     []string
+		ctx              context.Context
+		workflows        *mockWorkflows
+		expectedInput    map[string]any
+		expectedOutput   map[string]any
+		expectedErrorMsg string
+	}{
+		{
+			name: "input variables and outputs under the same names",
+			args: map[string]any{
+				subworkflow.WorkflowIDKey: "child-workflow",
+				"city":                    "Sydney",
+				"unrelated":               true,
+			},
+			inputVariables: []string{"city"},
+			outputFields:   []string{"temperature"},
+			workflows:      &mockWorkflows{result: completed(map[string]any{"temperature": 28.5})},
+			expectedInput:  map[string]any{"city": "Sydney"},
+			expectedOutput: map[string]any{"temperature": 28.5},
+		},
+		{
+			name: "mapped inputs and outputs",
+			args: map[string]any{
+				subworkflow.WorkflowIDKey:    "child-workflow",
+				subworkflow.InputMappingKey:  map[string]any{"city": "destination"},
+				subworkflow.OutputMappingKey: map[string]any{"destinationTemperature": "temperature", "destinationCity": "city"},
+				"destination":                "Perth",
+			},
+			outputFields: []string{"destinationTemperature", "destinationCity"},
+			workflows: &mockWorkflows{result: completed(
+				map[string]any{"temperature": 10.0},
+				map[string]any{"temperature": 12.0},
+			)},
+			expectedInput:  map[string]any{"city": "Perth"},
+			expectedOutput: map[string]any{"destinationTemperature": 12.0, "destinationCity": "Perth"},
+		},
+		{
+			name: "unset input variable",
+			args: map[string]any{
+				subworkflow.WorkflowIDKey:   "child-workflow",
+				subworkflow.InputMappingKey: map[string]any{"city": "destination"},
+			},
+			workflows:        &mockWorkflows{result: completed()},
+			expectedErrorMsg: "variable destination mapped to input city is not set",
+		},
+		{
+			name:             "output not set by the workflow",
+			args:             map[string]any{subworkflow.WorkflowIDKey: "child-workflow"},
+			outputFields:     []string{"temperature"},
+			workflows:        &mockWorkflows{result: completed()},
+			expectedErrorMsg: "did not set temperature",
+		},
+		{
+			name: "output mapping of an undeclared output",
+			args: map[string]any{
+				subworkflow.WorkflowIDKey:    "child-workflow",
+				subworkflow.OutputMappingKey: map[string]any{"other": "temperature"},
+			},
+			outputFields:     []string{"temperature"},
+			workflows:        &mockWorkflows{},
+			expectedErrorMsg: "maps other which is not an output variable",
+		},
+		{
+			name:             "failed workflow",
+			args:             map[string]any{subworkflow.WorkflowIDKey: "child-workflow"},
+			workflows:        &mockWorkflows{err: errors.New("boom")},
+			expectedErrorMsg: "workflow child-workflow failed: boom",
+		},
+		{
+			name:             "null workflow",
+			args:             map[string]any{subworkflow.WorkflowIDKey: nil},
+			workflows:        &mockWorkflows{},
+			expectedErrorMsg: "workflowId is required",
+		},
+		{
+			name:             "recursion depth exceeded",
+			args:             map[string]any{subworkflow.WorkflowIDKey: "child-workflow"},
+			ctx:              types.WithDepth(context.Background(), subworkflow.MaxDepth),
+			workflows:        &mockWorkflows{result: completed()},
+			expectedErrorMsg: "nested deeper than 5 levels",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := &subworkflow.Executor{Opts: &subworkflow.Options{Workflows: tt.workflows}}
+			executor.SetArgs(tt.args)
+			executor.SetOutputFields(tt.outputFields)
+
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+
+			err := executor.ValidateAndParse(tt.inputVariables)
+			if err == nil {
+				var output any
+				output, err = executor.Execute(ctx)
+				if tt.expectedErrorMsg == "" {
+					require.NoError(t, err)
+					require.Equal(t, tt.expectedOutput, output)
+					require.Equal(t, "child-workflow", tt.workflows.workflowID)
+					require.Equal(t, tt.expectedInput, tt.workflows.input)
+					require.Equal(t, types.Depth(ctx)+1, tt.workflows.depth)
+					return
+				}
+			}
+
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.expectedErrorMsg)
+		})
+	}
+}
+
+func TestExecute_WaitingWorkflow(t *testing.T) {
+	tests := []struct {
+		name             string
+		cancelErr        error
+		expectedErrorMsg string
+	}{
+		{
+			name:             "cancelled",
+			expectedErrorMsg: "workflow child-workflow paused in execution child, which was cancelled: sub-workflows must complete without pausing",
+		},
+		{
+			name:             "failing to cancel",
+			cancelErr:        errors.New("boom"),
+			expectedErrorMsg: "workflow child-workflow is waiting in execution child, which failed to cancel: boom",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workflows := &mockWorkflows{
+				result:    &types.WorkflowResult{ExecutionID: "child", Status: types.WorkflowStatusWaiting},
+				cancelErr: tt.cancelErr,
+			}
+			executor := &subworkflow.Executor{Opts: &subworkflow.Options{Workflows: workflows}}
+			executor.SetArgs(map[string]any{subworkflow.WorkflowIDKey: "child-workflow"})
+			require.NoError(t, executor.ValidateAndParse(nil))
+
+			_, err := executor.Execute(context.Background())
+			require.EqualError(t, err, "subworkflow: "+tt.expectedErrorMsg)
+			require.Equal(t, []string{"child"}, workflows.cancelled)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package types
+
+import "context"
+
+type contextKey int
+
+const (
+	executionIDKey contextKey = iota
+	depthKey
+)
+
+// WithExecutionID returns a context carrying the ID of the execution running the node.
+func WithExecutionID(ctx context.Context, executionID string) context.Context {
+	return context.WithValue(ctx, executionIDKey, executionID)
+}
+
+// ExecutionID returns the ID of the execution running the node, or "" if there is none.
+func ExecutionID(ctx context.Context) string {
+	id, _ := ctx.Value(executionIDKey).(string)
+	return id
+}
+
+// WithDepth returns a context carrying how many workflows are nested around the execution,
+// 0 for executions that are not started by another workflow.
+func WithDepth(ctx context.Context, depth int) context.Context {
+	return context.WithValue(ctx, depthKey, depth)
+}
+
+// Depth returns how many workflows are nested around the execution.
+func Depth(ctx context.Context) int {
+	depth, _ := ctx.Value(depthKey).(int)
+	return depth
+}
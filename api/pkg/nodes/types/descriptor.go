@@ -0,0 +1,115 @@
+package types
+
+import (
+	"fmt"
+)
+
+// ValueType is the type of a value exchanged between nodes.
+type ValueType string
+
+const (
+	ValueTypeAny     ValueType = "any"
+	ValueTypeString  ValueType = "string"
+	ValueTypeNumber  ValueType = "number"
+	ValueTypeBoolean ValueType = "boolean"
+	ValueTypeObject  ValueType = "object"
+	ValueTypeArray   ValueType = "array"
+	ValueTypeNull    ValueType = "null"
+)
+
+// AssignableTo reports whether a value of type t can be used where a value of type want is expected.
+// ValueTypeAny is assignable to and from every other type.
+func (t ValueType) AssignableTo(want ValueType) bool {
+	return t == want || t == ValueTypeAny || want == ValueTypeAny
+}
+
+// TypeOf returns the ValueType of a value decoded from JSON.
+// A null value is only assignable to ValueTypeAny.
+func TypeOf(v any) ValueType {
+	switch v.(type) {
+	case nil:
+		return ValueTypeNull
+	case string:
+		return ValueTypeString
+	case float64, float32, int, int32, int64:
+		return ValueTypeNumber
+	case bool:
+		return ValueTypeBoolean
+	case map[string]any:
+		return ValueTypeObject
+	case []any:
+		return ValueTypeArray
+	default:
+		return ValueTypeAny
+	}
+}
+
+// Property describes a single named value of a schema.
+type Property struct {
+	Name        string    `json:"name"`
+	Type        ValueType `json:"type"`
+	Required    bool      `json:"required"`
+	Description string    `json:"description,omitempty"`
+}
+
+// Descriptor publishes the contract of a node kind.
+//
+// Inputs are the execution variables the node reads, Metadata the configuration keys it reads from
+// the node definition, and Outputs the values it produces in the order they are bound to the node's
+// outputVariables.
+type Descriptor struct {
+	Kind        string     `json:"kind"`
+	Description string     `json:"description"`
+	Inputs      []Property `json:"inputs"`
+	Outputs     []Property `json:"outputs"`
+	Metadata    []Property `json:"metadata"`
+}
+
+// OutputType returns the type of the i-th output variable.
+// A descriptor with a single output applies its type to every output variable.
+func (d Descriptor) OutputType(i int) ValueType {
+	switch {
+	case len(d.Outputs) == 1:
+		return d.Outputs[0].Type
+	case i < len(d.Outputs):
+		return d.Outputs[i].Type
+	default:
+		return ValueTypeAny
+	}
+}
+
+// Validate checks args against the descriptor's inputs and metadata schema.
+// argsCheck lists additional keys that must be present regardless of their type.
+// Properties set to null are missing, so a required property cannot be null.
+func (d Descriptor) Validate(args map[string]any, argsCheck []string) error {
+	for _, key := range argsCheck {
+		if _, ok := args[key]; !ok {
+			return fmt.Errorf("%s: validation key failed, key: %v", d.Kind, key)
+		}
+	}
+
+	for _, props := range [][]Property{d.Inputs, d.Metadata} {
+		for _, prop := range props {
+			value, ok := args[prop.Name]
+			if !ok || value == nil {
+				if prop.Required {
+					return fmt.Errorf("%s: validation failed, %s is required", d.Kind, prop.Name)
+				}
+				continue
+			}
+
+			if got := TypeOf(value); !got.AssignableTo(prop.Type) {
+				return fmt.Errorf("%s: validation failed, %s must be %s, got %s", d.Kind, prop.Name, prop.Type, got)
+			}
+		}
+	}
+
+	return nil
+}
+
+// OutputResolver is implemented by executors whose outputs depend on the node metadata,
+// such as a form whose outputs are its declared fields.
+type OutputResolver interface {
+	// ResolveOutputs returns the outputs the node produces for the given metadata.
+	ResolveOutputs(metadata map[string]any) ([]Property, error)
+}
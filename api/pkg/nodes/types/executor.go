@@ -26,3 +26,20 @@ type NodeExecutor interface {
 	// Returns an error if validation fails or parsing encounters issues.
 	ValidateAndParse(argsCheck []string) error
 }
+
+// Simulator is implemented by executors with side effects, such as sending an email or calling an external service.
+// In dry runs the engine calls Simulate instead of Execute, which reports what Execute would have done without doing it.
+type Simulator interface {
+	// Simulate returns the simulation of Execute with the node's arguments.
+	Simulate(ctx context.Context) (*Simulation, error)
+}
+
+// Simulation is what an executor would have done when executed.
+type Simulation struct {
+	// Output is the output Execute would have returned, or nil if it is only known once the side effect happened,
+	// such as the response of an external service.
+	Output any
+
+	// Effect describes the side effect, such as the email that would have been sent.
+	Effect map[string]any
+}
@@ -0,0 +1,37 @@
+package types
+
+import "context"
+
+// WorkflowRunner runs stored workflows for the nodes starting other workflows, such as subworkflow and foreach nodes.
+// It is implemented by the workflow engine, which is injected into the nodes once it exists.
+type WorkflowRunner interface {
+	// RunWorkflow executes a workflow as the child of the execution in ctx, until it finishes or pauses.
+	// Returns an error if the execution fails.
+	RunWorkflow(ctx context.Context, workflowID string, input *WorkflowInput) (*WorkflowResult, error)
+
+	// CancelWorkflow cancels an execution started by RunWorkflow, such as one that paused in a node that cannot wait.
+	CancelWorkflow(ctx context.Context, executionID string) error
+}
+
+// WorkflowInput is the input of a workflow run by a node.
+type WorkflowInput struct {
+	// Variables are the initial variables of the execution, given to the workflow as form data.
+	Variables map[string]any
+}
+
+// WorkflowStatus is the status of a workflow run by a node.
+type WorkflowStatus string
+
+const (
+	WorkflowStatusCompleted WorkflowStatus = "completed"
+	WorkflowStatusWaiting   WorkflowStatus = "waiting"
+)
+
+// WorkflowResult is the outcome of a workflow run by a node.
+type WorkflowResult struct {
+	ExecutionID string
+	Status      WorkflowStatus
+
+	// Outputs are the outputs of the steps of the execution, in the order they ran.
+	Outputs []map[string]any
+}
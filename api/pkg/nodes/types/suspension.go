@@ -0,0 +1,30 @@
+package types
+
+import "time"
+
+// Suspension is returned as the output of Execute by executors that pause the execution after the node ran.
+// The engine persists the execution and continues from the next node at ResumeAt,
+// or when the execution is resumed through the API if ResumeAt is zero.
+type Suspension struct {
+	// ResumeAt is when the execution resumes. The zero value waits for an external resume.
+	ResumeAt time.Time
+
+	// WaitingFor describes the input an external resume must provide. Nil when ResumeAt is set.
+	WaitingFor *Wait
+
+	// Output is the node output, merged into the execution variables when the node is suspended.
+	Output map[string]any
+}
+
+// Wait describes what a suspended execution is waiting for before it can be resumed through the API.
+type Wait struct {
+	// Message is shown to whoever resumes the execution.
+	Message string `json:"message,omitempty"`
+
+	// Fields is the schema of the form data submitted on resume, in the form node's inputFields format.
+	Fields any `json:"fields,omitempty"`
+
+	// Decision is the output variable set to whether the execution was approved.
+	// The node's outgoing edges are followed along the handle matching the decision.
+	Decision string `json:"decision"`
+}
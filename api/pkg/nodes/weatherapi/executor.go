@@ -3,11 +3,26 @@ package weatherapi
 import (
 	"context"
 	"fmt"
+	"math"
+	"net/http"
 
+	"workflow-code-test/api/pkg/nodes/types"
 	"workflow-code-test/api/pkg/openstreetmap"
 	"workflow-code-test/api/pkg/openweather"
 )
 
+// Descriptor is the contract of the weather-api node kind.
+var Descriptor = types.Descriptor{
+	Kind:        "weather-api",
+	Description: "Fetches the current temperature in Celsius for a city.",
+	Inputs: []types.Property{
+		{Name: "city", Type: types.ValueTypeString, Required: true, Description: "City name to get the weather for."},
+	},
+	Outputs: []types.Property{
+		{Name: "temperature", Type: types.ValueTypeNumber, Description: "Temperature in Celsius rounded to 2 decimal places."},
+	},
+}
+
 type Options struct {
 	GeoClient     openstreetmap.Client
 	WeatherClient openweather.Client
@@ -30,14 +45,7 @@ func (e *Executor) SetOutputFields(fields []string) {
 
 // Validate implements nodes.NodeExecutor.
 func (e *Executor) ValidateAndParse(argsCheck []string) error {
-	for _, key := range argsCheck {
-		_, ok := e.args[key].(string)
-		if !ok {
-			return fmt.Errorf("%s: validation key failed, key: %v", e.ID(), key)
-		}
-	}
-
-	return nil
+	return Descriptor.Validate(e.args, argsCheck)
 }
 
 // ID implements NodeExecutor.
@@ -46,12 +54,12 @@ func (e *Executor) ID() string {
 }
 
 func (e *Executor) Execute(ctx context.Context) (any, error) {
-	lat, lng, err := e.Opts.GeoClient.LatLngByCity(e.args["city"].(string))
+	lat, lng, err := e.Opts.GeoClient.LatLngByCity(ctx, e.args["city"].(string))
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to get lat lng: %w", e.ID(), err)
 	}
 
-	temperature, err := e.Opts.WeatherClient.TemperatureInCelsiusByLatLng(lat, lng)
+	temperature, err := e.Opts.WeatherClient.TemperatureInCelsiusByLatLng(ctx, lat, lng)
 	if err != nil {
 		return nil, fmt.Errorf("%s: failed to get weather: %w", e.ID(), err)
 	}
@@ -63,8 +71,21 @@ func (e *Executor) Execute(ctx context.Context) (any, error) {
 
 	result := map[string]any{}
 	for _, field := range e.outputFields {
-		result[field] = fmt.Sprintf("%.2f", temperature)
+		result[field] = math.Round(temperature*100) / 100
 	}
 
 	return result, nil
 }
+
+// Simulate implements types.Simulator.
+// The temperature is only known once the services answered, so the simulation has no output. Only the request
+// locating the city is reported, as the request fetching the temperature depends on its response.
+func (e *Executor) Simulate(ctx context.Context) (*types.Simulation, error) {
+	return &types.Simulation{
+		Effect: map[string]any{
+			"requests": []any{
+				map[string]any{"method": http.MethodGet, "url": openstreetmap.SearchURL(e.args["city"].(string))},
+			},
+		},
+	}, nil
+}
@@ -14,7 +14,7 @@ type MockGeoClient struct {
 	mock.Mock
 }
 
-func (m *MockGeoClient) LatLngByCity(city string) (float64, float64, error) {
+func (m *MockGeoClient) LatLngByCity(ctx context.Context, city string) (float64, float64, error) {
 	args := m.Called(city)
 	return args.Get(0).(float64), args.Get(1).(float64), args.Error(2)
 }
@@ -23,7 +23,7 @@ type MockWeatherClient struct {
 	mock.Mock
 }
 
-func (m *MockWeatherClient) TemperatureInCelsiusByLatLng(lat, lng float64) (float64, error) {
+func (m *MockWeatherClient) TemperatureInCelsiusByLatLng(ctx context.Context, lat, lng float64) (float64, error) {
 	args := m.Called(lat, lng)
 	return args.Get(0).(float64), args.Error(1)
 }
@@ -47,7 +47,7 @@ func TestExecutor_Execute(t *testing.T) {
 			expectedLng:  101.6942371,
 			expectedTemp: 28.5,
 			expectedOutput: map[string]any{
-				"temperature": "28.50",
+				"temperature": 28.5,
 			},
 		},
 		{
@@ -57,7 +57,7 @@ func TestExecutor_Execute(t *testing.T) {
 			expectedLng:  151.2082848,
 			expectedTemp: 22.3,
 			expectedOutput: map[string]any{
-				"temperature": "22.30",
+				"temperature": 22.3,
 			},
 		},
 		{
@@ -124,3 +124,37 @@ func TestExecutor_Execute(t *testing.T) {
 		})
 	}
 }
+
+func TestExecutor_Simulate(t *testing.T) {
+	mockGeoClient := &MockGeoClient{}
+	mockWeatherClient := &MockWeatherClient{}
+
+	executor := weatherapi.Executor{
+		Opts: &weatherapi.Options{
+			GeoClient:     mockGeoClient,
+			WeatherClient: mockWeatherClient,
+		},
+	}
+
+	executor.SetArgs(map[string]any{
+		"city": "Kuala Lumpur",
+	})
+	executor.SetOutputFields([]string{"temperature"})
+
+	err := executor.ValidateAndParse([]string{"city"})
+	require.NoError(t, err)
+
+	simulation, err := executor.Simulate(context.Background())
+	require.NoError(t, err)
+
+	require.Nil(t, simulation.Output)
+	require.Equal(t, map[string]any{
+		"requests": []any{
+			map[string]any{"method": "GET", "url": "https://nominatim.openstreetmap.org/search?q=Kuala+Lumpur&format=json"},
+		},
+	}, simulation.Effect)
+
+	// Neither service is called
+	mockGeoClient.AssertExpectations(t)
+	mockWeatherClient.AssertExpectations(t)
+}
@@ -1,6 +1,7 @@
 package openstreetmap
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -8,14 +9,22 @@ import (
 	"net/url"
 	"strconv"
 	"workflow-code-test/api/pkg/helper"
+	"workflow-code-test/api/pkg/metrics"
+	"workflow-code-test/api/pkg/telemetry"
 )
 
-type Impl struct{}
+type Impl struct {
+	httpClient *http.Client
+}
 
 // LatLngByCity implements Client.
-func (i *Impl) LatLngByCity(city string) (float64, float64, error) {
-	cityUrlEncoded := url.QueryEscape(city)
-	resp, err := http.Get(fmt.Sprintf("https://nominatim.openstreetmap.org/search?q=%s&format=json", cityUrlEncoded))
+func (i *Impl) LatLngByCity(ctx context.Context, city string) (float64, float64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, SearchURL(city), nil)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to create city req: %w", err)
+	}
+
+	resp, err := i.httpClient.Do(req)
 	if err != nil {
 		return 0, 0, fmt.Errorf("failed to get city resp: %w", err)
 	}
@@ -55,6 +64,17 @@ func (i *Impl) LatLngByCity(city string) (float64, float64, error) {
 	return latitude, longitude, nil
 }
 
+// SearchURL returns the URL of the request locating the city.
+func SearchURL(city string) string {
+	return fmt.Sprintf("https://nominatim.openstreetmap.org/search?q=%s&format=json", url.QueryEscape(city))
+}
+
+// NewClient returns a client whose requests are traced and measured.
 func NewClient() Client {
-	return &Impl{}
+	httpClient := telemetry.NewHTTPClient()
+	httpClient.Transport = metrics.InstrumentTransport("openstreetmap", httpClient.Transport)
+
+	return &Impl{
+		httpClient: httpClient,
+	}
 }
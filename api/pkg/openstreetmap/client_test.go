@@ -1,6 +1,7 @@
 package openstreetmap_test
 
 import (
+	"context"
 	"testing"
 	"workflow-code-test/api/pkg/openstreetmap"
 
@@ -40,7 +41,7 @@ func TestLatLngByCity(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotLat, gotLng, err := openstreetmap.NewClient().LatLngByCity(tt.city)
+			gotLat, gotLng, err := openstreetmap.NewClient().LatLngByCity(context.Background(), tt.city)
 
 			if tt.wantErr {
 				require.Error(t, err, "error on test case %s", tt.name)
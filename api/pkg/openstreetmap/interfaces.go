@@ -1,7 +1,10 @@
 package openstreetmap
 
+import "context"
+
 type Client interface {
 	// LatLngByCity retrieves the latitude and longitude coordinates for a given city.
 	// It returns the latitude, longitude, and an error if the city cannot be located.
-	LatLngByCity(city string) (float64, float64, error)
+	// The request is cancelled when ctx is done.
+	LatLngByCity(ctx context.Context, city string) (float64, float64, error)
 }
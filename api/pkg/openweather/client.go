@@ -1,17 +1,28 @@
 package openweather
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"workflow-code-test/api/pkg/metrics"
+	"workflow-code-test/api/pkg/telemetry"
 )
 
-type Impl struct{}
+type Impl struct {
+	httpClient *http.Client
+}
 
 // TemperatureInCelsiusByLatLng implements Client.
-func (i *Impl) TemperatureInCelsiusByLatLng(lat float64, lng float64) (float64, error) {
-	resp, err := http.Get(fmt.Sprintf("https://api.open-meteo.com/v1/forecast?latitude=%f&longitude=%f&current=temperature_2m", lat, lng))
+func (i *Impl) TemperatureInCelsiusByLatLng(ctx context.Context, lat float64, lng float64) (float64, error) {
+	url := fmt.Sprintf("https://api.open-meteo.com/v1/forecast?latitude=%f&longitude=%f&current=temperature_2m", lat, lng)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create temperature req: %w", err)
+	}
+
+	resp, err := i.httpClient.Do(req)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get temperature resp: %w", err)
 	}
@@ -34,6 +45,12 @@ func (i *Impl) TemperatureInCelsiusByLatLng(lat float64, lng float64) (float64,
 	return float64(temperature.Current.Temperature2M), nil
 }
 
+// NewClient returns a client whose requests are traced and measured.
 func NewClient() Client {
-	return &Impl{}
+	httpClient := telemetry.NewHTTPClient()
+	httpClient.Transport = metrics.InstrumentTransport("openweather", httpClient.Transport)
+
+	return &Impl{
+		httpClient: httpClient,
+	}
 }
@@ -1,6 +1,7 @@
 package openweather_test
 
 import (
+	"context"
 	"testing"
 	"workflow-code-test/api/pkg/openweather"
 
@@ -56,7 +57,7 @@ func TestTemperatureInCelsiusByLatLng(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotTemp, err := openweather.NewClient().TemperatureInCelsiusByLatLng(tt.lat, tt.lng)
+			gotTemp, err := openweather.NewClient().TemperatureInCelsiusByLatLng(context.Background(), tt.lat, tt.lng)
 
 			if tt.wantErr {
 				require.Error(t, err, "error on test case %s", tt.name)
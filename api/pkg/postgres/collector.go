@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PoolCollector exposes the statistics of a connection pool to Prometheus.
+type PoolCollector struct {
+	pool *pgxpool.Pool
+
+	acquiredConns       *prometheus.Desc
+	idleConns           *prometheus.Desc
+	constructingConns   *prometheus.Desc
+	totalConns          *prometheus.Desc
+	maxConns            *prometheus.Desc
+	acquires            *prometheus.Desc
+	acquireDuration     *prometheus.Desc
+	canceledAcquires    *prometheus.Desc
+	emptyAcquires       *prometheus.Desc
+	newConns            *prometheus.Desc
+	maxLifetimeDestroys *prometheus.Desc
+	maxIdleDestroys     *prometheus.Desc
+}
+
+var _ prometheus.Collector = (*PoolCollector)(nil)
+
+// NewPoolCollector returns a collector of the statistics of the pool, read each time the metrics are scraped.
+func NewPoolCollector(pool *pgxpool.Pool) *PoolCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc("pgxpool_"+name, help, nil, nil)
+	}
+
+	return &PoolCollector{
+		pool:                pool,
+		acquiredConns:       desc("acquired_conns", "Connections currently acquired from the pool."),
+		idleConns:           desc("idle_conns", "Idle connections in the pool."),
+		constructingConns:   desc("constructing_conns", "Connections being established."),
+		totalConns:          desc("total_conns", "Connections in the pool, acquired, idle or being established."),
+		maxConns:            desc("max_conns", "Maximum size of the pool."),
+		acquires:            desc("acquires_total", "Connections acquired from the pool."),
+		acquireDuration:     desc("acquire_duration_seconds_total", "Time spent acquiring connections from the pool."),
+		canceledAcquires:    desc("canceled_acquires_total", "Acquires canceled by their context."),
+		emptyAcquires:       desc("empty_acquires_total", "Acquires that waited for a connection as the pool was empty."),
+		newConns:            desc("new_conns_total", "Connections opened by the pool."),
+		maxLifetimeDestroys: desc("max_lifetime_destroys_total", "Connections closed for exceeding their maximum lifetime."),
+		maxIdleDestroys:     desc("max_idle_destroys_total", "Connections closed for exceeding their maximum idle time."),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *PoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *PoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.constructingConns, prometheus.GaugeValue, float64(stat.ConstructingConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquires, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireDuration, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.canceledAcquires, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquires, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.newConns, prometheus.CounterValue, float64(stat.NewConnsCount()))
+	ch <- prometheus.MustNewConstMetric(c.maxLifetimeDestroys, prometheus.CounterValue, float64(stat.MaxLifetimeDestroyCount()))
+	ch <- prometheus.MustNewConstMetric(c.maxIdleDestroys, prometheus.CounterValue, float64(stat.MaxIdleDestroyCount()))
+}
@@ -0,0 +1,36 @@
+package postgres_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"workflow-code-test/api/pkg/postgres"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoolCollector(t *testing.T) {
+	// The pool connects lazily, so no database is needed to read its statistics
+	pool, err := pgxpool.New(context.Background(), "postgres://workflow@localhost:5432/workflow?pool_max_conns=7")
+	require.NoError(t, err)
+	defer pool.Close()
+
+	collector := postgres.NewPoolCollector(pool)
+	require.Equal(t, 12, testutil.CollectAndCount(collector))
+
+	expected := `
+# HELP pgxpool_max_conns Maximum size of the pool.
+# TYPE pgxpool_max_conns gauge
+pgxpool_max_conns 7
+# HELP pgxpool_total_conns Connections in the pool, acquired, idle or being established.
+# TYPE pgxpool_total_conns gauge
+pgxpool_total_conns 0
+`
+	require.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected), "pgxpool_max_conns", "pgxpool_total_conns"))
+
+	problems, err := testutil.CollectAndLint(collector)
+	require.NoError(t, err)
+	require.Empty(t, problems)
+}
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+UPDATE workflow_nodes
+SET data_metadata = jsonb_set(data_metadata, '{inputFields}', '[
+    {"name": "name", "type": "string", "required": true, "min": 1, "max": 50},
+    {"name": "email", "type": "string", "required": true, "format": "email"},
+    {"name": "city", "type": "string", "required": true, "min": 1, "max": 100},
+    {"name": "operator", "type": "string", "required": true, "enum": ["greater_than", "less_than", "equals", "greater_than_or_equal", "less_than_or_equal"]},
+    {"name": "threshold", "type": "number", "required": true, "min": -100, "max": 100}
+]'::jsonb)
+WHERE node_id = 'form'
+AND data_metadata->'inputFields' = '["name", "email", "city"]'::jsonb;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- Only the schema set by Up is reverted, leaving forms edited since untouched.
+UPDATE workflow_nodes
+SET data_metadata = jsonb_set(data_metadata, '{inputFields}', '["name", "email", "city"]'::jsonb)
+WHERE node_id = 'form'
+AND data_metadata->'inputFields' = '[
+    {"name": "name", "type": "string", "required": true, "min": 1, "max": 50},
+    {"name": "email", "type": "string", "required": true, "format": "email"},
+    {"name": "city", "type": "string", "required": true, "min": 1, "max": 100},
+    {"name": "operator", "type": "string", "required": true, "enum": ["greater_than", "less_than", "equals", "greater_than_or_equal", "less_than_or_equal"]},
+    {"name": "threshold", "type": "number", "required": true, "min": -100, "max": 100}
+]'::jsonb;
+-- +goose StatementEnd
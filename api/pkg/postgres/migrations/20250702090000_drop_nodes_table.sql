@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Available node kinds are defined by the node registry in pkg/nodes, not by this table.
+ALTER TABLE workflow_nodes DROP CONSTRAINT IF EXISTS workflow_nodes_fk;
+ALTER TABLE workflow_edges DROP CONSTRAINT IF EXISTS workflow_edges_fk1;
+ALTER TABLE workflow_edges DROP CONSTRAINT IF EXISTS workflow_edges_fk2;
+
+DROP TABLE IF EXISTS nodes;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS nodes (
+	id varchar NOT NULL,
+    is_default boolean DEFAULT false NOT NULL,
+	created_at timestamptz DEFAULT now() NOT NULL,
+	updated_at timestamptz DEFAULT now() NOT NULL,
+	
+	CONSTRAINT nodes_pkey PRIMARY KEY (id)
+);
+
+INSERT INTO nodes (id, is_default) VALUES
+    ('start', true),
+    ('end', true),
+    ('form', false),
+    ('weather-api', false),
+    ('condition', false),
+    ('email', false)
+ON CONFLICT (id) DO NOTHING;
+
+-- Nodes added since Up are not limited to the seeded IDs, so their IDs are restored for the constraints to accept them
+INSERT INTO nodes (id)
+SELECT node_id FROM workflow_nodes
+UNION SELECT node_source FROM workflow_edges
+UNION SELECT node_target FROM workflow_edges
+ON CONFLICT (id) DO NOTHING;
+
+ALTER TABLE workflow_nodes DROP CONSTRAINT IF EXISTS workflow_nodes_fk;
+ALTER TABLE workflow_edges DROP CONSTRAINT IF EXISTS workflow_edges_fk1;
+ALTER TABLE workflow_edges DROP CONSTRAINT IF EXISTS workflow_edges_fk2;
+
+ALTER TABLE workflow_nodes ADD CONSTRAINT workflow_nodes_fk FOREIGN KEY (node_id) REFERENCES nodes(id);
+ALTER TABLE workflow_edges ADD CONSTRAINT workflow_edges_fk1 FOREIGN KEY (node_source) REFERENCES nodes(id);
+ALTER TABLE workflow_edges ADD CONSTRAINT workflow_edges_fk2 FOREIGN KEY (node_target) REFERENCES nodes(id);
+-- +goose StatementEnd
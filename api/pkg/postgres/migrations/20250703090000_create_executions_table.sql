@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE executions (
+	id uuid DEFAULT gen_random_uuid() NOT NULL,
+	workflow_id uuid NOT NULL,
+	status varchar NOT NULL,
+	state jsonb DEFAULT '{}'::jsonb NOT NULL,
+	steps jsonb DEFAULT '[]'::jsonb NOT NULL,
+	resume_at timestamptz NULL,
+	executed_at timestamptz DEFAULT now() NOT NULL,
+	created_at timestamptz DEFAULT now() NOT NULL,
+	updated_at timestamptz DEFAULT now() NOT NULL,
+
+	CONSTRAINT executions_pkey PRIMARY KEY (id),
+	CONSTRAINT executions_fk FOREIGN KEY (workflow_id) REFERENCES workflows(id) ON DELETE CASCADE
+);
+
+-- Timers only look up waiting executions that are due
+CREATE INDEX executions_resume_at_idx ON executions (resume_at) WHERE status = 'waiting';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE executions;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- What a waiting execution needs to be resumed through the API, such as an approval
+ALTER TABLE executions ADD COLUMN waiting_for jsonb NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE executions DROP COLUMN waiting_for;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE workflow_schedules (
+	id uuid DEFAULT gen_random_uuid() NOT NULL,
+	workflow_id uuid NOT NULL,
+	cron varchar NOT NULL,
+	timezone varchar DEFAULT 'UTC' NOT NULL,
+	input_data jsonb DEFAULT '{}'::jsonb NOT NULL,
+	enabled boolean DEFAULT true NOT NULL,
+	next_run_at timestamptz NULL,
+	last_run_at timestamptz NULL,
+	created_at timestamptz DEFAULT now() NOT NULL,
+	updated_at timestamptz DEFAULT now() NOT NULL,
+
+	CONSTRAINT workflow_schedules_pkey PRIMARY KEY (id),
+	CONSTRAINT workflow_schedules_fk FOREIGN KEY (workflow_id) REFERENCES workflows(id) ON DELETE CASCADE
+);
+
+-- The scheduler only looks up enabled schedules that are due
+CREATE INDEX workflow_schedules_next_run_at_idx ON workflow_schedules (next_run_at) WHERE enabled;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE workflow_schedules;
+-- +goose StatementEnd
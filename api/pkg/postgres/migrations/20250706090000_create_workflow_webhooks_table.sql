@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE workflow_webhooks (
+	id uuid DEFAULT gen_random_uuid() NOT NULL,
+	workflow_id uuid NOT NULL,
+	secret varchar NOT NULL,
+	mapping jsonb DEFAULT '{}'::jsonb NOT NULL,
+	created_at timestamptz DEFAULT now() NOT NULL,
+	updated_at timestamptz DEFAULT now() NOT NULL,
+
+	CONSTRAINT workflow_webhooks_pkey PRIMARY KEY (id),
+	CONSTRAINT workflow_webhooks_fk FOREIGN KEY (workflow_id) REFERENCES workflows(id) ON DELETE CASCADE
+);
+
+-- Nonces of the deliveries received within the replay window
+CREATE TABLE webhook_nonces (
+	webhook_id uuid NOT NULL,
+	nonce varchar NOT NULL,
+	received_at timestamptz DEFAULT now() NOT NULL,
+
+	CONSTRAINT webhook_nonces_pkey PRIMARY KEY (webhook_id, nonce),
+	CONSTRAINT webhook_nonces_fk FOREIGN KEY (webhook_id) REFERENCES workflow_webhooks(id) ON DELETE CASCADE
+);
+
+CREATE INDEX webhook_nonces_received_at_idx ON webhook_nonces (received_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE webhook_nonces;
+DROP TABLE workflow_webhooks;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Executions started by a subworkflow node are linked to the execution running the node
+ALTER TABLE executions ADD COLUMN parent_execution_id uuid NULL;
+ALTER TABLE executions ADD CONSTRAINT executions_parent_fk FOREIGN KEY (parent_execution_id) REFERENCES executions(id) ON DELETE SET NULL;
+
+CREATE INDEX executions_parent_execution_id_idx ON executions (parent_execution_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE executions DROP COLUMN parent_execution_id;
+-- +goose StatementEnd
@@ -0,0 +1,83 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE workflow_versions (
+	id uuid DEFAULT gen_random_uuid() NOT NULL,
+	workflow_id uuid NOT NULL,
+	"version" integer NULL,
+	status varchar NOT NULL,
+	author varchar DEFAULT '' NOT NULL,
+	change_note varchar DEFAULT '' NOT NULL,
+	definition jsonb NOT NULL,
+	published_at timestamptz NULL,
+	created_at timestamptz DEFAULT now() NOT NULL,
+	updated_at timestamptz DEFAULT now() NOT NULL,
+
+	CONSTRAINT workflow_versions_pkey PRIMARY KEY (id),
+	CONSTRAINT workflow_versions_fk FOREIGN KEY (workflow_id) REFERENCES workflows(id) ON DELETE CASCADE,
+	CONSTRAINT workflow_versions_version_key UNIQUE (workflow_id, "version"),
+	-- Versions are numbered when they are published
+	CONSTRAINT workflow_versions_version_check CHECK ((status = 'draft') = ("version" IS NULL))
+);
+
+-- A workflow has at most one draft
+CREATE UNIQUE INDEX workflow_versions_draft_idx ON workflow_versions (workflow_id) WHERE status = 'draft';
+
+ALTER TABLE workflows ADD COLUMN published_version integer NULL;
+ALTER TABLE executions ADD COLUMN workflow_version integer NULL;
+
+-- Existing workflows become their first published version
+INSERT INTO workflow_versions (workflow_id, "version", status, change_note, definition, published_at)
+SELECT
+	w.id,
+	1,
+	'published',
+	'Initial version',
+	jsonb_build_object(
+		'nodes', coalesce((
+			SELECT jsonb_agg(jsonb_build_object(
+				'id', wn.node_id,
+				'workflowId', w.id,
+				'type', wn.kind,
+				'position', jsonb_build_object('x', wn.position_x, 'y', wn.position_y),
+				'data', jsonb_build_object(
+					'label', wn.data_label,
+					'description', wn.data_description,
+					'metadata', wn.data_metadata
+				),
+				'createdAt', wn.created_at,
+				'updatedAt', wn.updated_at
+			) ORDER BY wn.created_at, wn.node_id)
+			FROM workflow_nodes wn
+			WHERE wn.workflow_id = w.id
+		), '[]'::jsonb),
+		'edges', coalesce((
+			SELECT jsonb_agg(jsonb_build_object(
+				'id', we.node_source || '-' || we.node_target,
+				'source', we.node_source,
+				'target', we.node_target,
+				'type', we.kind,
+				'animated', we.is_animated,
+				'sourceHandle', we.is_source_handle::text,
+				'style', we.style,
+				'label', coalesce(we.label, ''),
+				'labelStyle', we.label_style,
+				'createdAt', we.created_at,
+				'updatedAt', we.updated_at
+			) ORDER BY we.created_at, we.node_source, we.node_target)
+			FROM workflow_edges we
+			WHERE we.workflow_id = w.id
+		), '[]'::jsonb)
+	),
+	w.updated_at
+FROM workflows w;
+
+UPDATE workflows SET published_version = 1;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE executions DROP COLUMN workflow_version;
+ALTER TABLE workflows DROP COLUMN published_version;
+
+DROP TABLE workflow_versions;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Breakpoints of executions started in debug mode, and the node a paused execution runs next
+ALTER TABLE executions ADD COLUMN debug jsonb NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE executions DROP COLUMN debug;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The form data executions start with, replayed by reruns, which are linked to the execution they replay
+ALTER TABLE executions ADD COLUMN input jsonb NULL;
+ALTER TABLE executions ADD COLUMN rerun_of uuid NULL;
+ALTER TABLE executions ADD CONSTRAINT executions_rerun_of_fk FOREIGN KEY (rerun_of) REFERENCES executions(id) ON DELETE SET NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE executions DROP COLUMN rerun_of;
+ALTER TABLE executions DROP COLUMN input;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Set to stop a running execution, checked by the API instance running it
+ALTER TABLE executions ADD COLUMN cancel_requested boolean NOT NULL DEFAULT false;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE executions DROP COLUMN cancel_requested;
+-- +goose StatementEnd
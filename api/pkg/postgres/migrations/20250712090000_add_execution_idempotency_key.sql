@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The Idempotency-Key of the request starting an execution, unique per workflow, with the hash of its input
+ALTER TABLE executions ADD COLUMN idempotency_key text NULL;
+ALTER TABLE executions ADD COLUMN request_hash text NULL;
+CREATE UNIQUE INDEX executions_idempotency_key_idx ON executions (workflow_id, idempotency_key) WHERE idempotency_key IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX executions_idempotency_key_idx;
+ALTER TABLE executions DROP COLUMN request_hash;
+ALTER TABLE executions DROP COLUMN idempotency_key;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- When executions completed, failed or were cancelled, reported with their duration
+ALTER TABLE executions ADD COLUMN finished_at timestamptz NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE executions DROP COLUMN finished_at;
+-- +goose StatementEnd
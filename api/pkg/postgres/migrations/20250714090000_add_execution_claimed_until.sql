@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Until when an execution resumed by a timer is claimed by the API instance running it. Claims not renewed in time,
+-- as the instance stopped, are taken over by another timer.
+ALTER TABLE executions ADD COLUMN claimed_until timestamptz NULL;
+CREATE INDEX executions_claimed_until_idx ON executions (claimed_until) WHERE status = 'running';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS executions_claimed_until_idx;
+ALTER TABLE executions DROP COLUMN IF EXISTS claimed_until;
+-- +goose StatementEnd
@@ -44,7 +44,13 @@ func NewService(ctx context.Context, opts *Options) (*Service, error) {
 		return nil, fmt.Errorf("connection uri is required")
 	}
 
-	pool, err := pgxpool.New(ctx, opts.ConnectionURI)
+	poolConfig, err := pgxpool.ParseConfig(opts.ConnectionURI)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse connection uri: %w", err)
+	}
+	poolConfig.ConnConfig.Tracer = &QueryTracer{}
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create pgx pool: %w", err)
 	}
@@ -74,6 +80,11 @@ func (s *Service) Pool() *pgxpool.Pool {
 	return s.pool
 }
 
+// Ping checks that a connection to the database can be acquired and used.
+func (s *Service) Ping(ctx context.Context) error {
+	return s.pool.Ping(ctx)
+}
+
 func (s *Service) Disconnect(ctx context.Context) {
 	if s.conn != nil {
 		s.conn.Close(ctx)
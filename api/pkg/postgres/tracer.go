@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"workflow-code-test/api/pkg/telemetry"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// QueryTracer traces the queries run by pgx, each in a span named after its operation, e.g. "db.select".
+type QueryTracer struct{}
+
+var _ pgx.QueryTracer = (*QueryTracer)(nil)
+
+// TraceQueryStart implements pgx.QueryTracer.
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	operation := queryOperation(data.SQL)
+
+	ctx, _ = telemetry.Tracer().Start(ctx, "db."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemPostgreSQL,
+			semconv.DBOperationName(operation),
+			semconv.DBQueryTextKey.String(data.SQL),
+		),
+	)
+
+	return ctx
+}
+
+// TraceQueryEnd implements pgx.QueryTracer.
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	defer span.End()
+
+	if data.Err != nil {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+		return
+	}
+
+	span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+}
+
+// queryOperation returns the lowercased first keyword of the query, such as select or update.
+func queryOperation(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "query"
+	}
+
+	return strings.ToLower(fields[0])
+}
@@ -0,0 +1,64 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"workflow-code-test/api/pkg/postgres"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func record(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	provider := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(provider) })
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	return recorder
+}
+
+func TestQueryTracer(t *testing.T) {
+	recorder := record(t)
+	tracer := &postgres.QueryTracer{}
+
+	const sql = "UPDATE executions SET status = @status WHERE id = @id"
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: sql})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("UPDATE 1")})
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, "db.update", spans[0].Name())
+	require.Equal(t, codes.Unset, spans[0].Status().Code)
+	require.ElementsMatch(t, []attribute.KeyValue{
+		attribute.String("db.system", "postgresql"),
+		attribute.String("db.operation.name", "update"),
+		attribute.String("db.query.text", sql),
+		attribute.Int64("db.rows_affected", 1),
+	}, spans[0].Attributes())
+}
+
+func TestQueryTracerError(t *testing.T) {
+	recorder := record(t)
+	tracer := &postgres.QueryTracer{}
+
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "\n  select * from workflows"})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: errors.New("connection refused")})
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, "db.select", spans[0].Name())
+	require.Equal(t, codes.Error, spans[0].Status().Code)
+	require.Equal(t, "connection refused", spans[0].Status().Description)
+	require.Len(t, spans[0].Events(), 1)
+}
@@ -4,16 +4,67 @@ import "errors"
 
 var (
 	ErrInvalidWorkflowID   = errors.New("invalid workflow id")
+	ErrInvalidExecutionID  = errors.New("invalid execution id")
+	ErrInvalidScheduleID   = errors.New("invalid schedule id")
+	ErrInvalidWebhookID    = errors.New("invalid webhook id")
+	ErrInvalidVersion      = errors.New("invalid workflow version")
+	ErrInvalidFormat       = errors.New("invalid document format, expected json or yaml")
+	ErrInvalidDocument     = errors.New("invalid document")
+	ErrInvalidSignature    = errors.New("invalid webhook signature")
+	ErrInvalidDryRun       = errors.New("invalid dryRun, expected true or false")
+	ErrLongIdempotencyKey  = errors.New("idempotency key is longer than 255 characters")
+	ErrIdempotencyConflict = errors.New("idempotency key was used with a different request")
+	ErrReplayedDelivery    = errors.New("webhook delivery already received")
+	ErrPayloadTooLarge     = errors.New("payload too large")
+	ErrExecutionNotWaiting = errors.New("execution is not waiting for input")
+	ErrExecutionNotPaused  = errors.New("execution is not paused")
+	ErrExecutionNotFailed  = errors.New("execution has not failed")
+	ErrExecutionFinished   = errors.New("execution has already finished")
 	ErrNotFound            = errors.New("not found")
 	ErrInternalServerError = errors.New("internal server error")
+	ErrValidationFailed    = errors.New("validation failed")
 )
 
 func GetAPIError(err error) error {
 	switch err {
 	case ErrInvalidWorkflowID:
 		return err
+	case ErrInvalidExecutionID:
+		return err
+	case ErrInvalidScheduleID:
+		return err
+	case ErrInvalidWebhookID:
+		return err
+	case ErrInvalidVersion:
+		return err
+	case ErrInvalidFormat:
+		return err
+	case ErrInvalidDocument:
+		return err
+	case ErrInvalidSignature:
+		return err
+	case ErrInvalidDryRun:
+		return err
+	case ErrLongIdempotencyKey:
+		return err
+	case ErrIdempotencyConflict:
+		return err
+	case ErrReplayedDelivery:
+		return err
+	case ErrPayloadTooLarge:
+		return err
+	case ErrExecutionNotWaiting:
+		return err
+	case ErrExecutionNotPaused:
+		return err
+	case ErrExecutionNotFailed:
+		return err
+	case ErrExecutionFinished:
+		return err
 	case ErrNotFound:
 		return err
+	case ErrValidationFailed:
+		return err
 	default:
 		return ErrInternalServerError
 	}
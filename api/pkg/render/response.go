@@ -23,7 +23,7 @@ func JSON(w http.ResponseWriter, r *http.Request, status int, v any) {
 
 func Error(w http.ResponseWriter, r *http.Request, status int, err error, log *slog.Logger) {
 	if log != nil {
-		log.Error("API Error",
+		log.ErrorContext(r.Context(), "API Error",
 			"endpoint", r.URL.Path,
 			"method", r.Method,
 			"status", status,
@@ -33,3 +33,20 @@ func Error(w http.ResponseWriter, r *http.Request, status int, err error, log *s
 	errorJSON := map[string]string{"error": GetAPIError(err).Error()}
 	JSON(w, r, status, errorJSON)
 }
+
+// ValidationError writes a 422 response listing the message for each invalid field.
+func ValidationError(w http.ResponseWriter, r *http.Request, fields map[string]string, log *slog.Logger) {
+	if log != nil {
+		log.ErrorContext(r.Context(), "API Error",
+			"endpoint", r.URL.Path,
+			"method", r.Method,
+			"status", http.StatusUnprocessableEntity,
+			"fields", fields,
+		)
+	}
+
+	JSON(w, r, http.StatusUnprocessableEntity, map[string]any{
+		"error":  ErrValidationFailed.Error(),
+		"fields": fields,
+	})
+}
@@ -0,0 +1,48 @@
+package telemetry
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Middleware traces the requests served by a router, continuing the trace of the caller if any.
+// Spans are named after the method and the route, e.g. "POST /api/v1/workflows/{id}/execute".
+func Middleware(next http.Handler) http.Handler {
+	route := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if template, ok := routeTemplate(r); ok {
+			trace.SpanFromContext(r.Context()).SetAttributes(semconv.HTTPRoute(template))
+		}
+		next.ServeHTTP(w, r)
+	})
+
+	return otelhttp.NewHandler(route, "http.request", otelhttp.WithSpanNameFormatter(spanName))
+}
+
+// NewHTTPClient returns a client tracing the requests it sends and propagating the trace to the servers called.
+func NewHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: otelhttp.NewTransport(http.DefaultTransport),
+	}
+}
+
+func spanName(_ string, r *http.Request) string {
+	if template, ok := routeTemplate(r); ok {
+		return r.Method + " " + template
+	}
+
+	return r.Method
+}
+
+func routeTemplate(r *http.Request) (string, bool) {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return "", false
+	}
+
+	template, err := route.GetPathTemplate()
+	return template, err == nil
+}
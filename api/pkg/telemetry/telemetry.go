@@ -0,0 +1,104 @@
+// Package telemetry traces the API with OpenTelemetry: HTTP requests, executions, nodes, database queries
+// and outbound HTTP calls. Spans are recorded by the global tracer provider installed by Setup, so nothing
+// is recorded until it is called.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Exporters spans are sent with.
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+const instrumentationName = "workflow-code-test/api"
+
+// Attributes of the spans of executions and nodes.
+const (
+	WorkflowID      = attribute.Key("workflow.id")
+	WorkflowVersion = attribute.Key("workflow.version")
+	ExecutionID     = attribute.Key("execution.id")
+	ExecutionStatus = attribute.Key("execution.status")
+	ExecutionDryRun = attribute.Key("execution.dry_run")
+	NodeID          = attribute.Key("node.id")
+	NodeType        = attribute.Key("node.type")
+	NodeAttempt     = attribute.Key("node.attempt")
+	NodeStatus      = attribute.Key("node.status")
+)
+
+// Options configure the tracer provider installed by Setup.
+type Options struct {
+	// Exporter is ExporterNone, ExporterStdout or ExporterOTLP. The OTLP exporter sends spans over HTTP and is
+	// configured by the standard OTEL_EXPORTER_OTLP_* environment variables.
+	Exporter string
+	// ServiceName identifies the API in the exported spans.
+	ServiceName string
+	// Writer receives the spans of the stdout exporter, os.Stdout if nil.
+	Writer io.Writer
+}
+
+// Tracer returns the tracer of the API from the global tracer provider.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Setup installs the global tracer provider exporting spans as configured, along with the W3C trace context
+// propagator, so traces continue across the services the API calls and is called by.
+// The returned function flushes the spans left and stops the provider.
+func Setup(ctx context.Context, opts *Options) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	switch opts.Exporter {
+	case ExporterNone, "":
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		writer := opts.Writer
+		if writer == nil {
+			writer = os.Stdout
+		}
+
+		stdout, err := stdouttrace.New(stdouttrace.WithWriter(writer))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create stdout exporter: %w", err)
+		}
+		exporter = stdout
+	case ExporterOTLP:
+		otlp, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+		}
+		exporter = otlp
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q, expected %s, %s or %s", opts.Exporter, ExporterNone, ExporterStdout, ExporterOTLP)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(semconv.ServiceName(opts.ServiceName)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
@@ -0,0 +1,144 @@
+package telemetry_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"workflow-code-test/api/pkg/telemetry"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// record installs a tracer provider recording the spans ended during the test.
+func record(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	provider, propagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(provider)
+		otel.SetTextMapPropagator(propagator)
+	})
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return recorder
+}
+
+func attributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	values := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		values[kv.Key] = kv.Value
+	}
+
+	return values
+}
+
+func TestSetup(t *testing.T) {
+	provider := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(provider) })
+
+	t.Run("none", func(t *testing.T) {
+		stop, err := telemetry.Setup(context.Background(), &telemetry.Options{Exporter: telemetry.ExporterNone})
+		require.NoError(t, err)
+		require.NoError(t, stop(context.Background()))
+	})
+
+	t.Run("stdout", func(t *testing.T) {
+		var out bytes.Buffer
+		stop, err := telemetry.Setup(context.Background(), &telemetry.Options{
+			Exporter:    telemetry.ExporterStdout,
+			ServiceName: "workflow-test",
+			Writer:      &out,
+		})
+		require.NoError(t, err)
+
+		_, span := telemetry.Tracer().Start(context.Background(), "test.span")
+		span.End()
+		require.NoError(t, stop(context.Background()))
+
+		require.Contains(t, out.String(), `"Name":"test.span"`)
+		require.Contains(t, out.String(), "workflow-test")
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		_, err := telemetry.Setup(context.Background(), &telemetry.Options{Exporter: "zipkin"})
+		require.ErrorContains(t, err, `unknown trace exporter "zipkin"`)
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	recorder := record(t)
+
+	router := mux.NewRouter()
+	router.Use(telemetry.Middleware)
+	router.HandleFunc("/workflows/{id}", func(w http.ResponseWriter, r *http.Request) {
+		require.True(t, trace.SpanFromContext(r.Context()).SpanContext().IsValid())
+		w.WriteHeader(http.StatusNoContent)
+	}).Methods(http.MethodGet)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/workflows/abc", nil))
+	require.Equal(t, http.StatusNoContent, rec.Code)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, "GET /workflows/{id}", spans[0].Name())
+	require.Equal(t, trace.SpanKindServer, spans[0].SpanKind())
+	require.Equal(t, "/workflows/{id}", attributes(spans[0])["http.route"].AsString())
+}
+
+func TestMiddlewareContinuesTrace(t *testing.T) {
+	recorder := record(t)
+
+	router := mux.NewRouter()
+	router.Use(telemetry.Middleware)
+	router.HandleFunc("/workflows", func(http.ResponseWriter, *http.Request) {})
+
+	req := httptest.NewRequest(http.MethodGet, "/workflows", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+	require.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+}
+
+func TestNewHTTPClient(t *testing.T) {
+	recorder := record(t)
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+	}))
+	defer server.Close()
+
+	ctx, parent := telemetry.Tracer().Start(context.Background(), "parent")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+
+	resp, err := telemetry.NewHTTPClient().Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	client := spans[0]
+	require.Equal(t, trace.SpanKindClient, client.SpanKind())
+	require.Equal(t, parent.SpanContext().SpanID(), client.Parent().SpanID())
+	require.Contains(t, traceparent, client.SpanContext().TraceID().String())
+	require.Contains(t, traceparent, client.SpanContext().SpanID().String())
+}
@@ -0,0 +1,69 @@
+// Package version reports the build of the API.
+package version
+
+import (
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"workflow-code-test/api/pkg/render"
+)
+
+// Build metadata, set when building the API with e.g.
+//
+//	go build -ldflags "-X workflow-code-test/api/pkg/version.Version=v1.2.0 -X workflow-code-test/api/pkg/version.Commit=$(git rev-parse HEAD)"
+//
+// Commit and BuildTime default to the VCS information stamped by go build, if any.
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildTime = ""
+)
+
+// Info is the build metadata of the API.
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuildTime string `json:"buildTime,omitempty"`
+	// Modified is true if the commit was built with uncommitted changes.
+	Modified  bool   `json:"modified,omitempty"`
+	GoVersion string `json:"goVersion"`
+}
+
+// Get returns the build metadata of the API.
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+	}
+
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+
+	for _, setting := range build.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			if info.Commit == "" {
+				info.Commit = setting.Value
+			}
+		case "vcs.time":
+			if info.BuildTime == "" {
+				info.BuildTime = setting.Value
+			}
+		case "vcs.modified":
+			info.Modified = setting.Value == "true"
+		}
+	}
+
+	return info
+}
+
+// Handler serves the build metadata of the API.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		render.JSON(w, r, http.StatusOK, Get())
+	})
+}
@@ -0,0 +1,39 @@
+package version_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+	"workflow-code-test/api/pkg/version"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGet(t *testing.T) {
+	defer func(v, c, b string) {
+		version.Version, version.Commit, version.BuildTime = v, c, b
+	}(version.Version, version.Commit, version.BuildTime)
+
+	version.Version = "v1.2.0"
+	version.Commit = "0123456789abcdef"
+	version.BuildTime = "2025-07-14T09:00:00Z"
+
+	info := version.Get()
+	require.Equal(t, "v1.2.0", info.Version)
+	require.Equal(t, "0123456789abcdef", info.Commit)
+	require.Equal(t, "2025-07-14T09:00:00Z", info.BuildTime)
+	require.Equal(t, runtime.Version(), info.GoVersion)
+}
+
+func TestHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	version.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/version", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var info version.Info
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &info))
+	require.Equal(t, version.Get(), info)
+}
@@ -0,0 +1,60 @@
+package webhook
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Map builds the workflow input from a JSON payload.
+// mapping maps each input variable to the dot separated path of its value in the payload,
+// e.g. "alert.location.city" or "recipients.0.email". Variables whose path is missing from the payload are left out,
+// so the workflow's form validation reports them. An empty mapping uses the payload object as the input.
+func Map(payload any, mapping map[string]string) (map[string]any, error) {
+	if len(mapping) == 0 {
+		input, ok := payload.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("payload must be a JSON object, got %T", payload)
+		}
+		return input, nil
+	}
+
+	input := make(map[string]any, len(mapping))
+	for variable, path := range mapping {
+		if value, ok := lookup(payload, path); ok {
+			input[variable] = value
+		}
+	}
+
+	return input, nil
+}
+
+// lookup returns the value at the dot separated path within value.
+func lookup(value any, path string) (any, bool) {
+	if path == "" {
+		return value, true
+	}
+
+	for _, key := range strings.Split(path, ".") {
+		switch v := value.(type) {
+		case map[string]any:
+			next, ok := v[key]
+			if !ok {
+				return nil, false
+			}
+			value = next
+
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			value = v[i]
+
+		default:
+			return nil, false
+		}
+	}
+
+	return value, true
+}
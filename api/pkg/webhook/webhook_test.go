@@ -0,0 +1,152 @@
+package webhook_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+	"workflow-code-test/api/pkg/webhook"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify(t *testing.T) {
+	const secret = "whsec_test"
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	body := []byte(`{"city":"Sydney"}`)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	signature := webhook.Sign(secret, timestamp, "nonce-1", body)
+
+	tests := []struct {
+		name          string
+		secret        string
+		timestamp     string
+		nonce         string
+		signature     string
+		body          []byte
+		expectedError error
+	}{
+		{
+			name:      "valid signature",
+			secret:    secret,
+			timestamp: timestamp,
+			nonce:     "nonce-1",
+			signature: signature,
+			body:      body,
+		},
+		{
+			name:          "wrong secret",
+			secret:        "whsec_other",
+			timestamp:     timestamp,
+			nonce:         "nonce-1",
+			signature:     signature,
+			body:          body,
+			expectedError: webhook.ErrInvalidSignature,
+		},
+		{
+			name:          "tampered body",
+			secret:        secret,
+			timestamp:     timestamp,
+			nonce:         "nonce-1",
+			signature:     signature,
+			body:          []byte(`{"city":"Perth"}`),
+			expectedError: webhook.ErrInvalidSignature,
+		},
+		{
+			name:          "swapped nonce",
+			secret:        secret,
+			timestamp:     timestamp,
+			nonce:         "nonce-2",
+			signature:     signature,
+			body:          body,
+			expectedError: webhook.ErrInvalidSignature,
+		},
+		{
+			name:          "stale timestamp",
+			secret:        secret,
+			timestamp:     strconv.FormatInt(now.Add(-10*time.Minute).Unix(), 10),
+			nonce:         "nonce-1",
+			signature:     webhook.Sign(secret, strconv.FormatInt(now.Add(-10*time.Minute).Unix(), 10), "nonce-1", body),
+			body:          body,
+			expectedError: webhook.ErrTimestampExpired,
+		},
+		{
+			name:          "missing nonce",
+			secret:        secret,
+			timestamp:     timestamp,
+			signature:     signature,
+			body:          body,
+			expectedError: webhook.ErrMissingHeaders,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := webhook.Verify(tt.secret, tt.timestamp, tt.nonce, tt.signature, tt.body, now, 5*time.Minute)
+			require.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestMap(t *testing.T) {
+	payload := map[string]any{
+		"alert": map[string]any{
+			"location":  map[string]any{"city": "Sydney"},
+			"threshold": 25.0,
+		},
+		"recipients": []any{
+			map[string]any{"email": "alice@example.com"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		payload  any
+		mapping  map[string]string
+		expected map[string]any
+		errorMsg string
+	}{
+		{
+			name:    "nested paths",
+			payload: payload,
+			mapping: map[string]string{
+				"city":      "alert.location.city",
+				"threshold": "alert.threshold",
+				"email":     "recipients.0.email",
+			},
+			expected: map[string]any{
+				"city":      "Sydney",
+				"threshold": 25.0,
+				"email":     "alice@example.com",
+			},
+		},
+		{
+			name:     "missing paths are left out",
+			payload:  payload,
+			mapping:  map[string]string{"city": "alert.city", "email": "recipients.1.email"},
+			expected: map[string]any{},
+		},
+		{
+			name:     "empty mapping uses the payload",
+			payload:  map[string]any{"city": "Sydney"},
+			expected: map[string]any{"city": "Sydney"},
+		},
+		{
+			name:     "empty mapping requires an object",
+			payload:  []any{"Sydney"},
+			errorMsg: "payload must be a JSON object",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input, err := webhook.Map(tt.payload, tt.mapping)
+			if tt.errorMsg != "" {
+				require.ErrorContains(t, err, tt.errorMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, input)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// Command greeting is a reference node plugin building a greeting for a name.
+//
+// It serves the plugin protocol over stdin/stdout when launched by the API, e.g.
+// NODE_PLUGINS="go run ./plugins/greeting", or over TCP when started with -listen and
+// registered as NODE_PLUGINS="tcp://localhost:9100".
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"workflow-code-test/api/pkg/nodes/plugin"
+	"workflow-code-test/api/pkg/nodes/types"
+)
+
+const (
+	NameKey     string = "name"
+	GreetingKey string = "greeting"
+
+	defaultGreeting = "Hello"
+)
+
+var descriptor = types.Descriptor{
+	Kind:        "greeting",
+	Description: "Builds a greeting for a name.",
+	Inputs: []types.Property{
+		{Name: NameKey, Type: types.ValueTypeString, Required: true, Description: "Name to greet."},
+	},
+	Outputs: []types.Property{
+		{Name: "message", Type: types.ValueTypeString, Description: "The greeting."},
+	},
+	Metadata: []types.Property{
+		{Name: GreetingKey, Type: types.ValueTypeString, Description: "Greeting word, defaults to Hello."},
+	},
+}
+
+type handler struct{}
+
+// Describe implements plugin.Handler.
+func (h *handler) Describe() types.Descriptor {
+	return descriptor
+}
+
+// Validate implements plugin.Handler.
+func (h *handler) Validate(args map[string]any, argsCheck []string) error {
+	return descriptor.Validate(args, argsCheck)
+}
+
+// Execute implements plugin.Handler.
+func (h *handler) Execute(ctx context.Context, args map[string]any, outputFields []string) (map[string]any, error) {
+	name, ok := args[NameKey].(string)
+	if !ok {
+		return nil, fmt.Errorf("%s: name must be a string", descriptor.Kind)
+	}
+
+	greeting, ok := args[GreetingKey].(string)
+	if !ok || greeting == "" {
+		greeting = defaultGreeting
+	}
+
+	// Hardcoded for now to explicitly there should be one output from the greeting
+	if len(outputFields) != 1 {
+		return nil, fmt.Errorf("%s: output should only contain one variable, outputs: %+v", descriptor.Kind, outputFields)
+	}
+
+	result := map[string]any{}
+	for _, field := range outputFields {
+		result[field] = fmt.Sprintf("%s, %s!", greeting, name)
+	}
+
+	return result, nil
+}
+
+func main() {
+	listen := flag.String("listen", "", "serve over TCP on this address instead of stdin/stdout")
+	flag.Parse()
+
+	// stdout carries the protocol, so logs go to stderr
+	log.SetOutput(os.Stderr)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if *listen == "" {
+		if err := plugin.Serve(ctx, os.Stdin, os.Stdout, &handler{}); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	ln, err := net.Listen("tcp", *listen)
+	if err != nil {
+		log.Fatal(err)
+	}
+	go func() {
+		<-ctx.Done()
+		ln.Close()
+	}()
+
+	log.Printf("greeting plugin listening on %s", ln.Addr())
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Fatal(err)
+		}
+
+		go func() {
+			defer conn.Close()
+			if err := plugin.Serve(ctx, conn, conn, &handler{}); err != nil {
+				log.Print(err)
+			}
+		}()
+	}
+}